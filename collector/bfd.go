@@ -0,0 +1,213 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	bfdSubsystem        = "bfd"
+	bfdPeerMetricPrefix = "bfd_peer"
+
+	bfdPeerLabels = []string{"vrf", "peer", "local", "iface", "profile"}
+	bfdDesc       = map[string]*prometheus.Desc{
+		"state":               colPromDesc(bfdPeerMetricPrefix, "state", "State of the BFD session (1 = Up, 0 = Down).", bfdPeerLabels),
+		"stateInfo":           colPromDesc(bfdPeerMetricPrefix, "state_info", "State of the BFD session, such as up, down, init or admin-down. Value is always 1.", append(bfdPeerLabels, "state")),
+		"localDiscriminator":  colPromDesc(bfdPeerMetricPrefix, "local_discriminator", "Local discriminator of the BFD session.", bfdPeerLabels),
+		"remoteDiscriminator": colPromDesc(bfdPeerMetricPrefix, "remote_discriminator", "Remote discriminator of the BFD session, which is 0 until the session is established.", bfdPeerLabels),
+		"detectMultiplier":    colPromDesc(bfdPeerMetricPrefix, "detect_multiplier", "Configured detection multiplier of the BFD session.", bfdPeerLabels),
+		"uptime":              colPromDesc(bfdPeerMetricPrefix, "uptime_seconds", "How long has the BFD session been up.", bfdPeerLabels),
+
+		"txInterval":       colPromDesc(bfdPeerMetricPrefix, "transmit_interval_seconds", "Negotiated interval between BFD control packets sent, the greater of the local transmit and the remote receive intervals.", bfdPeerLabels),
+		"rxInterval":       colPromDesc(bfdPeerMetricPrefix, "receive_interval_seconds", "Negotiated interval between BFD control packets received, the greater of the local receive and the remote transmit intervals.", bfdPeerLabels),
+		"detectTime":       colPromDesc(bfdPeerMetricPrefix, "detection_time_seconds", "Time without BFD control packets received after which the session is declared down, the negotiated receive interval multiplied by the remote detection multiplier.", bfdPeerLabels),
+		"diagnostic":       colPromDesc(bfdPeerMetricPrefix, "diagnostic_code", "RFC 5880 diagnostic code of the last local session state change, such as 1 for control detection time expired (0 = no diagnostic).", bfdPeerLabels),
+		"remoteDiagnostic": colPromDesc(bfdPeerMetricPrefix, "remote_diagnostic_code", "RFC 5880 diagnostic code of the last remote session state change, such as 7 for administratively down (0 = no diagnostic).", bfdPeerLabels),
+
+		"sessionUp":   colPromDesc(bfdPeerMetricPrefix, "session_up_total", "Number of times the BFD session has transitioned to up.", bfdPeerLabels),
+		"sessionDown": colPromDesc(bfdPeerMetricPrefix, "session_down_total", "Number of times the BFD session has transitioned to down, which detects flaps between scrapes.", bfdPeerLabels),
+	}
+	bfdErrors      = []error{}
+	totalBFDErrors = 0.0
+
+	// bfdStates maps the status of a BFD session to the state label, as bfdd reports admin-down as shutdown.
+	bfdStates = map[string]string{
+		"shutdown": "admin-down",
+	}
+	// bfdDiagnostics maps the diagnostic of a BFD session to the diagnostic code of RFC 5880.
+	bfdDiagnostics = map[string]float64{
+		"ok":                             0,
+		"control detection time expired": 1,
+		"echo function failed":           2,
+		"neighbor signaled session down": 3,
+		"forwarding plane reset":         4,
+		"path down":                      5,
+		"concatenated path down":         6,
+		"administratively down":          7,
+		"reverse concatenated path down": 8,
+	}
+)
+
+// BFDCollector collects BFD metrics, implemented as per prometheus.Collector interface.
+type BFDCollector struct{}
+
+// NewBFDCollector returns a BFDCollector struct.
+func NewBFDCollector() *BFDCollector {
+	return &BFDCollector{}
+}
+
+// Name of the collector. Used to populate flag name.
+func (*BFDCollector) Name() string {
+	return bfdSubsystem
+}
+
+// Help describes the metrics this collector scrapes. Used to populate flag help.
+func (*BFDCollector) Help() string {
+	return "Collect BFD Metrics"
+}
+
+// EnabledByDefault describes whether this collector is enabled by default. Used to populate flag default.
+func (*BFDCollector) EnabledByDefault() bool {
+	return false
+}
+
+// Describe implemented as per the prometheus.Collector interface.
+func (*BFDCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range bfdDesc {
+		ch <- desc
+	}
+}
+
+// Collect implemented as per the prometheus.Collector interface.
+func (c *BFDCollector) Collect(ch chan<- prometheus.Metric) {
+	bfdErrors = []error{}
+
+	// The profiles of the sessions are not included in the counters, so they are taken from the peers.
+	profiles := map[string]string{}
+	jsonBFDPeers, err := execVtyshCommand("-c", "show bfd peers json")
+	if err != nil {
+		bfdErrors = append(bfdErrors, fmt.Errorf("cannot get bfd peers: %s", err))
+	} else {
+		if profiles, err = processBFDPeers(ch, jsonBFDPeers); err != nil {
+			bfdErrors = append(bfdErrors, err)
+		}
+	}
+
+	jsonBFDPeerCounters, err := execVtyshCommand("-c", "show bfd peers counters json")
+	if err != nil {
+		bfdErrors = append(bfdErrors, fmt.Errorf("cannot get bfd peer counters: %s", err))
+	} else {
+		if err := processBFDPeerCounters(ch, jsonBFDPeerCounters, profiles); err != nil {
+			bfdErrors = append(bfdErrors, err)
+		}
+	}
+
+	totalBFDErrors += float64(len(bfdErrors))
+}
+
+// CollectErrors returns what errors have been gathered.
+func (*BFDCollector) CollectErrors() []error {
+	return bfdErrors
+}
+
+// CollectTotalErrors returns total errors.
+func (*BFDCollector) CollectTotalErrors() float64 {
+	return totalBFDErrors
+}
+
+// processBFDPeers returns the profiles of the sessions keyed by bfdPeer.key().
+func processBFDPeers(ch chan<- prometheus.Metric, jsonBFDPeers []byte) (map[string]string, error) {
+	var bfdPeers []bfdPeer
+	if err := json.Unmarshal(jsonBFDPeers, &bfdPeers); err != nil {
+		return nil, fmt.Errorf("cannot unmarshal bfd peers json: %s", err)
+	}
+
+	profiles := map[string]string{}
+	for _, peer := range bfdPeers {
+		profiles[peer.key()] = peer.Profile
+		// The labels are "vrf", "peer", "local", "iface", "profile"
+		labels := []string{peer.Vrf, peer.Peer, peer.Local, peer.Interface, peer.Profile}
+		state := 0.0
+		if peer.Status == "up" {
+			state = 1
+		}
+		newGauge(ch, bfdDesc["state"], state, labels...)
+		newGauge(ch, bfdDesc["stateInfo"], 1, append(labels, bfdState(peer.Status))...)
+		newGauge(ch, bfdDesc["localDiscriminator"], peer.ID, labels...)
+		newGauge(ch, bfdDesc["remoteDiscriminator"], peer.RemoteID, labels...)
+		newGauge(ch, bfdDesc["detectMultiplier"], peer.DetectMultiplier, labels...)
+		// The uptime is only included for sessions that are up, so it is 0 otherwise.
+		newGauge(ch, bfdDesc["uptime"], peer.Uptime, labels...)
+
+		// bfdd reports the configured local and the received remote intervals in milliseconds, the negotiated
+		// intervals are calculated as per RFC 5880.
+		rxInterval := math.Max(peer.ReceiveInterval, peer.RemoteTransmitInterval)
+		newGauge(ch, bfdDesc["txInterval"], math.Max(peer.TransmitInterval, peer.RemoteReceiveInterval)*0.001, labels...)
+		newGauge(ch, bfdDesc["rxInterval"], rxInterval*0.001, labels...)
+		newGauge(ch, bfdDesc["detectTime"], rxInterval*peer.RemoteDetectMultiplier*0.001, labels...)
+		if diagnostic, exist := bfdDiagnostics[peer.Diagnostic]; exist {
+			newGauge(ch, bfdDesc["diagnostic"], diagnostic, labels...)
+		}
+		if diagnostic, exist := bfdDiagnostics[peer.RemoteDiagnostic]; exist {
+			newGauge(ch, bfdDesc["remoteDiagnostic"], diagnostic, labels...)
+		}
+	}
+	return profiles, nil
+}
+
+func processBFDPeerCounters(ch chan<- prometheus.Metric, jsonBFDPeerCounters []byte, profiles map[string]string) error {
+	var bfdPeerCounters []struct {
+		bfdPeer
+		SessionUp   float64 `json:"session-up"`
+		SessionDown float64 `json:"session-down"`
+	}
+	if err := json.Unmarshal(jsonBFDPeerCounters, &bfdPeerCounters); err != nil {
+		return fmt.Errorf("cannot unmarshal bfd peer counters json: %s", err)
+	}
+
+	for _, peer := range bfdPeerCounters {
+		// The labels are "vrf", "peer", "local", "iface", "profile"
+		labels := []string{peer.Vrf, peer.Peer, peer.Local, peer.Interface, profiles[peer.key()]}
+		newCounter(ch, bfdDesc["sessionUp"], peer.SessionUp, labels...)
+		newCounter(ch, bfdDesc["sessionDown"], peer.SessionDown, labels...)
+	}
+	return nil
+}
+
+// bfdState returns the state label of a BFD session status, such as admin-down for shutdown.
+func bfdState(status string) string {
+	if state, exist := bfdStates[status]; exist {
+		return state
+	}
+	return status
+}
+
+type bfdPeer struct {
+	Peer             string
+	Local            string
+	Vrf              string
+	Interface        string
+	ID               float64
+	RemoteID         float64 `json:"remote-id"`
+	Status           string
+	DetectMultiplier float64 `json:"detect-multiplier"`
+	Uptime           float64
+	Profile          string
+	// The intervals are in milliseconds.
+	ReceiveInterval        float64 `json:"receive-interval"`
+	TransmitInterval       float64 `json:"transmit-interval"`
+	RemoteReceiveInterval  float64 `json:"remote-receive-interval"`
+	RemoteTransmitInterval float64 `json:"remote-transmit-interval"`
+	RemoteDetectMultiplier float64 `json:"remote-detect-multiplier"`
+	Diagnostic             string
+	RemoteDiagnostic       string `json:"remote-diagnostic"`
+}
+
+// key returns the key of the session, as sessions are identified by the VRF, addresses and interface.
+func (p bfdPeer) key() string {
+	return strings.Join([]string{p.Vrf, p.Peer, p.Local, p.Interface}, "|")
+}
@@ -0,0 +1,156 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var bfdPeersJSON = []byte(`[
+  {
+    "multihop":false,
+    "peer":"192.168.1.2",
+    "local":"192.168.1.1",
+    "vrf":"default",
+    "interface":"swp1",
+    "id":1234567890,
+    "remote-id":987654321,
+    "passive-mode":false,
+    "status":"up",
+    "uptime":3725,
+    "diagnostic":"ok",
+    "remote-diagnostic":"ok",
+    "receive-interval":300,
+    "transmit-interval":300,
+    "echo-receive-interval":50,
+    "echo-transmit-interval":0,
+    "detect-multiplier":3,
+    "remote-receive-interval":300,
+    "remote-transmit-interval":300,
+    "remote-echo-receive-interval":50,
+    "remote-detect-multiplier":3,
+    "profile":"fast"
+  },
+  {
+    "multihop":true,
+    "peer":"10.0.0.2",
+    "local":"10.0.0.1",
+    "vrf":"red",
+    "id":1111,
+    "remote-id":0,
+    "passive-mode":false,
+    "status":"down",
+    "downtime":12,
+    "diagnostic":"control detection time expired",
+    "remote-diagnostic":"administratively down",
+    "receive-interval":300,
+    "transmit-interval":300,
+    "echo-receive-interval":50,
+    "echo-transmit-interval":0,
+    "detect-multiplier":5,
+    "remote-receive-interval":1000,
+    "remote-transmit-interval":1000,
+    "remote-echo-receive-interval":0,
+    "remote-detect-multiplier":3
+  },
+  {
+    "multihop":false,
+    "peer":"fe80::2",
+    "vrf":"default",
+    "interface":"swp2",
+    "id":2222,
+    "remote-id":0,
+    "status":"shutdown",
+    "detect-multiplier":3
+  }
+]`)
+
+func TestProcessBFDPeers(t *testing.T) {
+	ch := make(chan prometheus.Metric, 1024)
+	if _, err := processBFDPeers(ch, bfdPeersJSON); err != nil {
+		t.Errorf("error calling processBFDPeers: %s", err)
+	}
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, map[string]float64{
+		"frr_bfd_peer_state{iface=swp1,local=192.168.1.1,peer=192.168.1.2,profile=fast,vrf=default}":                     1,
+		"frr_bfd_peer_state{iface=,local=10.0.0.1,peer=10.0.0.2,profile=,vrf=red}":                                       0,
+		"frr_bfd_peer_state{iface=swp2,local=,peer=fe80::2,profile=,vrf=default}":                                        0,
+		"frr_bfd_peer_state_info{iface=swp1,local=192.168.1.1,peer=192.168.1.2,profile=fast,state=up,vrf=default}":       1,
+		"frr_bfd_peer_state_info{iface=,local=10.0.0.1,peer=10.0.0.2,profile=,state=down,vrf=red}":                       1,
+		"frr_bfd_peer_state_info{iface=swp2,local=,peer=fe80::2,profile=,state=admin-down,vrf=default}":                  1,
+		"frr_bfd_peer_local_discriminator{iface=swp1,local=192.168.1.1,peer=192.168.1.2,profile=fast,vrf=default}":       1234567890,
+		"frr_bfd_peer_local_discriminator{iface=,local=10.0.0.1,peer=10.0.0.2,profile=,vrf=red}":                         1111,
+		"frr_bfd_peer_local_discriminator{iface=swp2,local=,peer=fe80::2,profile=,vrf=default}":                          2222,
+		"frr_bfd_peer_remote_discriminator{iface=swp1,local=192.168.1.1,peer=192.168.1.2,profile=fast,vrf=default}":      987654321,
+		"frr_bfd_peer_remote_discriminator{iface=,local=10.0.0.1,peer=10.0.0.2,profile=,vrf=red}":                        0,
+		"frr_bfd_peer_remote_discriminator{iface=swp2,local=,peer=fe80::2,profile=,vrf=default}":                         0,
+		"frr_bfd_peer_detect_multiplier{iface=swp1,local=192.168.1.1,peer=192.168.1.2,profile=fast,vrf=default}":         3,
+		"frr_bfd_peer_detect_multiplier{iface=,local=10.0.0.1,peer=10.0.0.2,profile=,vrf=red}":                           5,
+		"frr_bfd_peer_detect_multiplier{iface=swp2,local=,peer=fe80::2,profile=,vrf=default}":                            3,
+		"frr_bfd_peer_uptime_seconds{iface=swp1,local=192.168.1.1,peer=192.168.1.2,profile=fast,vrf=default}":            3725,
+		"frr_bfd_peer_uptime_seconds{iface=,local=10.0.0.1,peer=10.0.0.2,profile=,vrf=red}":                              0,
+		"frr_bfd_peer_uptime_seconds{iface=swp2,local=,peer=fe80::2,profile=,vrf=default}":                               0,
+		"frr_bfd_peer_transmit_interval_seconds{iface=swp1,local=192.168.1.1,peer=192.168.1.2,profile=fast,vrf=default}": 0.3,
+		"frr_bfd_peer_transmit_interval_seconds{iface=,local=10.0.0.1,peer=10.0.0.2,profile=,vrf=red}":                   1,
+		"frr_bfd_peer_transmit_interval_seconds{iface=swp2,local=,peer=fe80::2,profile=,vrf=default}":                    0,
+		"frr_bfd_peer_receive_interval_seconds{iface=swp1,local=192.168.1.1,peer=192.168.1.2,profile=fast,vrf=default}":  0.3,
+		"frr_bfd_peer_receive_interval_seconds{iface=,local=10.0.0.1,peer=10.0.0.2,profile=,vrf=red}":                    1,
+		"frr_bfd_peer_receive_interval_seconds{iface=swp2,local=,peer=fe80::2,profile=,vrf=default}":                     0,
+		"frr_bfd_peer_detection_time_seconds{iface=swp1,local=192.168.1.1,peer=192.168.1.2,profile=fast,vrf=default}":    0.9,
+		"frr_bfd_peer_detection_time_seconds{iface=,local=10.0.0.1,peer=10.0.0.2,profile=,vrf=red}":                      3,
+		"frr_bfd_peer_detection_time_seconds{iface=swp2,local=,peer=fe80::2,profile=,vrf=default}":                       0,
+		"frr_bfd_peer_diagnostic_code{iface=swp1,local=192.168.1.1,peer=192.168.1.2,profile=fast,vrf=default}":           0,
+		"frr_bfd_peer_diagnostic_code{iface=,local=10.0.0.1,peer=10.0.0.2,profile=,vrf=red}":                             1,
+		"frr_bfd_peer_remote_diagnostic_code{iface=swp1,local=192.168.1.1,peer=192.168.1.2,profile=fast,vrf=default}":    0,
+		"frr_bfd_peer_remote_diagnostic_code{iface=,local=10.0.0.1,peer=10.0.0.2,profile=,vrf=red}":                      7,
+	})
+}
+
+func TestProcessBFDPeerCounters(t *testing.T) {
+	bfdPeerCounters := []byte(`[
+  {
+    "multihop":false,
+    "peer":"192.168.1.2",
+    "local":"192.168.1.1",
+    "vrf":"default",
+    "interface":"swp1",
+    "control-packet-input":12450,
+    "control-packet-output":12461,
+    "echo-packet-input":0,
+    "echo-packet-output":0,
+    "session-up":3,
+    "session-down":2,
+    "zebra-notifications":7
+  },
+  {
+    "multihop":true,
+    "peer":"10.0.0.2",
+    "local":"10.0.0.1",
+    "vrf":"red",
+    "control-packet-input":0,
+    "control-packet-output":40,
+    "echo-packet-input":0,
+    "echo-packet-output":0,
+    "session-up":0,
+    "session-down":0,
+    "zebra-notifications":1
+  }
+]`)
+
+	ch := make(chan prometheus.Metric, 1024)
+	profiles := map[string]string{"default|192.168.1.2|192.168.1.1|swp1": "fast"}
+	if err := processBFDPeerCounters(ch, bfdPeerCounters, profiles); err != nil {
+		t.Errorf("error calling processBFDPeerCounters: %s", err)
+	}
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, map[string]float64{
+		"frr_bfd_peer_session_up_total{iface=swp1,local=192.168.1.1,peer=192.168.1.2,profile=fast,vrf=default}":   3,
+		"frr_bfd_peer_session_up_total{iface=,local=10.0.0.1,peer=10.0.0.2,profile=,vrf=red}":                     0,
+		"frr_bfd_peer_session_down_total{iface=swp1,local=192.168.1.1,peer=192.168.1.2,profile=fast,vrf=default}": 2,
+		"frr_bfd_peer_session_down_total{iface=,local=10.0.0.1,peer=10.0.0.2,profile=,vrf=red}":                   0,
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net"
 	"os/exec"
 	"regexp"
 	"strconv"
@@ -28,11 +29,21 @@ var (
 	totalBGP6Errors     = 0.0
 	bgpL2VPNErrors      = []error{}
 	totalBGPL2VPNErrors = 0.0
+	bgpErrorsMu         sync.Mutex
 
 	bgpPeerTypes          = kingpin.Flag("collector.bgp.peer-types", "Enable the frr_bgp_peer_types_up metric (default: disabled).").Default("False").Bool()
 	frrBGPDescKey         = kingpin.Flag("collector.bgp.peer-types.keys", "Select the keys from the JSON formatted BGP peer description of which the values will be used with the frr_bgp_peer_types_up metric. Supports multiple values (default: type).").Default("type").Strings()
 	bgpPeerDescs          = kingpin.Flag("collector.bgp.peer-descriptions", "Add the value of the desc key from the JSON formatted BGP peer description as a label to peer metrics. (default: disabled).").Default("False").Bool()
 	bgpPeerDescsText      = kingpin.Flag("collector.bgp.peer-descriptions.plain-text", "Use the full text field of the BGP peer description instead of the value of the JSON formatted desc key (default: disabled).").Default("False").Bool()
+	bgpVPN                = kingpin.Flag("collector.bgp.vpn", "Collect BGP VPNv4 metrics with the bgp collector and BGP VPNv6 metrics with the bgp6 collector (default: disabled).").Default("False").Bool()
+	bgpFlowspec           = kingpin.Flag("collector.bgp.flowspec", "Collect BGP IPv4 flowspec metrics with the bgp collector and BGP IPv6 flowspec metrics with the bgp6 collector (default: disabled).").Default("False").Bool()
+	bgpDampening          = kingpin.Flag("collector.bgp.dampening", "Collect the number of dampened and flapping BGP unicast prefixes with the bgp and bgp6 collectors (default: disabled).").Default("False").Bool()
+	bgpViews              = kingpin.Flag("collector.bgp.views", "Collect BGP unicast metrics from BGP views, such as route-server views, with the bgp and bgp6 collectors (default: disabled).").Default("False").Bool()
+	bgpFailedPeers        = kingpin.Flag("collector.bgp.failed-peers", "Enables the frr_bgp_peer_failed metric which exports BGP unicast peers that are not established along with the reason (default: disabled).").Default("False").Bool()
+	bgpListenRanges       = kingpin.Flag("collector.bgp.listen-ranges", "Enables the frr_bgp_listen_range_peers_count_total and frr_bgp_listen_limit metrics for BGP dynamic neighbors (default: disabled).").Default("False").Bool()
+	bgpLabeledUnicast     = kingpin.Flag("collector.bgp.labeled-unicast", "Collect BGP IPv4 labeled-unicast metrics with the bgp collector and BGP IPv6 labeled-unicast metrics with the bgp6 collector (default: disabled).").Default("False").Bool()
+	bgpLinkState          = kingpin.Flag("collector.bgp.link-state", "Collect BGP link-state (BGP-LS) metrics with the bgp collector (default: disabled).").Default("False").Bool()
+	bgpReceivedRoutes     = kingpin.Flag("collector.bgp.received-routes", "Enables the frr_bgp_peer_prefixes_received_pre_policy_count_total and frr_bgp_peer_prefixes_filtered_count_total metrics which export the number of prefixes received from a BGP peer before inbound policy is applied. Requires soft-reconfiguration inbound (default: disabled).").Default("False").Bool()
 	bgpAdvertisedPrefixes = kingpin.Flag("collector.bgp.advertised-prefixes", "Enables the frr_exporter_bgp_prefixes_advertised_count_total metric which exports the number of advertised prefixes to a BGP peer (default: disabled).").Default("False").Bool()
 )
 
@@ -64,11 +75,39 @@ func (*BGPCollector) Describe(ch chan<- *prometheus.Desc) {
 	for _, desc := range getBgpDesc() {
 		ch <- desc
 	}
+	for _, desc := range bgpNeighborDesc {
+		ch <- desc
+	}
 }
 
 // Collect implemented as per the prometheus.Collector interface.
 func (c *BGPCollector) Collect(ch chan<- prometheus.Metric) {
-	collectBGP(ch, "ipv4")
+	bgpErrors = []error{}
+	instances := collectBGP(ch, "ipv4", "unicast")
+	if *bgpViews {
+		collectBGPViews(ch, "ipv4", "unicast", instances)
+	}
+	if *bgpFailedPeers {
+		collectBGPFailedPeers(ch, "ipv4", "unicast")
+	}
+	if *bgpVPN {
+		collectBGPVPN(ch, "ipv4")
+	}
+	if *bgpFlowspec {
+		collectBGPFlowspec(ch, "ipv4")
+	}
+	if *bgpLabeledUnicast {
+		collectBGP(ch, "ipv4", "labeled-unicast")
+	}
+	if *bgpDampening {
+		collectBGPDampening(ch, "ipv4")
+	}
+	if *bgpLinkState {
+		collectBGPLinkState(ch)
+	}
+	if *bgpNeighbors {
+		collectBGPNeighbors(ch)
+	}
 }
 
 // CollectErrors returns what errors have been gathered.
@@ -113,7 +152,26 @@ func (*BGP6Collector) Describe(ch chan<- *prometheus.Desc) {
 
 // Collect implemented as per the prometheus.Collector interface.
 func (c *BGP6Collector) Collect(ch chan<- prometheus.Metric) {
-	collectBGP(ch, "ipv6")
+	bgp6Errors = []error{}
+	instances := collectBGP(ch, "ipv6", "unicast")
+	if *bgpViews {
+		collectBGPViews(ch, "ipv6", "unicast", instances)
+	}
+	if *bgpFailedPeers {
+		collectBGPFailedPeers(ch, "ipv6", "unicast")
+	}
+	if *bgpVPN {
+		collectBGPVPN(ch, "ipv6")
+	}
+	if *bgpFlowspec {
+		collectBGPFlowspec(ch, "ipv6")
+	}
+	if *bgpLabeledUnicast {
+		collectBGP(ch, "ipv6", "labeled-unicast")
+	}
+	if *bgpDampening {
+		collectBGPDampening(ch, "ipv6")
+	}
 }
 
 // CollectErrors returns what errors have been gathered.
@@ -163,6 +221,10 @@ func getBgpL2vpnEvpnSummary() ([]byte, error) {
 	return execVtyshCommand("-c", "show evpn vni json")
 }
 
+func getBgpL2vpnEvpnRoutes() ([]byte, error) {
+	return execVtyshCommand("-c", "show bgp l2vpn evpn route json")
+}
+
 type vxLanStats struct {
 	Vni            int
 	VxlanType      string `json:"type"`
@@ -173,17 +235,6 @@ type vxLanStats struct {
 	TenantVrf      string
 }
 
-func execVtyshCommand(args ...string) ([]byte, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), vtyshTimeout)
-	defer cancel()
-
-	output, err := exec.CommandContext(ctx, vtyshPath, args...).Output()
-	if err != nil {
-		return nil, err
-	}
-	return output, nil
-}
-
 func processBgpL2vpnEvpnSummary(ch chan<- prometheus.Metric, jsonBGPL2vpnEvpnSum []byte) error {
 	var jsonMap map[string]vxLanStats
 	bgpL2vpnDesc := getBgpL2vpnDesc()
@@ -200,18 +251,61 @@ func processBgpL2vpnEvpnSummary(ch chan<- prometheus.Metric, jsonBGPL2vpnEvpnSum
 	return nil
 }
 
+func processBgpL2vpnEvpnRoutes(ch chan<- prometheus.Metric, jsonBGPL2vpnEvpnRoutes []byte) error {
+	// The 'show bgp l2vpn evpn route json' output contains a key per route distinguisher alongside scalar keys such
+	// as bgpTableVersion. Each route distinguisher contains a key per prefix, where the prefix is formatted as
+	// "[type]:[...]", from which the route type is taken.
+	var jsonMap map[string]json.RawMessage
+	bgpL2vpnDesc := getBgpL2vpnDesc()
+	if err := json.Unmarshal(jsonBGPL2vpnEvpnRoutes, &jsonMap); err != nil {
+		return fmt.Errorf("cannot unmarshal outputs of 'show bgp l2vpn evpn route json': %s", err)
+	}
+
+	routeCount := map[string]float64{}
+	for _, rdData := range jsonMap {
+		var rdMap map[string]json.RawMessage
+		if err := json.Unmarshal(rdData, &rdMap); err != nil {
+			// Not a route distinguisher.
+			continue
+		}
+		for prefix := range rdMap {
+			if !strings.HasPrefix(prefix, "[") {
+				continue
+			}
+			if end := strings.Index(prefix, "]"); end > 1 {
+				routeCount[prefix[1:end]]++
+			}
+		}
+	}
+
+	// The EVPN routes are only held by the BGP instance of the default VRF, which imports and exports them to the VRFs.
+	for routeType, count := range routeCount {
+		// The labels are "vrf", "route_type"
+		newGauge(ch, bgpL2vpnDesc["routeCount"], count, "default", routeType)
+	}
+	return nil
+}
+
 // Collect implemented as per the prometheus.Collector interface.
 func (c *BGPL2VPNCollector) Collect(ch chan<- prometheus.Metric) {
-	collectBGP(ch, "l2vpn")
+	bgpL2VPNErrors = []error{}
+	collectBGP(ch, "l2vpn", "evpn")
 
 	jsonBGPL2vpnEvpnSum, err := getBgpL2vpnEvpnSummary()
 	if err != nil {
-		totalBGPL2VPNErrors++
-		bgpL2VPNErrors = append(bgpL2VPNErrors, fmt.Errorf("cannot execute 'show evpn vni json': %s", err))
+		addBGPError("l2vpn", fmt.Errorf("cannot execute 'show evpn vni json': %s", err))
 	} else {
 		if err := processBgpL2vpnEvpnSummary(ch, jsonBGPL2vpnEvpnSum); err != nil {
-			totalBGPL2VPNErrors++
-			bgpL2VPNErrors = append(bgpL2VPNErrors, err)
+			addBGPError("l2vpn", err)
+		}
+	}
+
+	jsonBGPL2vpnEvpnRoutes, err := getBgpL2vpnEvpnRoutes()
+	if err != nil {
+		addBGPError("l2vpn", fmt.Errorf("cannot execute 'show bgp l2vpn evpn route json': %s", err))
+	} else {
+		if err := processBgpL2vpnEvpnRoutes(ch, jsonBGPL2vpnEvpnRoutes); err != nil {
+			addBGPError("l2vpn", err)
 		}
 	}
 }
@@ -232,28 +326,46 @@ func getBgpDesc() map[string]*prometheus.Desc {
 	}
 
 	bgpLabels := []string{"vrf", "afi", "safi", "local_as"}
-	bgpPeerTypeLabels := []string{"type", "afi", "safi"}
+	bgpPeerTypeLabels := []string{"vrf", "type", "afi", "safi"}
+	bgpRDLabels := []string{"vrf", "afi", "safi", "rd"}
+	bgpRouteCountLabels := []string{"vrf", "afi", "safi"}
 	bgpPeerLabels := append(bgpLabels, "peer", "peer_as")
+	bgpPeerFailedLabels := append(bgpLabels, "peer", "peer_as", "reason")
 
 	if *bgpPeerDescs {
 		bgpPeerLabels = append(bgpLabels, "peer", "peer_as", "peer_desc")
 	}
+	bgpPeerStateLabels := append(append([]string{}, bgpPeerLabels...), "state")
 
 	bgpDesc = map[string]*prometheus.Desc{
-		"ribCount":        colPromDesc(bgpSubsystem, "rib_count_total", "Number of routes in the RIB.", bgpLabels),
-		"ribMemory":       colPromDesc(bgpSubsystem, "rib_memory_bytes", "Memory consumbed by the RIB.", bgpLabels),
-		"peerCount":       colPromDesc(bgpSubsystem, "peers_count_total", "Number peers configured.", bgpLabels),
-		"peerMemory":      colPromDesc(bgpSubsystem, "peers_memory_bytes", "Memory consumed by peers.", bgpLabels),
-		"peerGroupCount":  colPromDesc(bgpSubsystem, "peer_groups_count_total", "Number of peer groups configured.", bgpLabels),
-		"peerGroupMemory": colPromDesc(bgpSubsystem, "peer_groups_memory_bytes", "Memory consumed by peer groups.", bgpLabels),
-
-		"msgRcvd":               colPromDesc(bgpPeerMetricPrefix, "message_received_total", "Number of received messages.", bgpPeerLabels),
-		"msgSent":               colPromDesc(bgpPeerMetricPrefix, "message_sent_total", "Number of sent messages.", bgpPeerLabels),
-		"prefixReceivedCount":   colPromDesc(bgpPeerMetricPrefix, "prefixes_received_count_total", "Number of prefixes received.", bgpPeerLabels),
-		"prefixAdvertisedCount": colPromDesc(bgpPeerMetricPrefix, "prefixes_advertised_count_total", "Number of prefixes advertised.", bgpPeerLabels),
-		"state":                 colPromDesc(bgpPeerMetricPrefix, "state", "State of the peer (1 = Established, 0 = Down).", bgpPeerLabels),
-		"UptimeSec":             colPromDesc(bgpPeerMetricPrefix, "uptime_seconds", "How long has the peer been up.", bgpPeerLabels),
-		"peerTypesUp":           colPromDesc(bgpPeerMetricPrefix, "types_up", "Total Number of Peer Types that are Up.", bgpPeerTypeLabels),
+		"ribCount":         colPromDesc(bgpSubsystem, "rib_count_total", "Number of routes in the RIB.", bgpLabels),
+		"ribMemory":        colPromDesc(bgpSubsystem, "rib_memory_bytes", "Memory consumbed by the RIB.", bgpLabels),
+		"tableVersion":     colPromDesc(bgpSubsystem, "table_version", "Version of the BGP table, which increases as the table changes.", bgpLabels),
+		"peerCount":        colPromDesc(bgpSubsystem, "peers_count_total", "Number peers configured.", bgpLabels),
+		"peerMemory":       colPromDesc(bgpSubsystem, "peers_memory_bytes", "Memory consumed by peers.", bgpLabels),
+		"peerGroupCount":   colPromDesc(bgpSubsystem, "peer_groups_count_total", "Number of peer groups configured.", bgpLabels),
+		"peerGroupMemory":  colPromDesc(bgpSubsystem, "peer_groups_memory_bytes", "Memory consumed by peer groups.", bgpLabels),
+		"rdPrefixCount":    colPromDesc(bgpSubsystem, "rd_prefixes_count_total", "Number of prefixes per route distinguisher.", bgpRDLabels),
+		"flowspecRules":    colPromDesc(bgpSubsystem, "flowspec_rules_count_total", "Number of flowspec rules.", bgpRouteCountLabels),
+		"flowspecValid":    colPromDesc(bgpSubsystem, "flowspec_rules_valid_count_total", "Number of flowspec rules with a valid path.", bgpRouteCountLabels),
+		"dynamicPeers":     colPromDesc(bgpSubsystem, "dynamic_peers_count_total", "Number of dynamic peers.", bgpLabels),
+		"listenRangePeers": colPromDesc(bgpSubsystem, "listen_range_peers_count_total", "Number of dynamic peers within the listen range.", []string{"vrf", "afi", "safi", "range", "peer_group"}),
+		"listenLimit":      colPromDesc(bgpSubsystem, "listen_limit", "Maximum number of dynamic peers.", []string{"vrf", "afi", "safi"}),
+		"viewInfo":         colPromDesc(bgpSubsystem, "view_info", "BGP view, whose metrics use the view name as the vrf label. Value is always 1.", []string{"view", "afi", "safi"}),
+		"dampenedPaths":    colPromDesc(bgpSubsystem, "dampened_prefixes_count_total", "Number of prefixes suppressed by route dampening.", bgpRouteCountLabels),
+		"flapStatistics":   colPromDesc(bgpSubsystem, "flapping_prefixes_count_total", "Number of prefixes with route flap statistics.", bgpRouteCountLabels),
+
+		"msgRcvd":                 colPromDesc(bgpPeerMetricPrefix, "message_received_total", "Number of received messages.", bgpPeerLabels),
+		"msgSent":                 colPromDesc(bgpPeerMetricPrefix, "message_sent_total", "Number of sent messages.", bgpPeerLabels),
+		"prefixReceivedCount":     colPromDesc(bgpPeerMetricPrefix, "prefixes_received_count_total", "Number of prefixes received.", bgpPeerLabels),
+		"prefixAdvertisedCount":   colPromDesc(bgpPeerMetricPrefix, "prefixes_advertised_count_total", "Number of prefixes advertised.", bgpPeerLabels),
+		"prefixReceivedPrePolicy": colPromDesc(bgpPeerMetricPrefix, "prefixes_received_pre_policy_count_total", "Number of prefixes received before inbound policy is applied.", bgpPeerLabels),
+		"prefixFiltered":          colPromDesc(bgpPeerMetricPrefix, "prefixes_filtered_count_total", "Number of received prefixes filtered by inbound policy.", bgpPeerLabels),
+		"state":                   colPromDesc(bgpPeerMetricPrefix, "state", "State of the peer (1 = Established, 0 = Down).", bgpPeerLabels),
+		"stateInfo":               colPromDesc(bgpPeerMetricPrefix, "state_info", "State of the peer, such as Idle (Admin) for peers that have been shutdown. Value is always 1.", bgpPeerStateLabels),
+		"UptimeSec":               colPromDesc(bgpPeerMetricPrefix, "uptime_seconds", "How long has the peer been up.", bgpPeerLabels),
+		"peerFailed":              colPromDesc(bgpPeerMetricPrefix, "failed", "Peer that is not established, with the reason in the reason label. Value is always 1.", bgpPeerFailedLabels),
+		"peerTypesUp":             colPromDesc(bgpPeerMetricPrefix, "types_up", "Total Number of Peer Types that are Up.", bgpPeerTypeLabels),
 	}
 
 	return bgpDesc
@@ -265,53 +377,326 @@ func getBgpL2vpnDesc() map[string]*prometheus.Desc {
 	}
 	bgpL2vpnLabels := []string{"vni", "type", "vxlanIf", "tenantVrf"}
 	bgpL2vpnDesc = map[string]*prometheus.Desc{
-		"numMacs":        colPromDesc(bgpL2vpnMetricPrefix, "mac_count_total", "Number of known MAC addresses", bgpL2vpnLabels),
-		"numArpNd":       colPromDesc(bgpL2vpnMetricPrefix, "arp_nd_count_total", "Number of ARP / ND entries", bgpL2vpnLabels),
-		"numRemoteVteps": colPromDesc(bgpL2vpnMetricPrefix, "remote_vtep_count_total", "Number of known remote VTEPs", bgpL2vpnLabels),
+		// The VNI counts are deprecated in favour of the frr_evpn_vni_* metrics of the evpn collector, which also come
+		// from 'show evpn vni json'.
+		"numMacs":        colPromDesc(bgpL2vpnMetricPrefix, "mac_count_total", "Number of known MAC addresses. Deprecated, use frr_evpn_vni_macs_count_total.", bgpL2vpnLabels),
+		"numArpNd":       colPromDesc(bgpL2vpnMetricPrefix, "arp_nd_count_total", "Number of ARP / ND entries. Deprecated, use frr_evpn_vni_arp_nd_count_total.", bgpL2vpnLabels),
+		"numRemoteVteps": colPromDesc(bgpL2vpnMetricPrefix, "remote_vtep_count_total", "Number of known remote VTEPs. Deprecated, use frr_evpn_vni_remote_vteps_count_total.", bgpL2vpnLabels),
+		"routeCount":     colPromDesc(bgpL2vpnMetricPrefix, "route_count_total", "Number of EVPN routes by route type", []string{"vrf", "route_type"}),
 	}
 	return bgpL2vpnDesc
 }
 
-func collectBGP(ch chan<- prometheus.Metric, AFI string) {
-	SAFI := ""
-	errors := []error{}
-	totalErrors := 0.0
+// collectBGP collects the summary of the AFI and SAFI from all VRFs, returning the names of the BGP instances
+// that were present in the summary.
+func collectBGP(ch chan<- prometheus.Metric, AFI string, SAFI string) map[string]bool {
+	instances := map[string]bool{}
+	jsonBGPSum, err := getBGPSummary(AFI, SAFI)
+	if err != nil {
+		addBGPError(AFI, fmt.Errorf("cannot get bgp %s %s summary: %s", AFI, SAFI, err))
+	} else {
+		if err := processBGPSummary(ch, jsonBGPSum, AFI, SAFI); err != nil {
+			addBGPError(AFI, err)
+		}
+		if *bgpListenRanges && SAFI == "unicast" {
+			if err := collectBGPListenRanges(ch, jsonBGPSum, AFI, SAFI); err != nil {
+				addBGPError(AFI, err)
+			}
+		}
+		var jsonMap map[string]json.RawMessage
+		if err := json.Unmarshal(jsonBGPSum, &jsonMap); err == nil {
+			for instance := range jsonMap {
+				instances[instance] = true
+			}
+		}
+	}
+	return instances
+}
+
+// collectBGPViews collects the summary of the AFI and SAFI from BGP views (such as route-server views) that are not
+// part of the summary of all VRFs. Metrics from a view use the view name as the vrf label.
+func collectBGPViews(ch chan<- prometheus.Metric, AFI string, SAFI string, instances map[string]bool) {
+	output, err := execVtyshCommand("-c", "show bgp views")
+	if err != nil {
+		addBGPError(AFI, fmt.Errorf("cannot get bgp views: %s", err))
+		return
+	}
 
-	if (AFI == "ipv4") || (AFI == "ipv6") {
-		SAFI = "unicast"
+	bgpDesc := getBgpDesc()
+	for _, view := range parseBGPViews(output) {
+		newGauge(ch, bgpDesc["viewInfo"], 1, view, strings.ToLower(AFI), strings.ToLower(SAFI))
+		if instances[view] {
+			continue
+		}
 
-	} else if AFI == "l2vpn" {
-		SAFI = "evpn"
+		jsonBGPViewSum, err := execVtyshCommand("-c", fmt.Sprintf("show bgp view %s %s %s summary json", view, AFI, SAFI))
+		if err != nil {
+			addBGPError(AFI, fmt.Errorf("cannot get bgp view %s %s %s summary: %s", view, AFI, SAFI, err))
+			continue
+		}
+		// The summary of a single view is not keyed by the instance name, so wrap it to match the summary of
+		// all VRFs.
+		jsonBGPSum, err := json.Marshal(map[string]json.RawMessage{view: jsonBGPViewSum})
+		if err != nil {
+			addBGPError(AFI, fmt.Errorf("cannot process bgp view %s %s %s summary: %s", view, AFI, SAFI, err))
+			continue
+		}
+		if err := processBGPSummary(ch, jsonBGPSum, AFI, SAFI); err != nil {
+			addBGPError(AFI, err)
+		}
 	}
+}
 
-	jsonBGPSum, err := getBGPSummary(AFI, SAFI)
+// collectBGPLinkState collects the summary of BGP link-state. BGP-LS is not VRF aware, so only the default instance
+// is queried.
+func collectBGPLinkState(ch chan<- prometheus.Metric) {
+	jsonBGPLSSum, err := execVtyshCommand("-c", "show bgp link-state link-state summary json")
 	if err != nil {
-		totalErrors++
-		errors = append(errors, fmt.Errorf("cannot get bgp %s %s summary: %s", AFI, SAFI, err))
+		addBGPError("link-state", fmt.Errorf("cannot get bgp link-state summary: %s", err))
+		return
+	}
+	if err := processBGPLinkState(ch, jsonBGPLSSum); err != nil {
+		addBGPError("link-state", err)
+	}
+}
+
+func processBGPLinkState(ch chan<- prometheus.Metric, jsonBGPLSSum []byte) error {
+	// The summary of the default instance is not keyed by the instance name, so wrap it to match the summary of
+	// all VRFs.
+	jsonBGPSum, err := json.Marshal(map[string]json.RawMessage{"default": jsonBGPLSSum})
+	if err != nil {
+		return fmt.Errorf("cannot process bgp link-state summary: %s", err)
+	}
+	return processBGPSummary(ch, jsonBGPSum, "link-state", "link-state")
+}
+
+// parseBGPViews returns the names of the views from the output of 'show bgp views'.
+func parseBGPViews(output []byte) []string {
+	views := []string{}
+	r := regexp.MustCompile(`(?m)^\s+(\S+) \(AS\s*\d+\)`)
+	for _, match := range r.FindAllStringSubmatch(string(output), -1) {
+		// The default instance does not have a name.
+		if match[1] != "(null)" {
+			views = append(views, match[1])
+		}
+	}
+	return views
+}
+
+// collectBGPListenRanges collects the number of dynamic peers per listen range, and the listen limit, using the
+// listen ranges from the running configuration.
+func collectBGPListenRanges(ch chan<- prometheus.Metric, jsonBGPSum []byte, AFI string, SAFI string) error {
+	output, err := execVtyshCommand("-c", "show run bgpd")
+	if err != nil {
+		return fmt.Errorf("cannot get bgp running configuration: %s", err)
+	}
+	return processBGPListenRanges(ch, jsonBGPSum, parseBGPListenRanges(output), AFI, SAFI)
+}
+
+func processBGPListenRanges(ch chan<- prometheus.Metric, jsonBGPSum []byte, listen map[string]*bgpListen, AFI string, SAFI string) error {
+	var jsonMap map[string]bgpProcess
+	bgpDesc := getBgpDesc()
+	if err := json.Unmarshal(jsonBGPSum, &jsonMap); err != nil {
+		return fmt.Errorf("cannot unmarshal bgp summary json: %s", err)
+	}
+
+	for vrfName, vrfListen := range listen {
+		afiRanges := 0
+		for _, listenRange := range vrfListen.ranges {
+			_, network, err := net.ParseCIDR(listenRange.prefix)
+			if err != nil {
+				return fmt.Errorf("cannot parse bgp listen range %s: %s", listenRange.prefix, err)
+			}
+			// Listen ranges of the other AFI are skipped.
+			if (network.IP.To4() != nil) != (AFI == "ipv4") {
+				continue
+			}
+			peers := 0.0
+			for peerIP, peerData := range jsonMap[vrfName].Peers {
+				if ip := net.ParseIP(peerIP); peerData.DynamicPeer && ip != nil && network.Contains(ip) {
+					peers++
+				}
+			}
+			// The labels are "vrf", "afi", "safi", "range", "peer_group"
+			newGauge(ch, bgpDesc["listenRangePeers"], peers, strings.ToLower(vrfName), strings.ToLower(AFI), strings.ToLower(SAFI), listenRange.prefix, listenRange.peerGroup)
+			afiRanges++
+		}
+		// The limit applies to all dynamic peers of the VRF, but is only exported alongside the listen ranges of the
+		// AFI so the bgp and bgp6 collectors do not export the same time series.
+		if afiRanges > 0 {
+			newGauge(ch, bgpDesc["listenLimit"], vrfListen.limit, strings.ToLower(vrfName), strings.ToLower(AFI), strings.ToLower(SAFI))
+		}
+	}
+	return nil
+}
+
+// parseBGPListenRanges returns the listen ranges and listen limit of each VRF from the running configuration. The VRF
+// names are kept as configured so they match the keys of the bgp summary.
+func parseBGPListenRanges(output []byte) map[string]*bgpListen {
+	listen := map[string]*bgpListen{}
+	routerBGP := regexp.MustCompile(`^router bgp \d+(?: (?:vrf|view) (\S+))?`)
+	listenRange := regexp.MustCompile(`^\s+bgp listen range (\S+) peer-group (\S+)`)
+	listenLimit := regexp.MustCompile(`^\s+bgp listen limit (\d+)`)
+
+	var current *bgpListen
+	for _, line := range strings.Split(string(output), "\n") {
+		if match := routerBGP.FindStringSubmatch(line); match != nil {
+			vrfName := "default"
+			if match[1] != "" {
+				vrfName = match[1]
+			}
+			current = &bgpListen{limit: bgpListenLimitDefault}
+			listen[vrfName] = current
+			continue
+		}
+		if current == nil {
+			continue
+		}
+		if match := listenRange.FindStringSubmatch(line); match != nil {
+			current.ranges = append(current.ranges, bgpListenRange{prefix: match[1], peerGroup: match[2]})
+		} else if match := listenLimit.FindStringSubmatch(line); match != nil {
+			current.limit, _ = strconv.ParseFloat(match[1], 64)
+		}
+	}
+
+	// Only VRFs with listen ranges are of interest.
+	for vrfName, vrfListen := range listen {
+		if len(vrfListen.ranges) == 0 {
+			delete(listen, vrfName)
+		}
+	}
+	return listen
+}
+
+// collectBGPFailedPeers collects the peers of the AFI and SAFI that are not established.
+func collectBGPFailedPeers(ch chan<- prometheus.Metric, AFI string, SAFI string) {
+	jsonBGPFailed, err := execVtyshCommand("-c", fmt.Sprintf("show bgp vrf all %s %s summary failed json", AFI, SAFI))
+	if err != nil {
+		addBGPError(AFI, fmt.Errorf("cannot get bgp %s %s failed summary: %s", AFI, SAFI, err))
 	} else {
-		if err := processBGPSummary(ch, jsonBGPSum, AFI, SAFI); err != nil {
-			totalErrors++
-			errors = append(errors, err)
+		if err := processBGPFailedSummary(ch, jsonBGPFailed, AFI, SAFI); err != nil {
+			addBGPError(AFI, err)
 		}
 	}
+}
 
-	if AFI == "ipv4" {
-		bgpErrors = errors
-		if totalErrors > 0 {
-			totalBGPErrors += totalErrors
+func processBGPFailedSummary(ch chan<- prometheus.Metric, jsonBGPFailed []byte, AFI string, SAFI string) error {
+	var jsonMap map[string]bgpProcess
+	bgpDesc := getBgpDesc()
+	if err := json.Unmarshal(jsonBGPFailed, &jsonMap); err != nil {
+		return fmt.Errorf("cannot unmarshal bgp failed summary json: %s", err)
+	}
+
+	for vrfName, vrfData := range jsonMap {
+		localAs := strconv.FormatInt(vrfData.AS, 10)
+		for peerIP, peerData := range vrfData.Peers {
+			// The labels are "vrf", "afi", "safi", "local_as", "peer", "peer_as", "reason"
+			peerLabels := []string{strings.ToLower(vrfName), strings.ToLower(AFI), strings.ToLower(SAFI), localAs, peerIP, strconv.FormatInt(peerData.RemoteAs, 10), peerData.LastResetDueTo}
+			newGauge(ch, bgpDesc["peerFailed"], 1, peerLabels...)
 		}
-	} else if AFI == "ipv6" {
-		bgp6Errors = errors
-		if totalErrors > 0 {
-			totalBGP6Errors += totalErrors
+	}
+	return nil
+}
+
+// collectBGPVPN collects the VPN summary and per route distinguisher prefix counts.
+func collectBGPVPN(ch chan<- prometheus.Metric, AFI string) {
+	collectBGP(ch, AFI, "vpn")
+
+	jsonBGPVPNRoutes, err := getBGPVPNRoutes(AFI)
+	if err != nil {
+		addBGPError(AFI, fmt.Errorf("cannot get bgp %s vpn routes: %s", AFI, err))
+	} else {
+		if err := processBGPVPNRoutes(ch, jsonBGPVPNRoutes, AFI); err != nil {
+			addBGPError(AFI, err)
 		}
-	} else if AFI == "l2vpn" {
-		bgpL2VPNErrors = errors
-		if totalErrors > 0 {
-			totalBGPL2VPNErrors += totalErrors
+	}
+}
+
+// collectBGPFlowspec collects the flowspec summary and the number of flowspec rules.
+func collectBGPFlowspec(ch chan<- prometheus.Metric, AFI string) {
+	collectBGP(ch, AFI, "flowspec")
+
+	jsonBGPFlowspec, err := execVtyshCommand("-c", fmt.Sprintf("show bgp vrf all %s flowspec json", AFI))
+	if err != nil {
+		addBGPError(AFI, fmt.Errorf("cannot get bgp %s flowspec routes: %s", AFI, err))
+	} else {
+		if err := processBGPFlowspec(ch, jsonBGPFlowspec, AFI); err != nil {
+			addBGPError(AFI, err)
 		}
 	}
+}
+
+func processBGPFlowspec(ch chan<- prometheus.Metric, jsonBGPFlowspec []byte, AFI string) error {
+	var jsonMap map[string]bgpRoutes
+	bgpDesc := getBgpDesc()
+	if err := json.Unmarshal(jsonBGPFlowspec, &jsonMap); err != nil {
+		return fmt.Errorf("cannot unmarshal bgp %s flowspec json: %s", AFI, err)
+	}
 
+	for vrfName, vrfData := range jsonMap {
+		validRules := 0.0
+		for _, paths := range vrfData.Routes {
+			for _, path := range paths {
+				if path.Valid {
+					validRules++
+					break
+				}
+			}
+		}
+
+		// The labels are "vrf", "afi", "safi"
+		labels := []string{strings.ToLower(vrfName), strings.ToLower(AFI), "flowspec"}
+		newGauge(ch, bgpDesc["flowspecRules"], float64(len(vrfData.Routes)), labels...)
+		newGauge(ch, bgpDesc["flowspecValid"], validRules, labels...)
+	}
+	return nil
+}
+
+// collectBGPDampening collects the number of dampened and flapping unicast prefixes.
+func collectBGPDampening(ch chan<- prometheus.Metric, AFI string) {
+	for descName, dampening := range map[string]string{"dampenedPaths": "dampened-paths", "flapStatistics": "flap-statistics"} {
+		jsonBGPDampening, err := execVtyshCommand("-c", fmt.Sprintf("show bgp vrf all %s unicast dampening %s json", AFI, dampening))
+		if err != nil {
+			addBGPError(AFI, fmt.Errorf("cannot get bgp %s unicast dampening %s: %s", AFI, dampening, err))
+			continue
+		}
+		if err := processBGPDampening(ch, jsonBGPDampening, AFI, descName); err != nil {
+			addBGPError(AFI, err)
+		}
+	}
+}
+
+func processBGPDampening(ch chan<- prometheus.Metric, jsonBGPDampening []byte, AFI string, descName string) error {
+	var jsonMap map[string]bgpRoutes
+	bgpDesc := getBgpDesc()
+	if err := json.Unmarshal(jsonBGPDampening, &jsonMap); err != nil {
+		return fmt.Errorf("cannot unmarshal bgp %s unicast dampening json: %s", AFI, err)
+	}
+
+	for vrfName, vrfData := range jsonMap {
+		// The labels are "vrf", "afi", "safi"
+		newGauge(ch, bgpDesc[descName], float64(len(vrfData.Routes)), strings.ToLower(vrfName), strings.ToLower(AFI), "unicast")
+	}
+	return nil
+}
+
+func getBGPVPNRoutes(AFI string) ([]byte, error) {
+	return execVtyshCommand("-c", fmt.Sprintf("show bgp %s vpn json", AFI))
+}
+
+func processBGPVPNRoutes(ch chan<- prometheus.Metric, jsonBGPVPNRoutes []byte, AFI string) error {
+	var vpnRoutes bgpVPNRoutes
+	bgpDesc := getBgpDesc()
+	if err := json.Unmarshal(jsonBGPVPNRoutes, &vpnRoutes); err != nil {
+		return fmt.Errorf("cannot unmarshal bgp %s vpn json: %s", AFI, err)
+	}
+
+	// The VPN routes are only held by the BGP instance of the default VRF, which imports and exports them to the VRFs.
+	for rd, prefixes := range vpnRoutes.Routes.RouteDistinguishers {
+		// The labels are "vrf", "afi", "safi", "rd"
+		newGauge(ch, bgpDesc["rdPrefixCount"], float64(len(prefixes)), "default", strings.ToLower(AFI), "vpn", rd)
+	}
+	return nil
 }
 
 func getBGPSummary(AFI string, SAFI string) ([]byte, error) {
@@ -337,7 +722,7 @@ func processBGPSummary(ch chan<- prometheus.Metric, jsonBGPSum []byte, AFI strin
 		}
 	}
 
-	peerTypes := make(map[string]float64)
+	peerTypes := make(map[[2]string]float64)
 	wgAdvertisedPrefixes := &sync.WaitGroup{}
 	for vrfName, vrfData := range jsonMap {
 		// The labels are "vrf", "afi",  "safi", "local_as"
@@ -347,20 +732,17 @@ func processBGPSummary(ch chan<- prometheus.Metric, jsonBGPSum []byte, AFI strin
 		if vrfData.PeerCount != 0 {
 			newGauge(ch, bgpDesc["ribCount"], vrfData.RIBCount, procLabels...)
 			newGauge(ch, bgpDesc["ribMemory"], vrfData.RIBMemory, procLabels...)
+			newGauge(ch, bgpDesc["tableVersion"], vrfData.TableVersion, procLabels...)
 			newGauge(ch, bgpDesc["peerCount"], vrfData.PeerCount, procLabels...)
 			newGauge(ch, bgpDesc["peerMemory"], vrfData.PeerMemory, procLabels...)
 			newGauge(ch, bgpDesc["peerGroupCount"], vrfData.PeerGroupCount, procLabels...)
 			newGauge(ch, bgpDesc["peerGroupMemory"], vrfData.PeerGroupMemory, procLabels...)
+			newGauge(ch, bgpDesc["dynamicPeers"], vrfData.DynamicPeers, procLabels...)
 
 			for peerIP, peerData := range vrfData.Peers {
 				// The labels are "vrf", "afi", "safi", "local_as", "peer", "remote_as"
 				peerLabels := []string{strings.ToLower(vrfName), strings.ToLower(AFI), strings.ToLower(SAFI), localAs, peerIP, strconv.FormatInt(peerData.RemoteAs, 10)}
 
-				if *bgpAdvertisedPrefixes {
-					wgAdvertisedPrefixes.Add(1)
-					go getPeerAdvertisedPrefixes(ch, wgAdvertisedPrefixes, AFI, SAFI, vrfName, peerIP, peerLabels...)
-				}
-
 				if *bgpPeerDescs {
 					d := ""
 					if *bgpPeerDescsText {
@@ -384,11 +766,28 @@ func processBGPSummary(ch chan<- prometheus.Metric, jsonBGPSum []byte, AFI strin
 				}
 				newGauge(ch, bgpDesc["prefixReceivedCount"], prefixReceived, peerLabels...)
 
+				// Later versions of FRR include the number of advertised prefixes as PfxSnt, otherwise each peer needs to
+				// be queried individually.
+				if peerData.PfxSnt != nil {
+					newGauge(ch, bgpDesc["prefixAdvertisedCount"], *peerData.PfxSnt, peerLabels...)
+				} else if *bgpAdvertisedPrefixes {
+					wgAdvertisedPrefixes.Add(1)
+					go getPeerAdvertisedPrefixes(ch, wgAdvertisedPrefixes, AFI, SAFI, vrfName, peerIP, peerLabels...)
+				}
+
+				// The prefixes received before inbound policy is applied (adj-RIB-in) are only available per peer, and only
+				// for peers configured with soft-reconfiguration inbound. Only unicast supports received-routes.
+				if *bgpReceivedRoutes && SAFI == "unicast" {
+					wgAdvertisedPrefixes.Add(1)
+					go getPeerReceivedRoutes(ch, wgAdvertisedPrefixes, AFI, SAFI, vrfName, peerIP, peerLabels...)
+				}
+
 				if *bgpPeerTypes {
 					for _, descKey := range *frrBGPDescKey {
 						if peerDescJSON[peerIP][descKey] != "" {
-							if _, exist := peerTypes[strings.TrimSpace(peerDescJSON[peerIP][descKey])]; !exist {
-								peerTypes[strings.TrimSpace(peerDescJSON[peerIP][descKey])] = 0
+							peerType := [2]string{strings.ToLower(vrfName), strings.TrimSpace(peerDescJSON[peerIP][descKey])}
+							if _, exist := peerTypes[peerType]; !exist {
+								peerTypes[peerType] = 0
 							}
 						}
 					}
@@ -399,12 +798,13 @@ func processBGPSummary(ch chan<- prometheus.Metric, jsonBGPSum []byte, AFI strin
 					if *bgpPeerTypes {
 						for _, descKey := range *frrBGPDescKey {
 							if peerDescJSON[peerIP][descKey] != "" {
-								peerTypes[strings.TrimSpace(peerDescJSON[peerIP][descKey])]++
+								peerTypes[[2]string{strings.ToLower(vrfName), strings.TrimSpace(peerDescJSON[peerIP][descKey])}]++
 							}
 						}
 					}
 				}
 				newGauge(ch, bgpDesc["state"], peerState, peerLabels...)
+				newGauge(ch, bgpDesc["stateInfo"], 1, append(peerLabels, bgpPeerState(peerData.State))...)
 
 			}
 		}
@@ -413,72 +813,128 @@ func processBGPSummary(ch chan<- prometheus.Metric, jsonBGPSum []byte, AFI strin
 	wgAdvertisedPrefixes.Wait()
 
 	for peerType, count := range peerTypes {
-		peerTypeLabels := []string{peerType, strings.ToLower(AFI), strings.ToLower(SAFI)}
+		// The labels are "vrf", "type", "afi", "safi"
+		peerTypeLabels := []string{peerType[0], peerType[1], strings.ToLower(AFI), strings.ToLower(SAFI)}
 		newGauge(ch, bgpDesc["peerTypesUp"], count, peerTypeLabels...)
 	}
 	return nil
 }
 
+// bgpPeerStates are the states of a peer, where Idle (Admin) is a peer that has been administratively shutdown.
+var bgpPeerStates = []string{"Idle (Admin)", "Idle", "Connect", "Active", "OpenSent", "OpenConfirm", "Established"}
+
+// bgpPeerState returns the state of a peer as one of bgpPeerStates. Other idle states, such as Idle (PfxCt) when the
+// maximum prefix count has been exceeded, are reported as Idle.
+func bgpPeerState(state string) string {
+	for _, peerState := range bgpPeerStates {
+		if strings.EqualFold(state, peerState) {
+			return peerState
+		}
+	}
+	if strings.HasPrefix(strings.ToLower(state), "idle") {
+		return "Idle"
+	}
+	return state
+}
+
 func getPeerAdvertisedPrefixes(ch chan<- prometheus.Metric, wg *sync.WaitGroup, AFI string, SAFI string, vrfName string, neighbor string, peerLabels ...string) {
 	defer wg.Done()
 
-	errors := []error{}
-	totalErrors := 0.0
-
 	args := []string{}
 	if strings.ToLower(vrfName) == "default" {
-		args = []string{"-c", fmt.Sprintf("show bgp  %s %s neighbors %s advertised-routes json", AFI, SAFI, neighbor)}
+		args = []string{"-c", fmt.Sprintf("show bgp %s %s neighbors %s advertised-routes json", AFI, SAFI, neighbor)}
 	} else {
 		args = []string{"-c", fmt.Sprintf("show bgp vrf %s %s %s neighbors %s advertised-routes json", vrfName, AFI, SAFI, neighbor)}
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), vtyshTimeout)
-	defer cancel()
-
-	output, err := exec.CommandContext(ctx, vtyshPath, args...).Output()
+	output, err := execVtyshCommand(args...)
 	if err != nil {
-		totalErrors++
-		errors = append(errors, err)
+		addBGPError(AFI, fmt.Errorf("cannot get advertised prefixes for bgp peer %s in vrf %s: %s", neighbor, vrfName, err))
+		return
 	}
 
 	var advertisedPrefixes bgpAdvertisedRoutes
 	if err := json.Unmarshal(output, &advertisedPrefixes); err != nil {
-		totalErrors++
-		errors = append(errors, err)
+		addBGPError(AFI, fmt.Errorf("cannot unmarshal advertised prefixes json for bgp peer %s in vrf %s: %s", neighbor, vrfName, err))
+		return
 	}
+	newGauge(ch, bgpDesc["prefixAdvertisedCount"], advertisedPrefixes.TotalPrefixCounter, peerLabels...)
+}
 
-	if AFI == "ipv4" {
-		bgpErrors = append(bgpErrors, errors...)
-		if totalErrors > 0 {
-			totalBGPErrors += totalErrors
-			return
-		}
-	} else if AFI == "ipv6" {
-		bgp6Errors = append(bgpErrors, errors...)
-		if totalErrors > 0 {
-			totalBGP6Errors += totalErrors
-			return
-		}
-	} else if AFI == "l2vpn" {
-		bgpL2VPNErrors = append(bgpErrors, errors...)
-		if totalErrors > 0 {
-			totalBGPL2VPNErrors += totalErrors
-			return
-		}
+func getPeerReceivedRoutes(ch chan<- prometheus.Metric, wg *sync.WaitGroup, AFI string, SAFI string, vrfName string, neighbor string, peerLabels ...string) {
+	defer wg.Done()
+
+	args := []string{}
+	if strings.ToLower(vrfName) == "default" {
+		args = []string{"-c", fmt.Sprintf("show bgp %s %s neighbors %s received-routes json", AFI, SAFI, neighbor)}
+	} else {
+		args = []string{"-c", fmt.Sprintf("show bgp vrf %s %s %s neighbors %s received-routes json", vrfName, AFI, SAFI, neighbor)}
 	}
-	newGauge(ch, bgpDesc["prefixAdvertisedCount"], advertisedPrefixes.TotalPrefixCounter, peerLabels...)
 
+	output, err := execVtyshCommand(args...)
+	if err != nil {
+		addBGPError(AFI, fmt.Errorf("cannot get received routes for bgp peer %s in vrf %s: %s", neighbor, vrfName, err))
+		return
+	}
+	if err := processBGPReceivedRoutes(ch, output, peerLabels...); err != nil {
+		addBGPError(AFI, fmt.Errorf("bgp peer %s in vrf %s: %s", neighbor, vrfName, err))
+	}
+}
+
+func processBGPReceivedRoutes(ch chan<- prometheus.Metric, jsonReceivedRoutes []byte, peerLabels ...string) error {
+	// Peers without soft-reconfiguration inbound do not keep the received routes, for which bgpd returns a warning,
+	// such as {"warning":"Inbound soft reconfiguration not enabled"}, or an empty object instead of the counters. The
+	// peer is skipped so it is not reported as having received and filtered no prefixes.
+	var receivedRoutes struct {
+		Warning               string
+		TotalPrefixCounter    *float64 `json:"totalPrefixCounter"`
+		FilteredPrefixCounter *float64 `json:"filteredPrefixCounter"`
+	}
+	if err := json.Unmarshal(jsonReceivedRoutes, &receivedRoutes); err != nil {
+		return fmt.Errorf("cannot unmarshal received routes json: %s", err)
+	}
+	if receivedRoutes.Warning != "" || receivedRoutes.TotalPrefixCounter == nil {
+		return nil
+	}
+	bgpDesc := getBgpDesc()
+	newGauge(ch, bgpDesc["prefixReceivedPrePolicy"], *receivedRoutes.TotalPrefixCounter, peerLabels...)
+	if receivedRoutes.FilteredPrefixCounter != nil {
+		newGauge(ch, bgpDesc["prefixFiltered"], *receivedRoutes.FilteredPrefixCounter, peerLabels...)
+	}
+	return nil
+}
+
+// addBGPError records an error against the collector responsible for the AFI. It is safe to call from the goroutines
+// gathering advertised prefixes.
+func addBGPError(AFI string, err error) {
+	bgpErrorsMu.Lock()
+	defer bgpErrorsMu.Unlock()
+
+	switch AFI {
+	case "ipv4", "link-state":
+		// BGP link-state is collected by the bgp collector.
+		bgpErrors = append(bgpErrors, err)
+		totalBGPErrors++
+	case "ipv6":
+		bgp6Errors = append(bgp6Errors, err)
+		totalBGP6Errors++
+	case "l2vpn":
+		bgpL2VPNErrors = append(bgpL2VPNErrors, err)
+		totalBGPL2VPNErrors++
+	}
 }
 
 type bgpProcess struct {
 	RouterID        string
 	AS              int64
+	TableVersion    float64
 	RIBCount        float64
 	RIBMemory       float64
 	PeerCount       float64
 	PeerMemory      float64
 	PeerGroupCount  float64
 	PeerGroupMemory float64
+	DynamicPeers    float64
 	Peers           map[string]*bgpPeerSession
 }
 
@@ -490,15 +946,46 @@ type bgpPeerSession struct {
 	PeerUptimeMsec      float64
 	PrefixReceivedCount float64
 	PfxRcd              float64
+	PfxSnt              *float64
+	LastResetDueTo      string
+	DynamicPeer         bool
+}
+
+// bgpListenLimitDefault is the number of dynamic peers FRR allows if 'bgp listen limit' is not configured.
+const bgpListenLimitDefault = 100
+
+type bgpListen struct {
+	limit  float64
+	ranges []bgpListenRange
+}
+
+type bgpListenRange struct {
+	prefix    string
+	peerGroup string
 }
+
+type bgpVPNRoutes struct {
+	Routes struct {
+		RouteDistinguishers map[string]map[string]json.RawMessage `json:"routeDistinguishers"`
+	} `json:"routes"`
+}
+
+type bgpRoutes struct {
+	Routes map[string][]bgpPath `json:"routes"`
+}
+
+type bgpPath struct {
+	Valid bool `json:"valid"`
+}
+
 type bgpAdvertisedRoutes struct {
 	TotalPrefixCounter float64 `json:"totalPrefixCounter"`
 }
 
 // Returns:
-//  - Map from JSON formatted BGP peer descriptions
-//  - Plain text description of peers
-//  - Error
+//   - Map from JSON formatted BGP peer descriptions
+//   - Plain text description of peers
+//   - Error
 func getBGPPeerDesc() (map[string]map[string]string, map[string]string, error) {
 	args := []string{"-c", "show run bgpd"}
 	descJSON := make(map[string]map[string]string)
@@ -0,0 +1,453 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+var (
+	bgpPeerGroupMetricPrefix = "bgp_peer_group"
+
+	bgpNeighbors           = kingpin.Flag("collector.bgp.neighbors", "Collect per peer metrics from 'show bgp vrf all neighbors json' with the bgp collector (default: disabled).").Default("False").Bool()
+	bgpNeighborsStalePaths = kingpin.Flag("collector.bgp.neighbors.stale-paths", "Enables the frr_bgp_peer_stale_paths_count_total metric which exports the number of stale unicast paths retained for each BGP peer during graceful restart (default: disabled).").Default("False").Bool()
+
+	bgpNeighborLabels    = []string{"vrf", "local_as", "peer", "peer_as"}
+	bgpNeighborAFLabels  = append(bgpNeighborLabels, "afi", "safi")
+	bgpUpdateGroupLabels = []string{"vrf", "afi", "safi"}
+	bgpPeerGroupLabels   = []string{"vrf", "peer_group"}
+	bgpNeighborDesc      = map[string]*prometheus.Desc{
+		"peerInfo":                    colPromDesc(bgpPeerMetricPrefix, "info", "Information about the peer, such as the configured description and the hostname advertised by the peer with the hostname capability. Value is always 1.", append(bgpNeighborLabels, "desc", "peer_hostname")),
+		"capabilitiesInfo":            colPromDesc(bgpPeerMetricPrefix, "capabilities_info", "Whether capabilities have been negotiated (advertised and received) with the peer. Value is always 1.", append(bgpNeighborLabels, "four_byte_as", "addpath", "extended_nexthop", "graceful_restart")),
+		"holdTime":                    colPromDesc(bgpPeerMetricPrefix, "hold_time_seconds", "Negotiated hold time of the session to the peer.", bgpNeighborLabels),
+		"keepaliveInterval":           colPromDesc(bgpPeerMetricPrefix, "keepalive_interval_seconds", "Negotiated keepalive interval of the session to the peer.", bgpNeighborLabels),
+		"configuredHoldTime":          colPromDesc(bgpPeerMetricPrefix, "configured_hold_time_seconds", "Configured hold time of the session to the peer.", bgpNeighborLabels),
+		"configuredKeepaliveInterval": colPromDesc(bgpPeerMetricPrefix, "configured_keepalive_interval_seconds", "Configured keepalive interval of the session to the peer.", bgpNeighborLabels),
+		"connectionsEstablished":      colPromDesc(bgpPeerMetricPrefix, "connections_established_total", "Number of times the session to the peer has been established.", bgpNeighborLabels),
+		"connectionsDropped":          colPromDesc(bgpPeerMetricPrefix, "connections_dropped_total", "Number of times the established session to the peer has been dropped.", bgpNeighborLabels),
+		"lastEstablishedTimestamp":    colPromDesc(bgpPeerMetricPrefix, "last_established_timestamp_seconds", "Unix timestamp of when the session to the peer was last established.", bgpNeighborLabels),
+
+		"confederationInfo": colPromDesc(bgpPeerMetricPrefix, "confederation_info", "Peer that is a member of another sub-AS of the confederation. Value is always 1.", bgpNeighborLabels),
+		"rrClientInfo":      colPromDesc(bgpPeerMetricPrefix, "route_reflector_client_info", "Peer that is configured as a route reflector client of the address family. Value is always 1.", bgpNeighborAFLabels),
+		"rrClients":         colPromDesc(bgpSubsystem, "route_reflector_clients_count_total", "Number of route reflector clients.", bgpUpdateGroupLabels),
+
+		"conditionalAdvertise": colPromDesc(bgpPeerMetricPrefix, "conditional_advertisement_advertising", "Whether the routes of the advertise-map are advertised to the peer (1 = advertised, 0 = withdrawn).", append(bgpNeighborAFLabels, "advertise_map", "condition_map", "condition")),
+
+		"policyInfo":       colPromDesc(bgpPeerMetricPrefix, "policy_info", "Route-maps and prefix-lists applied to the peer, which are empty if not configured. Value is always 1.", append(bgpNeighborAFLabels, "route_map_in", "route_map_out", "prefix_list_in", "prefix_list_out")),
+		"defaultOriginate": colPromDesc(bgpPeerMetricPrefix, "default_originate_sent", "Whether a default route is originated to the peer with default-originate (1 = sent, 0 = not sent).", bgpNeighborAFLabels),
+
+		"prefixMax":      colPromDesc(bgpPeerMetricPrefix, "prefixes_max_count_total", "Configured maximum number of prefixes allowed from the peer.", bgpNeighborAFLabels),
+		"prefixMaxUsage": colPromDesc(bgpPeerMetricPrefix, "prefixes_max_usage_ratio", "Ratio of accepted prefixes to the configured maximum number of prefixes allowed from the peer.", bgpNeighborAFLabels),
+
+		"lastNotification": colPromDesc(bgpPeerMetricPrefix, "last_notification_timestamp_seconds", "Unix timestamp of the last notification sent to or received from the peer that reset the session.", append(bgpNeighborLabels, "direction", "code", "subcode", "reason")),
+
+		"grInfo":                  colPromDesc(bgpPeerMetricPrefix, "gr_info", "Local and remote graceful restart mode of the peer. Value is always 1.", append(bgpNeighborLabels, "local_mode", "remote_mode")),
+		"grRestartTimer":          colPromDesc(bgpPeerMetricPrefix, "gr_restart_timer_seconds", "Configured graceful restart timer.", bgpNeighborLabels),
+		"grReceivedRestartTimer":  colPromDesc(bgpPeerMetricPrefix, "gr_received_restart_timer_seconds", "Graceful restart timer received from the peer.", bgpNeighborLabels),
+		"grRestartTimerRemaining": colPromDesc(bgpPeerMetricPrefix, "gr_restart_timer_remaining_seconds", "Time remaining until the graceful restart timer of a restarting peer expires.", bgpNeighborLabels),
+		"grStalePaths":            colPromDesc(bgpPeerMetricPrefix, "stale_paths_count_total", "Number of stale paths from the peer retained during graceful restart or long-lived graceful restart.", bgpNeighborAFLabels),
+		"grRestarting":            colPromDesc(bgpPeerMetricPrefix, "gr_restarting", "Whether the peer is restarting and routes are being retained in helper mode (1 = restarting, 0 = not restarting).", bgpNeighborLabels),
+		"grEndOfRibSent":          colPromDesc(bgpPeerMetricPrefix, "gr_end_of_rib_sent", "Whether the End-of-RIB marker has been sent to the peer for the address family (1 = sent, 0 = not sent).", bgpNeighborAFLabels),
+		"grEndOfRibReceived":      colPromDesc(bgpPeerMetricPrefix, "gr_end_of_rib_received", "Whether the End-of-RIB marker has been received from the peer for the address family (1 = received, 0 = not received).", bgpNeighborAFLabels),
+		"grForwardingPreserved":   colPromDesc(bgpPeerMetricPrefix, "gr_forwarding_state_preserved", "Whether the peer preserved its forwarding state for the address family across the restart (F-bit) (1 = preserved, 0 = not preserved).", bgpNeighborAFLabels),
+		"grStalePathTimer":        colPromDesc(bgpPeerMetricPrefix, "gr_stale_path_timer_seconds", "Configured graceful restart stale path timer of the address family.", bgpNeighborAFLabels),
+		"grSelectionDeferral":     colPromDesc(bgpPeerMetricPrefix, "gr_selection_deferral_timer_seconds", "Configured graceful restart selection deferral timer of the address family.", bgpNeighborAFLabels),
+
+		"updateGroups":        colPromDesc(bgpSubsystem, "update_groups_count_total", "Number of update groups.", bgpUpdateGroupLabels),
+		"updateSubgroups":     colPromDesc(bgpSubsystem, "update_subgroups_count_total", "Number of update subgroups.", bgpUpdateGroupLabels),
+		"subgroupPacketQueue": colPromDesc(bgpSubsystem, "update_subgroup_packet_queue_length", "Number of packets queued to be sent to the peers of the update subgroup.", append(bgpUpdateGroupLabels, "update_group", "subgroup")),
+
+		"peerGroupMembers":            colPromDesc(bgpPeerGroupMetricPrefix, "members_count_total", "Number of peers in the peer group.", bgpPeerGroupLabels),
+		"peerGroupMembersEstablished": colPromDesc(bgpPeerGroupMetricPrefix, "members_established_count_total", "Number of established peers in the peer group.", bgpPeerGroupLabels),
+		"peerGroupPrefixesReceived":   colPromDesc(bgpPeerGroupMetricPrefix, "prefixes_received_count_total", "Number of prefixes received from peers in the peer group.", append(bgpPeerGroupLabels, "afi", "safi")),
+	}
+)
+
+func collectBGPNeighbors(ch chan<- prometheus.Metric) {
+	jsonBGPNeighbors, err := getBGPNeighbors()
+	if err != nil {
+		addBGPError("ipv4", fmt.Errorf("cannot get bgp neighbors: %s", err))
+	} else {
+		if err := processBGPNeighbors(ch, jsonBGPNeighbors); err != nil {
+			addBGPError("ipv4", err)
+		}
+	}
+}
+
+func getBGPNeighbors() ([]byte, error) {
+	return execVtyshCommand("-c", "show bgp vrf all neighbors json")
+}
+
+func processBGPNeighbors(ch chan<- prometheus.Metric, jsonBGPNeighbors []byte) error {
+	// Similar to 'show ip ospf vrf all interface json', each peer is added as a key on the same level as vrfName and
+	// vrfId, so the keys need to be checked to determine whether they are a peer.
+	var jsonMap map[string]map[string]json.RawMessage
+	if err := json.Unmarshal(jsonBGPNeighbors, &jsonMap); err != nil {
+		return fmt.Errorf("cannot unmarshal bgp neighbors json: %s", err)
+	}
+
+	wgStalePaths := &sync.WaitGroup{}
+	defer wgStalePaths.Wait()
+
+	for vrfName, vrfData := range jsonMap {
+		peerGroups := map[string]*bgpPeerGroupStats{}
+		// Keyed by address family, then by update group ID, then by subgroup ID. The value is the packet queue length.
+		// 'show bgp update-groups statistics' has no JSON output and only includes the number of update groups created,
+		// deleted, merged and split since bgpd started, so the current update groups are derived from the peers instead.
+		updateGroups := map[string]map[int]map[int]float64{}
+		// Keyed by address family.
+		rrClients := map[string]float64{}
+		for peerKey, peerValue := range vrfData {
+			switch peerKey {
+			case "vrfName", "vrfId":
+				// Do nothing as we do not need the value of these keys.
+				continue
+			}
+			var peer bgpNeighbor
+			if err := json.Unmarshal(peerValue, &peer); err != nil {
+				return fmt.Errorf("cannot unmarshal bgp neighbor %s json: %s", peerKey, err)
+			}
+			// The labels are "vrf", "local_as", "peer", "peer_as"
+			labels := []string{strings.ToLower(vrfName), strconv.FormatInt(peer.LocalAs, 10), peerKey, strconv.FormatInt(peer.RemoteAs, 10)}
+
+			newGauge(ch, bgpNeighborDesc["peerInfo"], 1, append(labels, peer.NbrDesc, peer.Hostname)...)
+
+			caps := peer.NeighborCapabilities
+			newGauge(ch, bgpNeighborDesc["capabilitiesInfo"], 1, append(labels, strconv.FormatBool(bgpCapabilityNegotiated(caps.FourByteAs)), strconv.FormatBool(caps.addPathNegotiated()), strconv.FormatBool(bgpCapabilityNegotiated(caps.ExtendedNexthop)), strconv.FormatBool(bgpCapabilityNegotiated(caps.GracefulRestart)))...)
+
+			newGauge(ch, bgpNeighborDesc["holdTime"], peer.BgpTimerHoldTimeMsecs*0.001, labels...)
+			newGauge(ch, bgpNeighborDesc["keepaliveInterval"], peer.BgpTimerKeepAliveIntervalMsecs*0.001, labels...)
+			// The configured timers are only included in later versions of FRR.
+			if peer.BgpTimerConfiguredHoldTimeMsecs != nil {
+				newGauge(ch, bgpNeighborDesc["configuredHoldTime"], *peer.BgpTimerConfiguredHoldTimeMsecs*0.001, labels...)
+			}
+			if peer.BgpTimerConfiguredKeepAliveIntervalMsecs != nil {
+				newGauge(ch, bgpNeighborDesc["configuredKeepaliveInterval"], *peer.BgpTimerConfiguredKeepAliveIntervalMsecs*0.001, labels...)
+			}
+
+			newCounter(ch, bgpNeighborDesc["connectionsEstablished"], peer.ConnectionsEstablished, labels...)
+			newCounter(ch, bgpNeighborDesc["connectionsDropped"], peer.ConnectionsDropped, labels...)
+			if peer.BgpTimerUpEstablishedEpoch != 0 {
+				newGauge(ch, bgpNeighborDesc["lastEstablishedTimestamp"], peer.BgpTimerUpEstablishedEpoch, labels...)
+			}
+
+			if peer.NbrConfedExternalLink {
+				newGauge(ch, bgpNeighborDesc["confederationInfo"], 1, labels...)
+			}
+
+			for afKey, af := range peer.AddressFamilyInfo {
+				afi, safi := bgpAddressFamily(afKey)
+				// The labels are "vrf", "local_as", "peer", "peer_as", "afi", "safi"
+				afLabels := append(labels, afi, safi)
+				if af.RouteReflectorClient {
+					newGauge(ch, bgpNeighborDesc["rrClientInfo"], 1, afLabels...)
+					rrClients[afKey]++
+				} else if _, exist := rrClients[afKey]; !exist {
+					rrClients[afKey] = 0
+				}
+				if af.UpdateGroupID != 0 {
+					if _, exist := updateGroups[afKey]; !exist {
+						updateGroups[afKey] = map[int]map[int]float64{}
+					}
+					if _, exist := updateGroups[afKey][af.UpdateGroupID]; !exist {
+						updateGroups[afKey][af.UpdateGroupID] = map[int]float64{}
+					}
+					// All peers of a subgroup share the same packet queue.
+					updateGroups[afKey][af.UpdateGroupID][af.SubGroupID] = af.PacketQueueLength
+				}
+				newGauge(ch, bgpNeighborDesc["policyInfo"], 1, append(afLabels, af.RouteMapForIncomingAdvertisements, af.RouteMapForOutgoingAdvertisements, af.IncomingUpdatePrefixFilterList, af.OutgoingUpdatePrefixFilterList)...)
+				// defaultSent or defaultNotSent are only included if default-originate is configured.
+				if af.DefaultSent {
+					newGauge(ch, bgpNeighborDesc["defaultOriginate"], 1, afLabels...)
+				} else if af.DefaultNotSent {
+					newGauge(ch, bgpNeighborDesc["defaultOriginate"], 0, afLabels...)
+				}
+				if advMap := af.AdvertiseMap; advMap != nil {
+					advertising := 0.0
+					if strings.ToLower(advMap.AdvertiseStatus) == "advertise" {
+						advertising = 1
+					}
+					newGauge(ch, bgpNeighborDesc["conditionalAdvertise"], advertising, append(afLabels, advMap.AdvertiseMap, advMap.ConditionMap, strings.ToLower(advMap.Condition))...)
+				}
+				if *bgpNeighborsStalePaths && safi == "unicast" {
+					wgStalePaths.Add(1)
+					go getPeerStalePaths(ch, wgStalePaths, afi, safi, vrfName, peerKey, afLabels...)
+				}
+				if af.PrefixAllowedMax > 0 {
+					newGauge(ch, bgpNeighborDesc["prefixMax"], af.PrefixAllowedMax, afLabels...)
+					newGauge(ch, bgpNeighborDesc["prefixMaxUsage"], af.AcceptedPrefixCounter/af.PrefixAllowedMax, afLabels...)
+				}
+			}
+
+			if direction := bgpNotificationDirection(peer.LastResetDueTo); direction != "" && len(peer.LastErrorCodeSubcode) == 4 {
+				lastNotification := float64(timeNow().Unix()) - peer.LastResetTimerMsecs*0.001
+				newGauge(ch, bgpNeighborDesc["lastNotification"], lastNotification, append(labels, direction, peer.LastErrorCodeSubcode[:2], peer.LastErrorCodeSubcode[2:], peer.LastNotificationReason)...)
+			}
+
+			gr := peer.GracefulRestartInfo
+			newGauge(ch, bgpNeighborDesc["grInfo"], 1, append(labels, gr.LocalGrMode, gr.RemoteGrMode)...)
+			newGauge(ch, bgpNeighborDesc["grRestartTimer"], gr.Timers.ConfiguredRestartTimer, labels...)
+			newGauge(ch, bgpNeighborDesc["grReceivedRestartTimer"], gr.Timers.ReceivedRestartTimer, labels...)
+			newGauge(ch, bgpNeighborDesc["grRestartTimerRemaining"], gr.Timers.RestartTimerRemaining, labels...)
+			grRestarting := 0.0
+			if gr.Timers.RestartTimerRemaining > 0 {
+				grRestarting = 1
+			}
+			newGauge(ch, bgpNeighborDesc["grRestarting"], grRestarting, labels...)
+
+			// The per address family graceful restart state is keyed by the address family, such as ipv4Unicast, alongside the peer
+			// level keys of gracefulRestartInfo.
+			var grPeer struct {
+				GracefulRestartInfo map[string]json.RawMessage
+			}
+			if err := json.Unmarshal(peerValue, &grPeer); err != nil {
+				return fmt.Errorf("cannot unmarshal bgp neighbor %s graceful restart json: %s", peerKey, err)
+			}
+			for afKey, afValue := range grPeer.GracefulRestartInfo {
+				afi, safi := bgpAddressFamily(afKey)
+				if safi == "" {
+					continue
+				}
+				var grAF bgpNeighborGracefulRestartAF
+				if err := json.Unmarshal(afValue, &grAF); err != nil {
+					// Some keys, such as the restart mode, are strings rather than address families.
+					continue
+				}
+				// The labels are "vrf", "local_as", "peer", "peer_as", "afi", "safi"
+				afLabels := append(labels, afi, safi)
+				eorSent := 0.0
+				if grAF.EndOfRibStatus.EndOfRibSend {
+					eorSent = 1
+				}
+				newGauge(ch, bgpNeighborDesc["grEndOfRibSent"], eorSent, afLabels...)
+				eorReceived := 0.0
+				if grAF.EndOfRibStatus.EndOfRibRecv {
+					eorReceived = 1
+				}
+				newGauge(ch, bgpNeighborDesc["grEndOfRibReceived"], eorReceived, afLabels...)
+				fBit := 0.0
+				if grAF.FBit {
+					fBit = 1
+				}
+				newGauge(ch, bgpNeighborDesc["grForwardingPreserved"], fBit, afLabels...)
+				newGauge(ch, bgpNeighborDesc["grStalePathTimer"], grAF.Timers.StalePathTimer, afLabels...)
+				newGauge(ch, bgpNeighborDesc["grSelectionDeferral"], grAF.Timers.SelectionDeferralTimer, afLabels...)
+			}
+
+			if peer.PeerGroup != "" {
+				group, exist := peerGroups[peer.PeerGroup]
+				if !exist {
+					group = &bgpPeerGroupStats{prefixesReceived: map[string]float64{}}
+					peerGroups[peer.PeerGroup] = group
+				}
+				group.members++
+				if strings.ToLower(peer.BgpState) == "established" {
+					group.established++
+				}
+				for afKey, af := range peer.AddressFamilyInfo {
+					group.prefixesReceived[afKey] += af.AcceptedPrefixCounter
+				}
+			}
+		}
+
+		for afKey, groups := range updateGroups {
+			afi, safi := bgpAddressFamily(afKey)
+			// The labels are "vrf", "afi", "safi"
+			afLabels := []string{strings.ToLower(vrfName), afi, safi}
+			subgroups := 0
+			for groupID, group := range groups {
+				for subgroupID, packetQueue := range group {
+					subgroups++
+					newGauge(ch, bgpNeighborDesc["subgroupPacketQueue"], packetQueue, append(afLabels, strconv.Itoa(groupID), strconv.Itoa(subgroupID))...)
+				}
+			}
+			newGauge(ch, bgpNeighborDesc["updateGroups"], float64(len(groups)), afLabels...)
+			newGauge(ch, bgpNeighborDesc["updateSubgroups"], float64(subgroups), afLabels...)
+		}
+
+		for afKey, clients := range rrClients {
+			afi, safi := bgpAddressFamily(afKey)
+			// The labels are "vrf", "afi", "safi"
+			newGauge(ch, bgpNeighborDesc["rrClients"], clients, strings.ToLower(vrfName), afi, safi)
+		}
+
+		for groupName, group := range peerGroups {
+			// The labels are "vrf", "peer_group"
+			groupLabels := []string{strings.ToLower(vrfName), groupName}
+			newGauge(ch, bgpNeighborDesc["peerGroupMembers"], group.members, groupLabels...)
+			newGauge(ch, bgpNeighborDesc["peerGroupMembersEstablished"], group.established, groupLabels...)
+			for afKey, prefixes := range group.prefixesReceived {
+				afi, safi := bgpAddressFamily(afKey)
+				newGauge(ch, bgpNeighborDesc["peerGroupPrefixesReceived"], prefixes, append(groupLabels, afi, safi)...)
+			}
+		}
+	}
+	return nil
+}
+
+func getPeerStalePaths(ch chan<- prometheus.Metric, wg *sync.WaitGroup, AFI string, SAFI string, vrfName string, neighbor string, afLabels ...string) {
+	defer wg.Done()
+
+	args := []string{}
+	if strings.ToLower(vrfName) == "default" {
+		args = []string{"-c", fmt.Sprintf("show bgp %s %s neighbors %s prefix-counts json", AFI, SAFI, neighbor)}
+	} else {
+		args = []string{"-c", fmt.Sprintf("show bgp vrf %s %s %s neighbors %s prefix-counts json", vrfName, AFI, SAFI, neighbor)}
+	}
+
+	output, err := execVtyshCommand(args...)
+	if err != nil {
+		addBGPError("ipv4", fmt.Errorf("cannot get prefix counts for bgp peer %s in vrf %s: %s", neighbor, vrfName, err))
+		return
+	}
+	if err := processBGPPeerPrefixCounts(ch, output, afLabels...); err != nil {
+		addBGPError("ipv4", fmt.Errorf("bgp peer %s in vrf %s: %s", neighbor, vrfName, err))
+	}
+}
+
+func processBGPPeerPrefixCounts(ch chan<- prometheus.Metric, jsonPrefixCounts []byte, afLabels ...string) error {
+	var prefixCounts struct {
+		RibTableWalkCounters map[string]float64
+	}
+	if err := json.Unmarshal(jsonPrefixCounts, &prefixCounts); err != nil {
+		return fmt.Errorf("cannot unmarshal prefix-counts json: %s", err)
+	}
+	newGauge(ch, bgpNeighborDesc["grStalePaths"], prefixCounts.RibTableWalkCounters["Stale"], afLabels...)
+	return nil
+}
+
+// bgpAddressFamily splits an addressFamilyInfo key, such as ipv4Unicast or l2VpnEvpn, into the AFI and SAFI.
+func bgpAddressFamily(key string) (string, string) {
+	key = strings.ToLower(key)
+	for _, afi := range []string{"ipv4", "ipv6", "l2vpn"} {
+		if strings.HasPrefix(key, afi) {
+			safi := strings.TrimPrefix(key, afi)
+			// Match the SAFI used in vtysh commands and the labels of the summary metrics.
+			if safi == "labeledunicast" {
+				safi = "labeled-unicast"
+			}
+			return afi, safi
+		}
+	}
+	return key, ""
+}
+
+// bgpNotificationDirection returns whether the session was last reset due to a notification that was sent or
+// received, or an empty string if the session was not reset due to a notification.
+func bgpNotificationDirection(lastResetDueTo string) string {
+	switch {
+	case strings.HasPrefix(lastResetDueTo, "Notification sent"):
+		return "sent"
+	case strings.HasPrefix(lastResetDueTo, "Notification received"):
+		return "received"
+	}
+	return ""
+}
+
+// bgpCapabilityNegotiated returns whether the capability was both advertised and received. Some capabilities are
+// objects rather than strings in some versions of FRR, in which case the capability is not considered negotiated.
+func bgpCapabilityNegotiated(capability json.RawMessage) bool {
+	var state string
+	if err := json.Unmarshal(capability, &state); err != nil {
+		return false
+	}
+	return state == "advertisedAndReceived"
+}
+
+// addPathNegotiated returns whether the addpath capability was advertised and received in either direction for any
+// address family.
+func (c bgpNeighborCapabilities) addPathNegotiated() bool {
+	for _, addPath := range c.AddPath {
+		if addPath["rxAdvertisedAndReceived"] || addPath["txAdvertisedAndReceived"] {
+			return true
+		}
+	}
+	return false
+}
+
+type bgpPeerGroupStats struct {
+	members          float64
+	established      float64
+	prefixesReceived map[string]float64
+}
+
+type bgpNeighbor struct {
+	RemoteAs                                 int64
+	LocalAs                                  int64
+	NbrDesc                                  string
+	Hostname                                 string
+	PeerGroup                                string
+	BgpState                                 string
+	NbrConfedExternalLink                    bool
+	AddressFamilyInfo                        map[string]bgpNeighborAddressFamily
+	ConnectionsEstablished                   float64
+	ConnectionsDropped                       float64
+	BgpTimerUpEstablishedEpoch               float64
+	LastResetDueTo                           string
+	LastResetTimerMsecs                      float64
+	LastErrorCodeSubcode                     string
+	LastNotificationReason                   string
+	BgpTimerHoldTimeMsecs                    float64
+	BgpTimerKeepAliveIntervalMsecs           float64
+	BgpTimerConfiguredHoldTimeMsecs          *float64
+	BgpTimerConfiguredKeepAliveIntervalMsecs *float64
+	GracefulRestartInfo                      bgpNeighborGracefulRestart
+	NeighborCapabilities                     bgpNeighborCapabilities
+}
+
+type bgpNeighborCapabilities struct {
+	FourByteAs      json.RawMessage `json:"4byteAs"`
+	AddPath         map[string]map[string]bool
+	ExtendedNexthop json.RawMessage
+	GracefulRestart json.RawMessage
+}
+
+type bgpNeighborGracefulRestart struct {
+	LocalGrMode  string
+	RemoteGrMode string
+	Timers       struct {
+		ConfiguredRestartTimer float64
+		ReceivedRestartTimer   float64
+		RestartTimerRemaining  float64
+	}
+}
+
+type bgpNeighborGracefulRestartAF struct {
+	FBit           bool `json:"fBit"`
+	EndOfRibStatus struct {
+		EndOfRibSend bool
+		EndOfRibRecv bool
+	}
+	Timers struct {
+		StalePathTimer         float64
+		SelectionDeferralTimer float64
+	}
+}
+
+type bgpNeighborAddressFamily struct {
+	UpdateGroupID                     int `json:"updateGroupId"`
+	SubGroupID                        int `json:"subGroupId"`
+	PacketQueueLength                 float64
+	RouteReflectorClient              bool
+	RouteMapForIncomingAdvertisements string
+	RouteMapForOutgoingAdvertisements string
+	IncomingUpdatePrefixFilterList    string
+	OutgoingUpdatePrefixFilterList    string
+	DefaultSent                       bool
+	DefaultNotSent                    bool
+	AdvertiseMap                      *bgpNeighborAdvertiseMap
+	PrefixAllowedMax                  float64
+	AcceptedPrefixCounter             float64
+}
+
+type bgpNeighborAdvertiseMap struct {
+	AdvertiseMap    string
+	ConditionMap    string
+	Condition       string
+	AdvertiseStatus string
+}
@@ -0,0 +1,314 @@
+package collector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	bgpNeighborsJSON = []byte(`{
+"default":{
+  "vrfId":0,
+  "vrfName":"default",
+  "192.168.0.2":{
+    "remoteAs":64513,
+    "localAs":64512,
+    "nbrExternalLink":true,
+    "nbrDesc":"transit-a",
+    "hostname":"transit-a-rtr1",
+    "hostname":"transit-a-rtr1",
+    "peerGroup":"transit",
+    "bgpVersion":4,
+    "remoteRouterId":"192.168.0.2",
+    "localRouterId":"192.168.0.1",
+    "bgpState":"Established",
+    "bgpTimerUpMsec":10000,
+    "bgpTimerUpEstablishedEpoch":1600000000,
+    "bgpTimerHoldTimeMsecs":9000,
+    "bgpTimerKeepAliveIntervalMsecs":3000,
+    "bgpTimerConfiguredHoldTimeMsecs":9000,
+    "bgpTimerConfiguredKeepAliveIntervalMsecs":3000,
+    "connectionsEstablished":3,
+    "connectionsDropped":2,
+    "lastResetDueTo":"Notification received",
+    "lastResetTimerMsecs":60000,
+    "lastResetCode":10,
+    "lastErrorCodeSubcode":"0606",
+    "lastNotificationReason":"Cease/Other Configuration Change",
+    "neighborCapabilities":{
+      "4byteAs":"advertisedAndReceived",
+      "addPath":{
+        "ipv4Unicast":{
+          "txAdvertised":true,
+          "rxAdvertisedAndReceived":true
+        }
+      },
+      "extendedNexthop":"advertised",
+      "gracefulRestart":"advertisedAndReceived",
+      "routeRefresh":"advertisedAndReceivedOldNew"
+    },
+    "gracefulRestartInfo":{
+      "localGrMode":"Helper*",
+      "remoteGrMode":"Restart",
+      "rBit":true,
+      "timers":{
+        "configuredRestartTimer":120,
+        "receivedRestartTimer":90,
+        "restartTimerRemaining":45
+      },
+      "ipv4Unicast":{
+        "fBit":true,
+        "endOfRibStatus":{
+          "endOfRibSend":true,
+          "endOfRibSentAfterUpdate":false,
+          "endOfRibRecv":false
+        },
+        "timers":{
+          "stalePathTimer":360,
+          "selectionDeferralTimer":360
+        }
+      }
+    },
+    "addressFamilyInfo":{
+      "ipv4Unicast":{
+        "peerGroupMember":"transit",
+        "updateGroupId":1,
+        "subGroupId":1,
+        "packetQueueLength":0,
+        "commAttriSentToNbr":"extendedAndStandard",
+        "acceptedPrefixCounter":10,
+        "sentPrefixCounter":4,
+        "prefixAllowedMax":40,
+        "prefixAllowedWarningThresh":75
+      }
+    }
+  },
+  "192.168.0.3":{
+    "remoteAs":64514,
+    "localAs":64512,
+    "nbrConfedExternalLink":true,
+    "peerGroup":"transit",
+    "bgpVersion":4,
+    "remoteRouterId":"0.0.0.0",
+    "localRouterId":"192.168.0.1",
+    "bgpState":"Active",
+    "bgpTimerLastRead":2000,
+    "gracefulRestartInfo":{
+      "localGrMode":"Helper*",
+      "remoteGrMode":"NotApplicable",
+      "timers":{
+        "configuredRestartTimer":120,
+        "receivedRestartTimer":0
+      }
+    },
+    "bgpTimerHoldTimeMsecs":180000,
+    "bgpTimerKeepAliveIntervalMsecs":60000,
+    "connectionsEstablished":0,
+    "connectionsDropped":0,
+    "lastResetDueTo":"Waiting for peer OPEN",
+    "lastResetTimerMsecs":2000,
+    "addressFamilyInfo":{
+      "ipv4Unicast":{
+        "peerGroupMember":"transit",
+        "updateGroupId":1,
+        "subGroupId":2,
+        "packetQueueLength":1,
+        "defaultRouteMap":"DEFAULT-OUT",
+        "defaultNotSent":true,
+        "incomingUpdatePrefixFilterList":"TRANSIT-IN",
+        "routeMapForIncomingAdvertisements":"TRANSIT-IN",
+        "routeMapForOutgoingAdvertisements":"TRANSIT-OUT",
+        "acceptedPrefixCounter":0,
+        "sentPrefixCounter":0
+      }
+    }
+  }
+}
+,
+"red":{
+  "vrfId":39,
+  "vrfName":"red",
+  "192.168.1.2":{
+    "remoteAs":64613,
+    "localAs":64612,
+    "nbrInternalLink":true,
+    "nbrDesc":"customer-b",
+    "bgpVersion":4,
+    "remoteRouterId":"192.168.1.2",
+    "localRouterId":"192.168.1.1",
+    "bgpState":"Established",
+    "bgpTimerUpMsec":20000,
+    "bgpTimerUpEstablishedEpoch":1600001000,
+    "bgpTimerHoldTimeMsecs":3000,
+    "bgpTimerKeepAliveIntervalMsecs":1000,
+    "connectionsEstablished":1,
+    "connectionsDropped":0,
+    "addressFamilyInfo":{
+      "ipv4Unicast":{
+        "updateGroupId":1,
+        "subGroupId":1,
+        "packetQueueLength":3,
+        "routeReflectorClient":true,
+        "defaultSent":true,
+        "advertiseMap":{
+          "condition":"EXIST",
+          "conditionMap":"DEFAULT-EXISTS",
+          "advertiseMap":"ANYCAST",
+          "advertiseStatus":"Withdraw"
+        },
+        "acceptedPrefixCounter":2,
+        "sentPrefixCounter":1
+      },
+      "ipv6Unicast":{
+        "acceptedPrefixCounter":1,
+        "sentPrefixCounter":0
+      }
+    }
+  }
+}
+}`)
+
+	expectedBGPNeighborMetrics = map[string]float64{
+		"frr_bgp_peer_info{desc=transit-a,local_as=64512,peer=192.168.0.2,peer_as=64513,peer_hostname=transit-a-rtr1,vrf=default}": 1.0,
+		"frr_bgp_peer_info{desc=,local_as=64512,peer=192.168.0.3,peer_as=64514,peer_hostname=,vrf=default}":                        1.0,
+		"frr_bgp_peer_info{desc=customer-b,local_as=64612,peer=192.168.1.2,peer_as=64613,peer_hostname=,vrf=red}":                  1.0,
+
+		"frr_bgp_peer_capabilities_info{addpath=true,extended_nexthop=false,four_byte_as=true,graceful_restart=true,local_as=64512,peer=192.168.0.2,peer_as=64513,vrf=default}":    1.0,
+		"frr_bgp_peer_capabilities_info{addpath=false,extended_nexthop=false,four_byte_as=false,graceful_restart=false,local_as=64512,peer=192.168.0.3,peer_as=64514,vrf=default}": 1.0,
+		"frr_bgp_peer_capabilities_info{addpath=false,extended_nexthop=false,four_byte_as=false,graceful_restart=false,local_as=64612,peer=192.168.1.2,peer_as=64613,vrf=red}":     1.0,
+
+		"frr_bgp_peer_hold_time_seconds{local_as=64512,peer=192.168.0.2,peer_as=64513,vrf=default}":                     9.0,
+		"frr_bgp_peer_hold_time_seconds{local_as=64512,peer=192.168.0.3,peer_as=64514,vrf=default}":                     180.0,
+		"frr_bgp_peer_hold_time_seconds{local_as=64612,peer=192.168.1.2,peer_as=64613,vrf=red}":                         3.0,
+		"frr_bgp_peer_keepalive_interval_seconds{local_as=64512,peer=192.168.0.2,peer_as=64513,vrf=default}":            3.0,
+		"frr_bgp_peer_keepalive_interval_seconds{local_as=64512,peer=192.168.0.3,peer_as=64514,vrf=default}":            60.0,
+		"frr_bgp_peer_keepalive_interval_seconds{local_as=64612,peer=192.168.1.2,peer_as=64613,vrf=red}":                1.0,
+		"frr_bgp_peer_configured_hold_time_seconds{local_as=64512,peer=192.168.0.2,peer_as=64513,vrf=default}":          9.0,
+		"frr_bgp_peer_configured_keepalive_interval_seconds{local_as=64512,peer=192.168.0.2,peer_as=64513,vrf=default}": 3.0,
+
+		"frr_bgp_peer_connections_established_total{local_as=64512,peer=192.168.0.2,peer_as=64513,vrf=default}":      3.0,
+		"frr_bgp_peer_connections_established_total{local_as=64512,peer=192.168.0.3,peer_as=64514,vrf=default}":      0.0,
+		"frr_bgp_peer_connections_established_total{local_as=64612,peer=192.168.1.2,peer_as=64613,vrf=red}":          1.0,
+		"frr_bgp_peer_connections_dropped_total{local_as=64512,peer=192.168.0.2,peer_as=64513,vrf=default}":          2.0,
+		"frr_bgp_peer_connections_dropped_total{local_as=64512,peer=192.168.0.3,peer_as=64514,vrf=default}":          0.0,
+		"frr_bgp_peer_connections_dropped_total{local_as=64612,peer=192.168.1.2,peer_as=64613,vrf=red}":              0.0,
+		"frr_bgp_peer_last_established_timestamp_seconds{local_as=64512,peer=192.168.0.2,peer_as=64513,vrf=default}": 1600000000.0,
+		"frr_bgp_peer_last_established_timestamp_seconds{local_as=64612,peer=192.168.1.2,peer_as=64613,vrf=red}":     1600001000.0,
+
+		"frr_bgp_peer_confederation_info{local_as=64512,peer=192.168.0.3,peer_as=64514,vrf=default}":                            1.0,
+		"frr_bgp_peer_route_reflector_client_info{afi=ipv4,local_as=64612,peer=192.168.1.2,peer_as=64613,safi=unicast,vrf=red}": 1.0,
+		"frr_bgp_route_reflector_clients_count_total{afi=ipv4,safi=unicast,vrf=default}":                                        0.0,
+		"frr_bgp_route_reflector_clients_count_total{afi=ipv4,safi=unicast,vrf=red}":                                            1.0,
+		"frr_bgp_route_reflector_clients_count_total{afi=ipv6,safi=unicast,vrf=red}":                                            0.0,
+
+		"frr_bgp_peer_conditional_advertisement_advertising{advertise_map=ANYCAST,afi=ipv4,condition=exist,condition_map=DEFAULT-EXISTS,local_as=64612,peer=192.168.1.2,peer_as=64613,safi=unicast,vrf=red}": 0.0,
+
+		"frr_bgp_peer_policy_info{afi=ipv4,local_as=64512,peer=192.168.0.2,peer_as=64513,prefix_list_in=,prefix_list_out=,route_map_in=,route_map_out=,safi=unicast,vrf=default}":                                1.0,
+		"frr_bgp_peer_policy_info{afi=ipv4,local_as=64512,peer=192.168.0.3,peer_as=64514,prefix_list_in=TRANSIT-IN,prefix_list_out=,route_map_in=TRANSIT-IN,route_map_out=TRANSIT-OUT,safi=unicast,vrf=default}": 1.0,
+		"frr_bgp_peer_policy_info{afi=ipv4,local_as=64612,peer=192.168.1.2,peer_as=64613,prefix_list_in=,prefix_list_out=,route_map_in=,route_map_out=,safi=unicast,vrf=red}":                                    1.0,
+		"frr_bgp_peer_policy_info{afi=ipv6,local_as=64612,peer=192.168.1.2,peer_as=64613,prefix_list_in=,prefix_list_out=,route_map_in=,route_map_out=,safi=unicast,vrf=red}":                                    1.0,
+		"frr_bgp_peer_default_originate_sent{afi=ipv4,local_as=64512,peer=192.168.0.3,peer_as=64514,safi=unicast,vrf=default}":                                                                                   0.0,
+		"frr_bgp_peer_default_originate_sent{afi=ipv4,local_as=64612,peer=192.168.1.2,peer_as=64613,safi=unicast,vrf=red}":                                                                                       1.0,
+
+		"frr_bgp_peer_prefixes_max_count_total{afi=ipv4,local_as=64512,peer=192.168.0.2,peer_as=64513,safi=unicast,vrf=default}": 40.0,
+		"frr_bgp_peer_prefixes_max_usage_ratio{afi=ipv4,local_as=64512,peer=192.168.0.2,peer_as=64513,safi=unicast,vrf=default}": 0.25,
+
+		"frr_bgp_peer_last_notification_timestamp_seconds{code=06,direction=received,local_as=64512,peer=192.168.0.2,peer_as=64513,reason=Cease/Other Configuration Change,subcode=06,vrf=default}": 1600003540.0,
+
+		"frr_bgp_peer_gr_info{local_as=64512,local_mode=Helper*,peer=192.168.0.2,peer_as=64513,remote_mode=Restart,vrf=default}":            1.0,
+		"frr_bgp_peer_gr_info{local_as=64512,local_mode=Helper*,peer=192.168.0.3,peer_as=64514,remote_mode=NotApplicable,vrf=default}":      1.0,
+		"frr_bgp_peer_gr_info{local_as=64612,local_mode=,peer=192.168.1.2,peer_as=64613,remote_mode=,vrf=red}":                              1.0,
+		"frr_bgp_peer_gr_restart_timer_seconds{local_as=64512,peer=192.168.0.2,peer_as=64513,vrf=default}":                                  120.0,
+		"frr_bgp_peer_gr_restart_timer_seconds{local_as=64512,peer=192.168.0.3,peer_as=64514,vrf=default}":                                  120.0,
+		"frr_bgp_peer_gr_restart_timer_seconds{local_as=64612,peer=192.168.1.2,peer_as=64613,vrf=red}":                                      0.0,
+		"frr_bgp_peer_gr_received_restart_timer_seconds{local_as=64512,peer=192.168.0.2,peer_as=64513,vrf=default}":                         90.0,
+		"frr_bgp_peer_gr_received_restart_timer_seconds{local_as=64512,peer=192.168.0.3,peer_as=64514,vrf=default}":                         0.0,
+		"frr_bgp_peer_gr_received_restart_timer_seconds{local_as=64612,peer=192.168.1.2,peer_as=64613,vrf=red}":                             0.0,
+		"frr_bgp_peer_gr_restart_timer_remaining_seconds{local_as=64512,peer=192.168.0.2,peer_as=64513,vrf=default}":                        45.0,
+		"frr_bgp_peer_gr_restart_timer_remaining_seconds{local_as=64512,peer=192.168.0.3,peer_as=64514,vrf=default}":                        0.0,
+		"frr_bgp_peer_gr_restart_timer_remaining_seconds{local_as=64612,peer=192.168.1.2,peer_as=64613,vrf=red}":                            0.0,
+		"frr_bgp_peer_gr_restarting{local_as=64512,peer=192.168.0.2,peer_as=64513,vrf=default}":                                             1.0,
+		"frr_bgp_peer_gr_restarting{local_as=64512,peer=192.168.0.3,peer_as=64514,vrf=default}":                                             0.0,
+		"frr_bgp_peer_gr_restarting{local_as=64612,peer=192.168.1.2,peer_as=64613,vrf=red}":                                                 0.0,
+		"frr_bgp_peer_gr_end_of_rib_sent{afi=ipv4,local_as=64512,peer=192.168.0.2,peer_as=64513,safi=unicast,vrf=default}":                  1.0,
+		"frr_bgp_peer_gr_end_of_rib_received{afi=ipv4,local_as=64512,peer=192.168.0.2,peer_as=64513,safi=unicast,vrf=default}":              0.0,
+		"frr_bgp_peer_gr_forwarding_state_preserved{afi=ipv4,local_as=64512,peer=192.168.0.2,peer_as=64513,safi=unicast,vrf=default}":       1.0,
+		"frr_bgp_peer_gr_stale_path_timer_seconds{afi=ipv4,local_as=64512,peer=192.168.0.2,peer_as=64513,safi=unicast,vrf=default}":         360.0,
+		"frr_bgp_peer_gr_selection_deferral_timer_seconds{afi=ipv4,local_as=64512,peer=192.168.0.2,peer_as=64513,safi=unicast,vrf=default}": 360.0,
+
+		"frr_bgp_update_groups_count_total{afi=ipv4,safi=unicast,vrf=default}":                                     1.0,
+		"frr_bgp_update_groups_count_total{afi=ipv4,safi=unicast,vrf=red}":                                         1.0,
+		"frr_bgp_update_subgroups_count_total{afi=ipv4,safi=unicast,vrf=default}":                                  2.0,
+		"frr_bgp_update_subgroups_count_total{afi=ipv4,safi=unicast,vrf=red}":                                      1.0,
+		"frr_bgp_update_subgroup_packet_queue_length{afi=ipv4,safi=unicast,subgroup=1,update_group=1,vrf=default}": 0.0,
+		"frr_bgp_update_subgroup_packet_queue_length{afi=ipv4,safi=unicast,subgroup=2,update_group=1,vrf=default}": 1.0,
+		"frr_bgp_update_subgroup_packet_queue_length{afi=ipv4,safi=unicast,subgroup=1,update_group=1,vrf=red}":     3.0,
+
+		"frr_bgp_peer_group_members_count_total{peer_group=transit,vrf=default}":                                 2.0,
+		"frr_bgp_peer_group_members_established_count_total{peer_group=transit,vrf=default}":                     1.0,
+		"frr_bgp_peer_group_prefixes_received_count_total{afi=ipv4,peer_group=transit,safi=unicast,vrf=default}": 10.0,
+	}
+)
+
+func TestBGPAddressFamily(t *testing.T) {
+	for key, expected := range map[string][2]string{
+		"ipv4Unicast":        {"ipv4", "unicast"},
+		"ipv6Vpn":            {"ipv6", "vpn"},
+		"l2VpnEvpn":          {"l2vpn", "evpn"},
+		"ipv4Flowspec":       {"ipv4", "flowspec"},
+		"ipv4Multicast":      {"ipv4", "multicast"},
+		"ipv6LabeledUnicast": {"ipv6", "labeled-unicast"},
+	} {
+		afi, safi := bgpAddressFamily(key)
+		if afi != expected[0] || safi != expected[1] {
+			t.Errorf("bgpAddressFamily(%q) expected %s/%s got %s/%s", key, expected[0], expected[1], afi, safi)
+		}
+	}
+}
+
+func TestProcessBGPNeighbors(t *testing.T) {
+	timeNow = func() time.Time { return time.Unix(1600003600, 0) }
+	defer func() { timeNow = time.Now }()
+
+	ch := make(chan prometheus.Metric, 1024)
+	if err := processBGPNeighbors(ch, bgpNeighborsJSON); err != nil {
+		t.Errorf("error calling processBGPNeighbors: %s", err)
+	}
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, expectedBGPNeighborMetrics)
+}
+
+func TestProcessBGPPeerPrefixCounts(t *testing.T) {
+	prefixCounts := []byte(`{
+  "prefixCountsFor":"192.168.0.2",
+  "multiProtocol":"IPv4 Unicast",
+  "pfxCounter":10,
+  "ribTableWalkCounters":{
+    "Adj-in":10,
+    "Damped":0,
+    "Removed":0,
+    "History":0,
+    "Stale":7,
+    "Valid":10,
+    "All RIB":10,
+    "PfxCt counted":10,
+    "PfxCt best selected":4,
+    "Useless":0
+  }
+}`)
+
+	ch := make(chan prometheus.Metric, 1024)
+	if err := processBGPPeerPrefixCounts(ch, prefixCounts, "default", "64512", "192.168.0.2", "64513", "ipv4", "unicast"); err != nil {
+		t.Errorf("error calling processBGPPeerPrefixCounts: %s", err)
+	}
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, map[string]float64{
+		"frr_bgp_peer_stale_paths_count_total{afi=ipv4,local_as=64512,peer=192.168.0.2,peer_as=64513,safi=unicast,vrf=default}": 7.0,
+	})
+}
@@ -0,0 +1,120 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	bgpNexthopMetricPrefix = "bgp_nexthop"
+
+	bgpNexthopLabels = []string{"vrf", "afi"}
+	bgpNexthopDesc   = map[string]*prometheus.Desc{
+		"nexthops":            colPromDesc(bgpSubsystem, "nexthops_count_total", "Number of nexthops tracked by BGP.", bgpNexthopLabels),
+		"nexthopsUnreachable": colPromDesc(bgpSubsystem, "nexthops_unreachable_count_total", "Number of nexthops tracked by BGP that are unreachable.", bgpNexthopLabels),
+		"nexthopValid":        colPromDesc(bgpNexthopMetricPrefix, "valid", "Whether the nexthop is reachable (1 = reachable, 0 = unreachable).", append(bgpNexthopLabels, "nexthop")),
+		"nexthopPaths":        colPromDesc(bgpNexthopMetricPrefix, "paths_count_total", "Number of paths depending on the nexthop.", append(bgpNexthopLabels, "nexthop")),
+	}
+	bgpNexthopErrors      = []error{}
+	totalBGPNexthopErrors = 0.0
+)
+
+// BGPNexthopCollector collects BGP nexthop tracking metrics, implemented as per prometheus.Collector interface.
+type BGPNexthopCollector struct{}
+
+// NewBGPNexthopCollector returns a BGPNexthopCollector struct.
+func NewBGPNexthopCollector() *BGPNexthopCollector {
+	return &BGPNexthopCollector{}
+}
+
+// Name of the collector. Used to populate flag name.
+func (*BGPNexthopCollector) Name() string {
+	return bgpSubsystem + "nexthop"
+}
+
+// Help describes the metrics this collector scrapes. Used to populate flag help.
+func (*BGPNexthopCollector) Help() string {
+	return "Collect BGP Nexthop Tracking Metrics"
+}
+
+// EnabledByDefault describes whether this collector is enabled by default. Used to populate flag default.
+func (*BGPNexthopCollector) EnabledByDefault() bool {
+	return false
+}
+
+// Describe implemented as per the prometheus.Collector interface.
+func (*BGPNexthopCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range bgpNexthopDesc {
+		ch <- desc
+	}
+}
+
+// Collect implemented as per the prometheus.Collector interface.
+func (c *BGPNexthopCollector) Collect(ch chan<- prometheus.Metric) {
+	bgpNexthopErrors = []error{}
+
+	jsonBGPNexthop, err := execVtyshCommand("-c", "show bgp vrf all nexthop json")
+	if err != nil {
+		bgpNexthopErrors = append(bgpNexthopErrors, fmt.Errorf("cannot get bgp nexthops: %s", err))
+	} else {
+		if err := processBGPNexthop(ch, jsonBGPNexthop); err != nil {
+			bgpNexthopErrors = append(bgpNexthopErrors, err)
+		}
+	}
+
+	totalBGPNexthopErrors += float64(len(bgpNexthopErrors))
+}
+
+// CollectErrors returns what errors have been gathered.
+func (*BGPNexthopCollector) CollectErrors() []error {
+	return bgpNexthopErrors
+}
+
+// CollectTotalErrors returns total errors.
+func (*BGPNexthopCollector) CollectTotalErrors() float64 {
+	return totalBGPNexthopErrors
+}
+
+func processBGPNexthop(ch chan<- prometheus.Metric, jsonBGPNexthop []byte) error {
+	// The JSON is keyed by VRF, then by address family, then by nexthop.
+	var jsonMap map[string]map[string]json.RawMessage
+	if err := json.Unmarshal(jsonBGPNexthop, &jsonMap); err != nil {
+		return fmt.Errorf("cannot unmarshal bgp nexthop json: %s", err)
+	}
+
+	for vrfName, vrfData := range jsonMap {
+		for afi, afiData := range vrfData {
+			var nexthops map[string]bgpNexthop
+			if err := json.Unmarshal(afiData, &nexthops); err != nil {
+				// Not an address family, such as the vrfId key.
+				continue
+			}
+
+			unreachable := 0.0
+			// The labels are "vrf", "afi"
+			labels := []string{strings.ToLower(vrfName), strings.ToLower(afi)}
+			for nexthopIP, nexthop := range nexthops {
+				valid := 1.0
+				if !nexthop.Valid {
+					valid = 0
+					unreachable++
+				}
+				newGauge(ch, bgpNexthopDesc["nexthopValid"], valid, append(labels, nexthopIP)...)
+				newGauge(ch, bgpNexthopDesc["nexthopPaths"], nexthop.PathCount, append(labels, nexthopIP)...)
+			}
+			newGauge(ch, bgpNexthopDesc["nexthops"], float64(len(nexthops)), labels...)
+			newGauge(ch, bgpNexthopDesc["nexthopsUnreachable"], unreachable, labels...)
+		}
+	}
+	return nil
+}
+
+type bgpNexthop struct {
+	Valid     bool
+	Complete  bool
+	IgpMetric float64
+	PathCount float64
+}
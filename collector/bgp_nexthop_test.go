@@ -0,0 +1,62 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	bgpNexthopJSON = []byte(`{
+"default":{
+  "ipv4":{
+    "192.168.0.2":{
+      "valid":true,
+      "complete":true,
+      "igpMetric":0,
+      "pathCount":12,
+      "peer":"192.168.0.2",
+      "nexthops":[{"interfaceName":"eth0"}]
+    },
+    "10.255.0.9":{
+      "valid":false,
+      "complete":false,
+      "igpMetric":0,
+      "pathCount":3
+    }
+  },
+  "ipv6":{
+    "fd00::1":{
+      "valid":true,
+      "complete":true,
+      "igpMetric":10,
+      "pathCount":1
+    }
+  }
+}
+}`)
+
+	expectedBGPNexthopMetrics = map[string]float64{
+		"frr_bgp_nexthops_count_total{afi=ipv4,vrf=default}":                          2.0,
+		"frr_bgp_nexthops_count_total{afi=ipv6,vrf=default}":                          1.0,
+		"frr_bgp_nexthops_unreachable_count_total{afi=ipv4,vrf=default}":              1.0,
+		"frr_bgp_nexthops_unreachable_count_total{afi=ipv6,vrf=default}":              0.0,
+		"frr_bgp_nexthop_valid{afi=ipv4,nexthop=192.168.0.2,vrf=default}":             1.0,
+		"frr_bgp_nexthop_valid{afi=ipv4,nexthop=10.255.0.9,vrf=default}":              0.0,
+		"frr_bgp_nexthop_valid{afi=ipv6,nexthop=fd00::1,vrf=default}":                 1.0,
+		"frr_bgp_nexthop_paths_count_total{afi=ipv4,nexthop=192.168.0.2,vrf=default}": 12.0,
+		"frr_bgp_nexthop_paths_count_total{afi=ipv4,nexthop=10.255.0.9,vrf=default}":  3.0,
+		"frr_bgp_nexthop_paths_count_total{afi=ipv6,nexthop=fd00::1,vrf=default}":     1.0,
+	}
+)
+
+func TestProcessBGPNexthop(t *testing.T) {
+	ch := make(chan prometheus.Metric, 1024)
+	if err := processBGPNexthop(ch, bgpNexthopJSON); err != nil {
+		t.Errorf("error calling processBGPNexthop: %s", err)
+	}
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, expectedBGPNexthopMetrics)
+}
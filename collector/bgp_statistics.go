@@ -0,0 +1,193 @@
+package collector
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+var (
+	bgpStatisticsMetricPrefix = "bgp_statistics"
+
+	bgpStatisticsFullTable = kingpin.Flag("collector.bgpstatistics.routes", "Collect the number of multipath (ECMP) prefixes and prefixes per prefix length with the bgpstatistics collector, which retrieves the full unicast BGP table of every VRF (default: disabled).").Default("False").Bool()
+
+	bgpStatisticsLabels = []string{"vrf", "afi", "safi"}
+	bgpStatisticsDesc   = map[string]*prometheus.Desc{
+		"totalPrefixes":       colPromDesc(bgpStatisticsMetricPrefix, "prefixes_count_total", "Number of prefixes in the BGP table.", bgpStatisticsLabels),
+		"totalAdvertisements": colPromDesc(bgpStatisticsMetricPrefix, "paths_count_total", "Number of paths in the BGP table.", bgpStatisticsLabels),
+		"averagePrefixLength": colPromDesc(bgpStatisticsMetricPrefix, "average_prefix_length", "Average prefix length of the prefixes in the BGP table.", bgpStatisticsLabels),
+		"averageAsPathLength": colPromDesc(bgpStatisticsMetricPrefix, "average_as_path_length", "Average AS path length (hops) of the paths in the BGP table.", bgpStatisticsLabels),
+		"longestAsPath":       colPromDesc(bgpStatisticsMetricPrefix, "longest_as_path_length", "Longest AS path length (hops) of the paths in the BGP table.", bgpStatisticsLabels),
+		"prefixLength":        colPromDesc(bgpStatisticsMetricPrefix, "prefix_length_prefixes_count_total", "Number of prefixes in the BGP table per prefix length.", append(bgpStatisticsLabels, "prefix_length")),
+		"multipathPrefixes":   colPromDesc(bgpStatisticsMetricPrefix, "multipath_prefixes_count_total", "Number of prefixes with more than one bestpath (ECMP).", bgpStatisticsLabels),
+	}
+	bgpStatisticsErrors      = []error{}
+	totalBGPStatisticsErrors = 0.0
+)
+
+// BGPStatisticsCollector collects BGP table statistics, implemented as per prometheus.Collector interface.
+type BGPStatisticsCollector struct{}
+
+// NewBGPStatisticsCollector returns a BGPStatisticsCollector struct.
+func NewBGPStatisticsCollector() *BGPStatisticsCollector {
+	return &BGPStatisticsCollector{}
+}
+
+// Name of the collector. Used to populate flag name.
+func (*BGPStatisticsCollector) Name() string {
+	return bgpSubsystem + "statistics"
+}
+
+// Help describes the metrics this collector scrapes. Used to populate flag help.
+func (*BGPStatisticsCollector) Help() string {
+	return "Collect BGP Table Statistics Metrics"
+}
+
+// EnabledByDefault describes whether this collector is enabled by default. Used to populate flag default.
+func (*BGPStatisticsCollector) EnabledByDefault() bool {
+	return false
+}
+
+// Describe implemented as per the prometheus.Collector interface.
+func (*BGPStatisticsCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range bgpStatisticsDesc {
+		ch <- desc
+	}
+}
+
+// Collect implemented as per the prometheus.Collector interface.
+func (c *BGPStatisticsCollector) Collect(ch chan<- prometheus.Metric) {
+	bgpStatisticsErrors = []error{}
+
+	for _, afi := range []string{"ipv4", "ipv6"} {
+		jsonBGPStatistics, err := execVtyshCommand("-c", fmt.Sprintf("show bgp vrf all %s unicast statistics json", afi))
+		if err != nil {
+			bgpStatisticsErrors = append(bgpStatisticsErrors, fmt.Errorf("cannot get bgp %s unicast statistics: %s", afi, err))
+		} else {
+			if err := processBGPStatistics(ch, jsonBGPStatistics, afi, "unicast"); err != nil {
+				bgpStatisticsErrors = append(bgpStatisticsErrors, err)
+			}
+		}
+
+		// The full BGP table is only needed for the multipath prefixes and prefix lengths, and is too large to retrieve
+		// on every scrape of routers with full tables unless enabled.
+		if *bgpStatisticsFullTable {
+			jsonBGPRoutes, err := execVtyshCommand("-c", fmt.Sprintf("show bgp vrf all %s unicast json", afi))
+			if err != nil {
+				bgpStatisticsErrors = append(bgpStatisticsErrors, fmt.Errorf("cannot get bgp %s unicast routes: %s", afi, err))
+			} else {
+				if err := processBGPStatisticsRoutes(ch, jsonBGPRoutes, afi, "unicast"); err != nil {
+					bgpStatisticsErrors = append(bgpStatisticsErrors, err)
+				}
+			}
+		}
+	}
+
+	totalBGPStatisticsErrors += float64(len(bgpStatisticsErrors))
+}
+
+// CollectErrors returns what errors have been gathered.
+func (*BGPStatisticsCollector) CollectErrors() []error {
+	return bgpStatisticsErrors
+}
+
+// CollectTotalErrors returns total errors.
+func (*BGPStatisticsCollector) CollectTotalErrors() float64 {
+	return totalBGPStatisticsErrors
+}
+
+func processBGPStatistics(ch chan<- prometheus.Metric, jsonBGPStatistics []byte, AFI string, SAFI string) error {
+	// The statistics of each instance are an element of a list keyed by the address family, such as ipv4Unicast.
+	var jsonMap map[string][]bgpTableStatistics
+	if err := json.Unmarshal(jsonBGPStatistics, &jsonMap); err != nil {
+		return fmt.Errorf("cannot unmarshal bgp %s %s statistics json: %s", AFI, SAFI, err)
+	}
+
+	for _, instances := range jsonMap {
+		for _, stats := range instances {
+			// The labels are "vrf", "afi", "safi"
+			labels := []string{bgpStatisticsInstance(stats.Instance), strings.ToLower(AFI), strings.ToLower(SAFI)}
+			newGauge(ch, bgpStatisticsDesc["totalPrefixes"], stats.TotalPrefixes, labels...)
+			newGauge(ch, bgpStatisticsDesc["totalAdvertisements"], stats.TotalAdvertisements, labels...)
+			newGauge(ch, bgpStatisticsDesc["averagePrefixLength"], stats.AveragePrefixLength, labels...)
+			newGauge(ch, bgpStatisticsDesc["averageAsPathLength"], stats.AverageAsPathLengthHops, labels...)
+			newGauge(ch, bgpStatisticsDesc["longestAsPath"], stats.LongestAsPath, labels...)
+		}
+	}
+	return nil
+}
+
+func processBGPStatisticsRoutes(ch chan<- prometheus.Metric, jsonBGPRoutes []byte, AFI string, SAFI string) error {
+	var jsonMap map[string]bgpStatisticsRoutes
+	if err := json.Unmarshal(jsonBGPRoutes, &jsonMap); err != nil {
+		return fmt.Errorf("cannot unmarshal bgp %s %s json: %s", AFI, SAFI, err)
+	}
+
+	for vrfName, vrfData := range jsonMap {
+		multipathPrefixes := 0.0
+		prefixLengths := map[string]float64{}
+		for prefix, paths := range vrfData.Routes {
+			if i := strings.LastIndex(prefix, "/"); i != -1 {
+				prefixLengths[prefix[i+1:]]++
+			}
+			bestpaths := 0
+			for _, path := range paths {
+				if path.Multipath || path.isBestpath() {
+					bestpaths++
+				}
+			}
+			if bestpaths > 1 {
+				multipathPrefixes++
+			}
+		}
+		// The labels are "vrf", "afi", "safi"
+		labels := []string{strings.ToLower(vrfName), strings.ToLower(AFI), strings.ToLower(SAFI)}
+		newGauge(ch, bgpStatisticsDesc["multipathPrefixes"], multipathPrefixes, labels...)
+		for prefixLength, prefixes := range prefixLengths {
+			// The labels are "vrf", "afi", "safi", "prefix_length"
+			newGauge(ch, bgpStatisticsDesc["prefixLength"], prefixes, append(labels, prefixLength)...)
+		}
+	}
+	return nil
+}
+
+// bgpStatisticsInstance returns the VRF name from the instance name of the statistics, such as "VRF default".
+func bgpStatisticsInstance(instance string) string {
+	return strings.ToLower(strings.TrimPrefix(instance, "VRF "))
+}
+
+type bgpTableStatistics struct {
+	Instance                string
+	TotalPrefixes           float64
+	TotalAdvertisements     float64
+	AveragePrefixLength     float64
+	AverageAsPathLengthHops float64
+	LongestAsPath           float64
+}
+
+type bgpStatisticsRoutes struct {
+	Routes map[string][]bgpStatisticsPath
+}
+
+type bgpStatisticsPath struct {
+	Multipath bool
+	Bestpath  json.RawMessage
+}
+
+// isBestpath handles bestpath being either a bool or, in later versions of FRR, an object with an overall key.
+func (p bgpStatisticsPath) isBestpath() bool {
+	if bytes.Equal(p.Bestpath, []byte("true")) {
+		return true
+	}
+	var bestpath struct {
+		Overall bool
+	}
+	if err := json.Unmarshal(p.Bestpath, &bestpath); err != nil {
+		return false
+	}
+	return bestpath.Overall
+}
@@ -0,0 +1,108 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	bgpStatisticsV4 = []byte(`{
+  "ipv4Unicast":[
+    {
+      "instance":"VRF default",
+      "totalPrefixes":3,
+      "averagePrefixLength":24,
+      "totalAdvertisements":5,
+      "unaggregateablePrefixes":3,
+      "maximumAggregateablePrefixes":0,
+      "bgpAggregateAdvertisements":0,
+      "addressSpaceAdvertised":768,
+      "advertisementsWithPaths":5,
+      "longestAsPath":3,
+      "largestAsPath":14,
+      "averageAsPathLengthHops":2,
+      "averageAsPathSizeBytes":10,
+      "highestPublicAsn":64514
+    },
+    {
+      "instance":"VRF red",
+      "totalPrefixes":1,
+      "averagePrefixLength":32,
+      "totalAdvertisements":1,
+      "advertisementsWithPaths":1,
+      "longestAsPath":1,
+      "largestAsPath":6,
+      "averageAsPathLengthHops":1,
+      "averageAsPathSizeBytes":6
+    }
+  ]
+}`)
+
+	bgpRoutesV4 = []byte(`{
+"default":{
+  "vrfId":0,
+  "vrfName":"default",
+  "routerId":"192.168.0.1",
+  "localAS":64512,
+  "routes":{
+    "10.0.0.0/24":[
+      {"valid":true,"multipath":true,"pathFrom":"external","prefix":"10.0.0.0","prefixLen":24},
+      {"valid":true,"bestpath":{"overall":true},"multipath":true,"pathFrom":"external","prefix":"10.0.0.0","prefixLen":24}
+    ],
+    "10.0.1.0/24":[
+      {"valid":true,"bestpath":true,"pathFrom":"external","prefix":"10.0.1.0","prefixLen":24},
+      {"valid":true,"pathFrom":"external","prefix":"10.0.1.0","prefixLen":24}
+    ],
+    "10.0.2.0/24":[
+      {"valid":true,"bestpath":true,"pathFrom":"external","prefix":"10.0.2.0","prefixLen":24}
+    ]
+  }
+}
+,
+"red":{
+  "vrfId":39,
+  "vrfName":"red",
+  "routerId":"192.168.1.1",
+  "localAS":64612,
+  "routes":{
+    "10.1.0.1/32":[
+      {"valid":true,"bestpath":true,"pathFrom":"internal","prefix":"10.1.0.1","prefixLen":32}
+    ]
+  }
+}
+}`)
+
+	expectedBGPStatisticsMetrics = map[string]float64{
+		"frr_bgp_statistics_prefixes_count_total{afi=ipv4,safi=unicast,vrf=default}":           3.0,
+		"frr_bgp_statistics_prefixes_count_total{afi=ipv4,safi=unicast,vrf=red}":               1.0,
+		"frr_bgp_statistics_paths_count_total{afi=ipv4,safi=unicast,vrf=default}":              5.0,
+		"frr_bgp_statistics_paths_count_total{afi=ipv4,safi=unicast,vrf=red}":                  1.0,
+		"frr_bgp_statistics_multipath_prefixes_count_total{afi=ipv4,safi=unicast,vrf=default}": 1.0,
+		"frr_bgp_statistics_multipath_prefixes_count_total{afi=ipv4,safi=unicast,vrf=red}":     0.0,
+
+		"frr_bgp_statistics_average_prefix_length{afi=ipv4,safi=unicast,vrf=default}":  24.0,
+		"frr_bgp_statistics_average_prefix_length{afi=ipv4,safi=unicast,vrf=red}":      32.0,
+		"frr_bgp_statistics_average_as_path_length{afi=ipv4,safi=unicast,vrf=default}": 2.0,
+		"frr_bgp_statistics_average_as_path_length{afi=ipv4,safi=unicast,vrf=red}":     1.0,
+		"frr_bgp_statistics_longest_as_path_length{afi=ipv4,safi=unicast,vrf=default}": 3.0,
+		"frr_bgp_statistics_longest_as_path_length{afi=ipv4,safi=unicast,vrf=red}":     1.0,
+
+		"frr_bgp_statistics_prefix_length_prefixes_count_total{afi=ipv4,prefix_length=24,safi=unicast,vrf=default}": 3.0,
+		"frr_bgp_statistics_prefix_length_prefixes_count_total{afi=ipv4,prefix_length=32,safi=unicast,vrf=red}":     1.0,
+	}
+)
+
+func TestProcessBGPStatistics(t *testing.T) {
+	ch := make(chan prometheus.Metric, 1024)
+	if err := processBGPStatistics(ch, bgpStatisticsV4, "ipv4", "unicast"); err != nil {
+		t.Errorf("error calling processBGPStatistics ipv4unicast: %s", err)
+	}
+	if err := processBGPStatisticsRoutes(ch, bgpRoutesV4, "ipv4", "unicast"); err != nil {
+		t.Errorf("error calling processBGPStatisticsRoutes ipv4unicast: %s", err)
+	}
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, expectedBGPStatisticsMetrics)
+}
@@ -48,7 +48,8 @@ var (
       "peerUptime":"never",
       "peerUptimeMsec":0,
       "pfxRcd":2,
-      "state":"Active",
+      "pfxSnt":5,
+      "state":"Idle (Admin)",
       "idType":"ipv4"
     }
   },
@@ -232,71 +233,311 @@ var (
     ]
   }
   }`)
+	evpnRouteJSON = []byte(`{
+  "bgpTableVersion":12,
+  "bgpLocalRouterId":"10.0.0.11",
+  "defaultLocPrf":100,
+  "localAS":65011,
+  "10.0.0.11:2":{
+    "rd":"10.0.0.11:2",
+    "[2]:[0]:[48]:[00:02:00:00:00:0a]":{
+      "prefix":"[2]:[0]:[48]:[00:02:00:00:00:0a]",
+      "prefixLen":352,
+      "paths":[[{"valid":true,"bestpath":true}]]
+    },
+    "[2]:[0]:[48]:[00:02:00:00:00:0a]:[32]:[10.0.1.10]":{
+      "prefix":"[2]:[0]:[48]:[00:02:00:00:00:0a]:[32]:[10.0.1.10]",
+      "prefixLen":352,
+      "paths":[[{"valid":true,"bestpath":true}]]
+    },
+    "[3]:[0]:[32]:[10.0.0.11]":{
+      "prefix":"[3]:[0]:[32]:[10.0.0.11]",
+      "prefixLen":352,
+      "paths":[[{"valid":true,"bestpath":true}]]
+    }
+  },
+  "10.0.0.13:3":{
+    "rd":"10.0.0.13:3",
+    "[5]:[0]:[24]:[10.0.3.0]":{
+      "prefix":"[5]:[0]:[24]:[10.0.3.0]",
+      "prefixLen":352,
+      "paths":[[{"valid":true,"bestpath":true}]]
+    }
+  },
+  "numPrefix":4,
+  "numPaths":4
+}`)
+
+	expectedBgpL2vpnRouteMetrics = map[string]float64{
+		"frr_bgp_l2vpn_evpn_route_count_total{route_type=2,vrf=default}": 2.0,
+		"frr_bgp_l2vpn_evpn_route_count_total{route_type=3,vrf=default}": 1.0,
+		"frr_bgp_l2vpn_evpn_route_count_total{route_type=5,vrf=default}": 1.0,
+	}
+	bgpVPNv4Routes = []byte(`{
+  "vrfId":0,
+  "vrfName":"default",
+  "tableVersion":5,
+  "routerId":"192.168.0.1",
+  "defaultLocPrf":100,
+  "localAS":64512,
+  "routes":{
+    "routeDistinguishers":{
+      "64512:1":{
+        "10.1.0.0/24":[{"valid":true,"bestpath":true}],
+        "10.1.1.0/24":[{"valid":true,"bestpath":true}]
+      },
+      "64513:1":{
+        "10.2.0.0/24":[{"valid":true,"bestpath":true},{"valid":true}]
+      }
+    }
+  }
+}`)
+
+	expectedBGPVPNRouteMetrics = map[string]float64{
+		"frr_bgp_rd_prefixes_count_total{afi=ipv4,rd=64512:1,safi=vpn,vrf=default}": 2.0,
+		"frr_bgp_rd_prefixes_count_total{afi=ipv4,rd=64513:1,safi=vpn,vrf=default}": 1.0,
+	}
+	bgpFlowspecV4 = []byte(`{
+"default":{
+  "vrfId":0,
+  "vrfName":"default",
+  "tableVersion":3,
+  "routerId":"192.168.0.1",
+  "localAS":64512,
+  "routes":{
+    "to 10.0.0.1/32, proto 17, dstport 53":[{"valid":true}],
+    "to 10.0.0.2/32, proto 6":[{"valid":true}],
+    "from 192.0.2.0/24":[{}]
+  }
+}
+,
+"red":{
+  "vrfId":39,
+  "vrfName":"red",
+  "tableVersion":1,
+  "routerId":"192.168.1.1",
+  "localAS":64612,
+  "routes":{
+    "to 10.1.0.1/32":[{"valid":true}]
+  }
+}
+}`)
+
+	expectedBGPFlowspecMetrics = map[string]float64{
+		"frr_bgp_flowspec_rules_count_total{afi=ipv4,safi=flowspec,vrf=default}":       3.0,
+		"frr_bgp_flowspec_rules_count_total{afi=ipv4,safi=flowspec,vrf=red}":           1.0,
+		"frr_bgp_flowspec_rules_valid_count_total{afi=ipv4,safi=flowspec,vrf=default}": 2.0,
+		"frr_bgp_flowspec_rules_valid_count_total{afi=ipv4,safi=flowspec,vrf=red}":     1.0,
+	}
+	bgpDampenedPathsV4 = []byte(`{
+"default":{
+  "vrfId":0,
+  "vrfName":"default",
+  "routerId":"192.168.0.1",
+  "localAS":64512,
+  "routes":{
+    "10.10.0.0/24":[{"valid":true}],
+    "10.10.1.0/24":[{"valid":true}]
+  }
+}
+,
+"red":{
+  "vrfId":39,
+  "vrfName":"red",
+  "routerId":"192.168.1.1",
+  "localAS":64612,
+  "routes":{}
+}
+}`)
+
+	expectedBGPDampeningMetrics = map[string]float64{
+		"frr_bgp_dampened_prefixes_count_total{afi=ipv4,safi=unicast,vrf=default}": 2.0,
+		"frr_bgp_dampened_prefixes_count_total{afi=ipv4,safi=unicast,vrf=red}":     0.0,
+	}
+	bgpSumFailedV4Unicast = []byte(`{
+"default":{
+  "routerId":"192.168.0.1",
+  "as":64512,
+  "vrfId":0,
+  "vrfName":"default",
+  "peerCount":2,
+  "peers":{
+    "192.168.0.3":{
+      "remoteAs":64514,
+      "connectionsEstablished":0,
+      "connectionsDropped":0,
+      "peerUptime":"never",
+      "peerUptimeMsec":0,
+      "lastResetDueTo":"Waiting for peer OPEN",
+      "idType":"ipv4"
+    }
+  },
+  "failedPeersCount":1,
+  "totalPeers":2
+}
+,
+"red":{
+  "routerId":"192.168.1.1",
+  "as":64612,
+  "vrfId":39,
+  "vrfName":"red",
+  "peerCount":2,
+  "peers":{
+    "192.168.1.3":{
+      "remoteAs":64614,
+      "connectionsEstablished":1,
+      "connectionsDropped":1,
+      "peerUptime":"never",
+      "peerUptimeMsec":0,
+      "lastResetDueTo":"Admin. shutdown",
+      "idType":"ipv4"
+    }
+  },
+  "failedPeersCount":1,
+  "totalPeers":2
+}
+}`)
+
+	expectedBGPFailedMetrics = map[string]float64{
+		"frr_bgp_peer_failed{afi=ipv4,local_as=64512,peer=192.168.0.3,peer_as=64514,reason=Waiting for peer OPEN,safi=unicast,vrf=default}": 1.0,
+		"frr_bgp_peer_failed{afi=ipv4,local_as=64612,peer=192.168.1.3,peer_as=64614,reason=Admin. shutdown,safi=unicast,vrf=red}":           1.0,
+	}
+	bgpSumV4Dynamic = []byte(`{
+"default":{
+  "routerId":"192.168.0.1",
+  "as":64512,
+  "vrfId":0,
+  "vrfName":"default",
+  "peerCount":3,
+  "dynamicPeers":2,
+  "peers":{
+    "10.0.0.1":{"remoteAs":64513,"state":"Established","dynamicPeer":true},
+    "10.0.0.2":{"remoteAs":64514,"state":"Established","dynamicPeer":true},
+    "192.168.0.2":{"remoteAs":64515,"state":"Established"}
+  }
+},
+"Green":{
+  "routerId":"192.168.3.1",
+  "as":64812,
+  "vrfId":9,
+  "vrfName":"Green",
+  "peerCount":1,
+  "dynamicPeers":1,
+  "peers":{
+    "10.3.0.5":{"remoteAs":64813,"state":"Established","dynamicPeer":true}
+  }
+}
+}`)
+
+	bgpRunningConfig = []byte(`!
+router bgp 64512
+ bgp router-id 192.168.0.1
+ bgp listen limit 200
+ bgp listen range 10.0.0.0/24 peer-group leaves
+ bgp listen range fd00::/64 peer-group leaves6
+ neighbor leaves peer-group
+!
+router bgp 64612 vrf red
+ bgp listen range 10.1.0.0/24 peer-group tenants
+!
+router bgp 64712 vrf blue
+ neighbor 192.168.2.2 remote-as 64713
+!
+router bgp 64812 vrf Green
+ bgp listen range 10.3.0.0/24 peer-group tenants
+!
+`)
+
+	expectedBGPListenRangeMetrics = map[string]float64{
+		"frr_bgp_listen_limit{afi=ipv4,safi=unicast,vrf=default}":                                                       200.0,
+		"frr_bgp_listen_limit{afi=ipv4,safi=unicast,vrf=red}":                                                           100.0,
+		"frr_bgp_listen_range_peers_count_total{afi=ipv4,peer_group=leaves,range=10.0.0.0/24,safi=unicast,vrf=default}": 2.0,
+		"frr_bgp_listen_range_peers_count_total{afi=ipv4,peer_group=tenants,range=10.1.0.0/24,safi=unicast,vrf=red}":    0.0,
+		"frr_bgp_listen_limit{afi=ipv4,safi=unicast,vrf=green}":                                                         100.0,
+		"frr_bgp_listen_range_peers_count_total{afi=ipv4,peer_group=tenants,range=10.3.0.0/24,safi=unicast,vrf=green}":  1.0,
+	}
 	expectedBGPMetrics = map[string]float64{
-		"frr_bgp_peer_groups_count_total{afi=ipv4,local_as=64512,safi=unicast,vrf=default}":                                           0.0,
-		"frr_bgp_peer_groups_count_total{afi=ipv4,local_as=64612,safi=unicast,vrf=red}":                                               0.0,
-		"frr_bgp_peer_groups_count_total{afi=ipv6,local_as=64512,safi=unicast,vrf=default}":                                           0.0,
-		"frr_bgp_peer_groups_count_total{afi=ipv6,local_as=64612,safi=unicast,vrf=red}":                                               0.0,
-		"frr_bgp_peer_groups_memory_bytes{afi=ipv4,local_as=64512,safi=unicast,vrf=default}":                                          0.0,
-		"frr_bgp_peer_groups_memory_bytes{afi=ipv4,local_as=64612,safi=unicast,vrf=red}":                                              0.0,
-		"frr_bgp_peer_groups_memory_bytes{afi=ipv6,local_as=64512,safi=unicast,vrf=default}":                                          0.0,
-		"frr_bgp_peer_groups_memory_bytes{afi=ipv6,local_as=64612,safi=unicast,vrf=red}":                                              0.0,
-		"frr_bgp_peer_message_received_total{afi=ipv4,local_as=64512,peer=192.168.0.2,peer_as=64513,safi=unicast,vrf=default}":        100.0,
-		"frr_bgp_peer_message_received_total{afi=ipv4,local_as=64512,peer=192.168.0.3,peer_as=64514,safi=unicast,vrf=default}":        0.0,
-		"frr_bgp_peer_message_received_total{afi=ipv4,local_as=64612,peer=192.168.1.2,peer_as=64613,safi=unicast,vrf=red}":            100.0,
-		"frr_bgp_peer_message_received_total{afi=ipv4,local_as=64612,peer=192.168.1.3,peer_as=64614,safi=unicast,vrf=red}":            200.0,
-		"frr_bgp_peer_message_received_total{afi=ipv6,local_as=64512,peer=fd00::1,peer_as=64513,safi=unicast,vrf=default}":            29285.0,
-		"frr_bgp_peer_message_received_total{afi=ipv6,local_as=64512,peer=fd00::5,peer_as=64514,safi=unicast,vrf=default}":            0.0,
-		"frr_bgp_peer_message_received_total{afi=ipv6,local_as=64612,peer=fd00::101,peer_as=64613,safi=unicast,vrf=red}":              29285.0,
-		"frr_bgp_peer_message_received_total{afi=ipv6,local_as=64612,peer=fd00::105,peer_as=64614,safi=unicast,vrf=red}":              0.0,
-		"frr_bgp_peer_message_sent_total{afi=ipv4,local_as=64512,peer=192.168.0.2,peer_as=64513,safi=unicast,vrf=default}":            100.0,
-		"frr_bgp_peer_message_sent_total{afi=ipv4,local_as=64512,peer=192.168.0.3,peer_as=64514,safi=unicast,vrf=default}":            0.0,
-		"frr_bgp_peer_message_sent_total{afi=ipv4,local_as=64612,peer=192.168.1.2,peer_as=64613,safi=unicast,vrf=red}":                100.0,
-		"frr_bgp_peer_message_sent_total{afi=ipv4,local_as=64612,peer=192.168.1.3,peer_as=64614,safi=unicast,vrf=red}":                200.0,
-		"frr_bgp_peer_message_sent_total{afi=ipv6,local_as=64512,peer=fd00::1,peer_as=64513,safi=unicast,vrf=default}":                29285.0,
-		"frr_bgp_peer_message_sent_total{afi=ipv6,local_as=64512,peer=fd00::5,peer_as=64514,safi=unicast,vrf=default}":                0.0,
-		"frr_bgp_peer_message_sent_total{afi=ipv6,local_as=64612,peer=fd00::101,peer_as=64613,safi=unicast,vrf=red}":                  29285.0,
-		"frr_bgp_peer_message_sent_total{afi=ipv6,local_as=64612,peer=fd00::105,peer_as=64614,safi=unicast,vrf=red}":                  0.0,
-		"frr_bgp_peer_prefixes_received_count_total{afi=ipv4,local_as=64512,peer=192.168.0.2,peer_as=64513,safi=unicast,vrf=default}": 0.0,
-		"frr_bgp_peer_prefixes_received_count_total{afi=ipv4,local_as=64512,peer=192.168.0.3,peer_as=64514,safi=unicast,vrf=default}": 2.0,
-		"frr_bgp_peer_prefixes_received_count_total{afi=ipv4,local_as=64612,peer=192.168.1.2,peer_as=64613,safi=unicast,vrf=red}":     2.0,
-		"frr_bgp_peer_prefixes_received_count_total{afi=ipv4,local_as=64612,peer=192.168.1.3,peer_as=64614,safi=unicast,vrf=red}":     0.0,
-		"frr_bgp_peer_prefixes_received_count_total{afi=ipv6,local_as=64512,peer=fd00::1,peer_as=64513,safi=unicast,vrf=default}":     1.0,
-		"frr_bgp_peer_prefixes_received_count_total{afi=ipv6,local_as=64512,peer=fd00::5,peer_as=64514,safi=unicast,vrf=default}":     0.0,
-		"frr_bgp_peer_prefixes_received_count_total{afi=ipv6,local_as=64612,peer=fd00::101,peer_as=64613,safi=unicast,vrf=red}":       1.0,
-		"frr_bgp_peer_prefixes_received_count_total{afi=ipv6,local_as=64612,peer=fd00::105,peer_as=64614,safi=unicast,vrf=red}":       0.0,
-		"frr_bgp_peers_count_total{afi=ipv4,local_as=64512,safi=unicast,vrf=default}":                                                 2.0,
-		"frr_bgp_peers_count_total{afi=ipv4,local_as=64612,safi=unicast,vrf=red}":                                                     2.0,
-		"frr_bgp_peers_count_total{afi=ipv6,local_as=64512,safi=unicast,vrf=default}":                                                 2.0,
-		"frr_bgp_peers_count_total{afi=ipv6,local_as=64612,safi=unicast,vrf=red}":                                                     2.0,
-		"frr_bgp_peers_memory_bytes{afi=ipv4,local_as=64512,safi=unicast,vrf=default}":                                                39936.0,
-		"frr_bgp_peers_memory_bytes{afi=ipv4,local_as=64612,safi=unicast,vrf=red}":                                                    39936.0,
-		"frr_bgp_peers_memory_bytes{afi=ipv6,local_as=64512,safi=unicast,vrf=default}":                                                59904.0,
-		"frr_bgp_peers_memory_bytes{afi=ipv6,local_as=64612,safi=unicast,vrf=red}":                                                    59904.0,
-		"frr_bgp_peer_state{afi=ipv4,local_as=64512,peer=192.168.0.2,peer_as=64513,safi=unicast,vrf=default}":                         1.0,
-		"frr_bgp_peer_state{afi=ipv4,local_as=64512,peer=192.168.0.3,peer_as=64514,safi=unicast,vrf=default}":                         0.0,
-		"frr_bgp_peer_state{afi=ipv4,local_as=64612,peer=192.168.1.2,peer_as=64613,safi=unicast,vrf=red}":                             1.0,
-		"frr_bgp_peer_state{afi=ipv4,local_as=64612,peer=192.168.1.3,peer_as=64614,safi=unicast,vrf=red}":                             0.0,
-		"frr_bgp_peer_state{afi=ipv6,local_as=64512,peer=fd00::1,peer_as=64513,safi=unicast,vrf=default}":                             1.0,
-		"frr_bgp_peer_state{afi=ipv6,local_as=64512,peer=fd00::5,peer_as=64514,safi=unicast,vrf=default}":                             0.0,
-		"frr_bgp_peer_state{afi=ipv6,local_as=64612,peer=fd00::101,peer_as=64613,safi=unicast,vrf=red}":                               1.0,
-		"frr_bgp_peer_state{afi=ipv6,local_as=64612,peer=fd00::105,peer_as=64614,safi=unicast,vrf=red}":                               0.0,
-		"frr_bgp_peer_uptime_seconds{afi=ipv4,local_as=64512,peer=192.168.0.2,peer_as=64513,safi=unicast,vrf=default}":                10.0,
-		"frr_bgp_peer_uptime_seconds{afi=ipv4,local_as=64512,peer=192.168.0.3,peer_as=64514,safi=unicast,vrf=default}":                0.0,
-		"frr_bgp_peer_uptime_seconds{afi=ipv4,local_as=64612,peer=192.168.1.2,peer_as=64613,safi=unicast,vrf=red}":                    20.0,
-		"frr_bgp_peer_uptime_seconds{afi=ipv4,local_as=64612,peer=192.168.1.3,peer_as=64614,safi=unicast,vrf=red}":                    0.0,
-		"frr_bgp_peer_uptime_seconds{afi=ipv6,local_as=64512,peer=fd00::1,peer_as=64513,safi=unicast,vrf=default}":                    87873.0,
-		"frr_bgp_peer_uptime_seconds{afi=ipv6,local_as=64512,peer=fd00::5,peer_as=64514,safi=unicast,vrf=default}":                    0.0,
-		"frr_bgp_peer_uptime_seconds{afi=ipv6,local_as=64612,peer=fd00::101,peer_as=64613,safi=unicast,vrf=red}":                      87873.0,
-		"frr_bgp_peer_uptime_seconds{afi=ipv6,local_as=64612,peer=fd00::105,peer_as=64614,safi=unicast,vrf=red}":                      0.0,
-		"frr_bgp_rib_count_total{afi=ipv4,local_as=64512,safi=unicast,vrf=default}":                                                   1.0,
-		"frr_bgp_rib_count_total{afi=ipv4,local_as=64612,safi=unicast,vrf=red}":                                                       0.0,
-		"frr_bgp_rib_count_total{afi=ipv6,local_as=64512,safi=unicast,vrf=default}":                                                   3.0,
-		"frr_bgp_rib_count_total{afi=ipv6,local_as=64612,safi=unicast,vrf=red}":                                                       3.0,
-		"frr_bgp_rib_memory_bytes{afi=ipv4,local_as=64512,safi=unicast,vrf=default}":                                                  64.0,
-		"frr_bgp_rib_memory_bytes{afi=ipv4,local_as=64612,safi=unicast,vrf=red}":                                                      0.0,
-		"frr_bgp_rib_memory_bytes{afi=ipv6,local_as=64512,safi=unicast,vrf=default}":                                                  456.0,
-		"frr_bgp_rib_memory_bytes{afi=ipv6,local_as=64612,safi=unicast,vrf=red}":                                                      456.0,
+		"frr_bgp_table_version{afi=ipv4,local_as=64512,safi=unicast,vrf=default}":                                                       0.0,
+		"frr_bgp_table_version{afi=ipv4,local_as=64612,safi=unicast,vrf=red}":                                                           0.0,
+		"frr_bgp_table_version{afi=ipv6,local_as=64512,safi=unicast,vrf=default}":                                                       6.0,
+		"frr_bgp_table_version{afi=ipv6,local_as=64612,safi=unicast,vrf=red}":                                                           6.0,
+		"frr_bgp_dynamic_peers_count_total{afi=ipv4,local_as=64512,safi=unicast,vrf=default}":                                           0.0,
+		"frr_bgp_dynamic_peers_count_total{afi=ipv4,local_as=64612,safi=unicast,vrf=red}":                                               0.0,
+		"frr_bgp_dynamic_peers_count_total{afi=ipv6,local_as=64512,safi=unicast,vrf=default}":                                           0.0,
+		"frr_bgp_dynamic_peers_count_total{afi=ipv6,local_as=64612,safi=unicast,vrf=red}":                                               0.0,
+		"frr_bgp_peer_groups_count_total{afi=ipv4,local_as=64512,safi=unicast,vrf=default}":                                             0.0,
+		"frr_bgp_peer_groups_count_total{afi=ipv4,local_as=64612,safi=unicast,vrf=red}":                                                 0.0,
+		"frr_bgp_peer_groups_count_total{afi=ipv6,local_as=64512,safi=unicast,vrf=default}":                                             0.0,
+		"frr_bgp_peer_groups_count_total{afi=ipv6,local_as=64612,safi=unicast,vrf=red}":                                                 0.0,
+		"frr_bgp_peer_groups_memory_bytes{afi=ipv4,local_as=64512,safi=unicast,vrf=default}":                                            0.0,
+		"frr_bgp_peer_groups_memory_bytes{afi=ipv4,local_as=64612,safi=unicast,vrf=red}":                                                0.0,
+		"frr_bgp_peer_groups_memory_bytes{afi=ipv6,local_as=64512,safi=unicast,vrf=default}":                                            0.0,
+		"frr_bgp_peer_groups_memory_bytes{afi=ipv6,local_as=64612,safi=unicast,vrf=red}":                                                0.0,
+		"frr_bgp_peer_message_received_total{afi=ipv4,local_as=64512,peer=192.168.0.2,peer_as=64513,safi=unicast,vrf=default}":          100.0,
+		"frr_bgp_peer_message_received_total{afi=ipv4,local_as=64512,peer=192.168.0.3,peer_as=64514,safi=unicast,vrf=default}":          0.0,
+		"frr_bgp_peer_message_received_total{afi=ipv4,local_as=64612,peer=192.168.1.2,peer_as=64613,safi=unicast,vrf=red}":              100.0,
+		"frr_bgp_peer_message_received_total{afi=ipv4,local_as=64612,peer=192.168.1.3,peer_as=64614,safi=unicast,vrf=red}":              200.0,
+		"frr_bgp_peer_message_received_total{afi=ipv6,local_as=64512,peer=fd00::1,peer_as=64513,safi=unicast,vrf=default}":              29285.0,
+		"frr_bgp_peer_message_received_total{afi=ipv6,local_as=64512,peer=fd00::5,peer_as=64514,safi=unicast,vrf=default}":              0.0,
+		"frr_bgp_peer_message_received_total{afi=ipv6,local_as=64612,peer=fd00::101,peer_as=64613,safi=unicast,vrf=red}":                29285.0,
+		"frr_bgp_peer_message_received_total{afi=ipv6,local_as=64612,peer=fd00::105,peer_as=64614,safi=unicast,vrf=red}":                0.0,
+		"frr_bgp_peer_message_sent_total{afi=ipv4,local_as=64512,peer=192.168.0.2,peer_as=64513,safi=unicast,vrf=default}":              100.0,
+		"frr_bgp_peer_message_sent_total{afi=ipv4,local_as=64512,peer=192.168.0.3,peer_as=64514,safi=unicast,vrf=default}":              0.0,
+		"frr_bgp_peer_message_sent_total{afi=ipv4,local_as=64612,peer=192.168.1.2,peer_as=64613,safi=unicast,vrf=red}":                  100.0,
+		"frr_bgp_peer_message_sent_total{afi=ipv4,local_as=64612,peer=192.168.1.3,peer_as=64614,safi=unicast,vrf=red}":                  200.0,
+		"frr_bgp_peer_message_sent_total{afi=ipv6,local_as=64512,peer=fd00::1,peer_as=64513,safi=unicast,vrf=default}":                  29285.0,
+		"frr_bgp_peer_message_sent_total{afi=ipv6,local_as=64512,peer=fd00::5,peer_as=64514,safi=unicast,vrf=default}":                  0.0,
+		"frr_bgp_peer_message_sent_total{afi=ipv6,local_as=64612,peer=fd00::101,peer_as=64613,safi=unicast,vrf=red}":                    29285.0,
+		"frr_bgp_peer_message_sent_total{afi=ipv6,local_as=64612,peer=fd00::105,peer_as=64614,safi=unicast,vrf=red}":                    0.0,
+		"frr_bgp_peer_prefixes_advertised_count_total{afi=ipv4,local_as=64512,peer=192.168.0.3,peer_as=64514,safi=unicast,vrf=default}": 5.0,
+		"frr_bgp_peer_prefixes_received_count_total{afi=ipv4,local_as=64512,peer=192.168.0.2,peer_as=64513,safi=unicast,vrf=default}":   0.0,
+		"frr_bgp_peer_prefixes_received_count_total{afi=ipv4,local_as=64512,peer=192.168.0.3,peer_as=64514,safi=unicast,vrf=default}":   2.0,
+		"frr_bgp_peer_prefixes_received_count_total{afi=ipv4,local_as=64612,peer=192.168.1.2,peer_as=64613,safi=unicast,vrf=red}":       2.0,
+		"frr_bgp_peer_prefixes_received_count_total{afi=ipv4,local_as=64612,peer=192.168.1.3,peer_as=64614,safi=unicast,vrf=red}":       0.0,
+		"frr_bgp_peer_prefixes_received_count_total{afi=ipv6,local_as=64512,peer=fd00::1,peer_as=64513,safi=unicast,vrf=default}":       1.0,
+		"frr_bgp_peer_prefixes_received_count_total{afi=ipv6,local_as=64512,peer=fd00::5,peer_as=64514,safi=unicast,vrf=default}":       0.0,
+		"frr_bgp_peer_prefixes_received_count_total{afi=ipv6,local_as=64612,peer=fd00::101,peer_as=64613,safi=unicast,vrf=red}":         1.0,
+		"frr_bgp_peer_prefixes_received_count_total{afi=ipv6,local_as=64612,peer=fd00::105,peer_as=64614,safi=unicast,vrf=red}":         0.0,
+		"frr_bgp_peers_count_total{afi=ipv4,local_as=64512,safi=unicast,vrf=default}":                                                   2.0,
+		"frr_bgp_peers_count_total{afi=ipv4,local_as=64612,safi=unicast,vrf=red}":                                                       2.0,
+		"frr_bgp_peers_count_total{afi=ipv6,local_as=64512,safi=unicast,vrf=default}":                                                   2.0,
+		"frr_bgp_peers_count_total{afi=ipv6,local_as=64612,safi=unicast,vrf=red}":                                                       2.0,
+		"frr_bgp_peers_memory_bytes{afi=ipv4,local_as=64512,safi=unicast,vrf=default}":                                                  39936.0,
+		"frr_bgp_peers_memory_bytes{afi=ipv4,local_as=64612,safi=unicast,vrf=red}":                                                      39936.0,
+		"frr_bgp_peers_memory_bytes{afi=ipv6,local_as=64512,safi=unicast,vrf=default}":                                                  59904.0,
+		"frr_bgp_peers_memory_bytes{afi=ipv6,local_as=64612,safi=unicast,vrf=red}":                                                      59904.0,
+		"frr_bgp_peer_state{afi=ipv4,local_as=64512,peer=192.168.0.2,peer_as=64513,safi=unicast,vrf=default}":                           1.0,
+		"frr_bgp_peer_state_info{afi=ipv4,local_as=64512,peer=192.168.0.2,peer_as=64513,safi=unicast,state=Established,vrf=default}":    1.0,
+		"frr_bgp_peer_state{afi=ipv4,local_as=64512,peer=192.168.0.3,peer_as=64514,safi=unicast,vrf=default}":                           0.0,
+		"frr_bgp_peer_state_info{afi=ipv4,local_as=64512,peer=192.168.0.3,peer_as=64514,safi=unicast,state=Idle (Admin),vrf=default}":   1.0,
+		"frr_bgp_peer_state{afi=ipv4,local_as=64612,peer=192.168.1.2,peer_as=64613,safi=unicast,vrf=red}":                               1.0,
+		"frr_bgp_peer_state_info{afi=ipv4,local_as=64612,peer=192.168.1.2,peer_as=64613,safi=unicast,state=Established,vrf=red}":        1.0,
+		"frr_bgp_peer_state{afi=ipv4,local_as=64612,peer=192.168.1.3,peer_as=64614,safi=unicast,vrf=red}":                               0.0,
+		"frr_bgp_peer_state_info{afi=ipv4,local_as=64612,peer=192.168.1.3,peer_as=64614,safi=unicast,state=Active,vrf=red}":             1.0,
+		"frr_bgp_peer_state{afi=ipv6,local_as=64512,peer=fd00::1,peer_as=64513,safi=unicast,vrf=default}":                               1.0,
+		"frr_bgp_peer_state_info{afi=ipv6,local_as=64512,peer=fd00::1,peer_as=64513,safi=unicast,state=Established,vrf=default}":        1.0,
+		"frr_bgp_peer_state{afi=ipv6,local_as=64512,peer=fd00::5,peer_as=64514,safi=unicast,vrf=default}":                               0.0,
+		"frr_bgp_peer_state_info{afi=ipv6,local_as=64512,peer=fd00::5,peer_as=64514,safi=unicast,state=Active,vrf=default}":             1.0,
+		"frr_bgp_peer_state{afi=ipv6,local_as=64612,peer=fd00::101,peer_as=64613,safi=unicast,vrf=red}":                                 1.0,
+		"frr_bgp_peer_state_info{afi=ipv6,local_as=64612,peer=fd00::101,peer_as=64613,safi=unicast,state=Established,vrf=red}":          1.0,
+		"frr_bgp_peer_state{afi=ipv6,local_as=64612,peer=fd00::105,peer_as=64614,safi=unicast,vrf=red}":                                 0.0,
+		"frr_bgp_peer_state_info{afi=ipv6,local_as=64612,peer=fd00::105,peer_as=64614,safi=unicast,state=Active,vrf=red}":               1.0,
+		"frr_bgp_peer_uptime_seconds{afi=ipv4,local_as=64512,peer=192.168.0.2,peer_as=64513,safi=unicast,vrf=default}":                  10.0,
+		"frr_bgp_peer_uptime_seconds{afi=ipv4,local_as=64512,peer=192.168.0.3,peer_as=64514,safi=unicast,vrf=default}":                  0.0,
+		"frr_bgp_peer_uptime_seconds{afi=ipv4,local_as=64612,peer=192.168.1.2,peer_as=64613,safi=unicast,vrf=red}":                      20.0,
+		"frr_bgp_peer_uptime_seconds{afi=ipv4,local_as=64612,peer=192.168.1.3,peer_as=64614,safi=unicast,vrf=red}":                      0.0,
+		"frr_bgp_peer_uptime_seconds{afi=ipv6,local_as=64512,peer=fd00::1,peer_as=64513,safi=unicast,vrf=default}":                      87873.0,
+		"frr_bgp_peer_uptime_seconds{afi=ipv6,local_as=64512,peer=fd00::5,peer_as=64514,safi=unicast,vrf=default}":                      0.0,
+		"frr_bgp_peer_uptime_seconds{afi=ipv6,local_as=64612,peer=fd00::101,peer_as=64613,safi=unicast,vrf=red}":                        87873.0,
+		"frr_bgp_peer_uptime_seconds{afi=ipv6,local_as=64612,peer=fd00::105,peer_as=64614,safi=unicast,vrf=red}":                        0.0,
+		"frr_bgp_rib_count_total{afi=ipv4,local_as=64512,safi=unicast,vrf=default}":                                                     1.0,
+		"frr_bgp_rib_count_total{afi=ipv4,local_as=64612,safi=unicast,vrf=red}":                                                         0.0,
+		"frr_bgp_rib_count_total{afi=ipv6,local_as=64512,safi=unicast,vrf=default}":                                                     3.0,
+		"frr_bgp_rib_count_total{afi=ipv6,local_as=64612,safi=unicast,vrf=red}":                                                         3.0,
+		"frr_bgp_rib_memory_bytes{afi=ipv4,local_as=64512,safi=unicast,vrf=default}":                                                    64.0,
+		"frr_bgp_rib_memory_bytes{afi=ipv4,local_as=64612,safi=unicast,vrf=red}":                                                        0.0,
+		"frr_bgp_rib_memory_bytes{afi=ipv6,local_as=64512,safi=unicast,vrf=default}":                                                    456.0,
+		"frr_bgp_rib_memory_bytes{afi=ipv6,local_as=64612,safi=unicast,vrf=red}":                                                        456.0,
 	}
 	expectedBgpL2vpnMetrics = map[string]float64{
 		"frr_bgp_l2vpn_evpn_arp_nd_count_total{tenantVrf=default,type=L2,vni=172192,vxlanIf=ONTEP1_172192}":      23.000000,
@@ -387,3 +628,229 @@ func TestProcessBgpL2vpnEvpnSummary(t *testing.T) {
 	gotMetrics := prepareMetrics(ch, t)
 	compareMetrics(t, gotMetrics, expectedBgpL2vpnMetrics)
 }
+
+func TestProcessBgpL2vpnEvpnRoutes(t *testing.T) {
+	ch := make(chan prometheus.Metric, 1024)
+	if err := processBgpL2vpnEvpnRoutes(ch, evpnRouteJSON); err != nil {
+		t.Errorf("error calling processBgpL2vpnEvpnRoutes: %s", err)
+	}
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, expectedBgpL2vpnRouteMetrics)
+}
+
+func TestProcessBGPSummaryLabeledUnicast(t *testing.T) {
+	ch := make(chan prometheus.Metric, 1024)
+	if err := processBGPSummary(ch, bgpSumV4Unicast, "ipv4", "labeled-unicast"); err != nil {
+		t.Errorf("error calling processBGPSummary ipv4labeled-unicast: %s", err)
+	}
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	expectedMetrics := map[string]float64{}
+	for metricName, metricVal := range expectedBGPMetrics {
+		if strings.Contains(metricName, "afi=ipv4,") {
+			expectedMetrics[strings.Replace(metricName, "safi=unicast", "safi=labeled-unicast", 1)] = metricVal
+		}
+	}
+	compareMetrics(t, gotMetrics, expectedMetrics)
+}
+
+func TestProcessBGPLinkState(t *testing.T) {
+	// 'show bgp link-state link-state summary json' is not VRF aware, so the summary is not keyed by the instance.
+	bgpSumLinkState := []byte(`{
+  "routerId":"192.0.2.1",
+  "as":65001,
+  "vrfId":0,
+  "vrfName":"default",
+  "tableVersion":8,
+  "ribCount":8,
+  "ribMemory":1472,
+  "peerCount":1,
+  "peerMemory":20232,
+  "peers":{
+    "192.0.2.2":{
+      "remoteAs":65002,
+      "localAs":65001,
+      "version":4,
+      "msgRcvd":35,
+      "msgSent":29,
+      "tableVersion":0,
+      "outq":0,
+      "inq":0,
+      "peerUptime":"00:20:11",
+      "peerUptimeMsec":1211000,
+      "pfxRcd":8,
+      "pfxSnt":0,
+      "state":"Established",
+      "peerState":"OK",
+      "idType":"ipv4"
+    }
+  },
+  "failedPeers":0,
+  "displayedPeers":1,
+  "totalPeers":1,
+  "dynamicPeers":0
+}`)
+
+	// Received routes are only queried for unicast peers, so no vtysh command is run for the link-state peer.
+	origReceivedRoutes := *bgpReceivedRoutes
+	*bgpReceivedRoutes = true
+	defer func() { *bgpReceivedRoutes = origReceivedRoutes }()
+	bgpErrors = []error{}
+
+	ch := make(chan prometheus.Metric, 1024)
+	if err := processBGPLinkState(ch, bgpSumLinkState); err != nil {
+		t.Errorf("error calling processBGPLinkState: %s", err)
+	}
+	close(ch)
+	if len(bgpErrors) != 0 {
+		t.Errorf("unexpected bgp errors: %v", bgpErrors)
+	}
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, map[string]float64{
+		"frr_bgp_rib_count_total{afi=link-state,local_as=65001,safi=link-state,vrf=default}":                                                   8.0,
+		"frr_bgp_rib_memory_bytes{afi=link-state,local_as=65001,safi=link-state,vrf=default}":                                                  1472.0,
+		"frr_bgp_table_version{afi=link-state,local_as=65001,safi=link-state,vrf=default}":                                                     8.0,
+		"frr_bgp_peers_count_total{afi=link-state,local_as=65001,safi=link-state,vrf=default}":                                                 1.0,
+		"frr_bgp_peers_memory_bytes{afi=link-state,local_as=65001,safi=link-state,vrf=default}":                                                20232.0,
+		"frr_bgp_peer_groups_count_total{afi=link-state,local_as=65001,safi=link-state,vrf=default}":                                           0.0,
+		"frr_bgp_peer_groups_memory_bytes{afi=link-state,local_as=65001,safi=link-state,vrf=default}":                                          0.0,
+		"frr_bgp_dynamic_peers_count_total{afi=link-state,local_as=65001,safi=link-state,vrf=default}":                                         0.0,
+		"frr_bgp_peer_state{afi=link-state,local_as=65001,peer=192.0.2.2,peer_as=65002,safi=link-state,vrf=default}":                           1.0,
+		"frr_bgp_peer_state_info{afi=link-state,local_as=65001,peer=192.0.2.2,peer_as=65002,safi=link-state,state=Established,vrf=default}":    1.0,
+		"frr_bgp_peer_uptime_seconds{afi=link-state,local_as=65001,peer=192.0.2.2,peer_as=65002,safi=link-state,vrf=default}":                  1211.0,
+		"frr_bgp_peer_message_received_total{afi=link-state,local_as=65001,peer=192.0.2.2,peer_as=65002,safi=link-state,vrf=default}":          35.0,
+		"frr_bgp_peer_message_sent_total{afi=link-state,local_as=65001,peer=192.0.2.2,peer_as=65002,safi=link-state,vrf=default}":              29.0,
+		"frr_bgp_peer_prefixes_received_count_total{afi=link-state,local_as=65001,peer=192.0.2.2,peer_as=65002,safi=link-state,vrf=default}":   8.0,
+		"frr_bgp_peer_prefixes_advertised_count_total{afi=link-state,local_as=65001,peer=192.0.2.2,peer_as=65002,safi=link-state,vrf=default}": 0.0,
+	})
+}
+
+func TestProcessBGPReceivedRoutes(t *testing.T) {
+	receivedRoutes := []byte(`{
+  "bgpTableVersion":12,
+  "bgpLocalRouterId":"192.168.0.1",
+  "defaultLocPrf":100,
+  "localAS":64512,
+  "receivedRoutes":{
+    "10.0.0.0/24":{"addrPrefix":"10.0.0.0","prefixLen":24,"network":"10.0.0.0/24","nextHop":"192.168.0.2","metric":0,"weight":0,"path":"64513","origin":"IGP"},
+    "10.0.1.0/24":{"addrPrefix":"10.0.1.0","prefixLen":24,"network":"10.0.1.0/24","nextHop":"192.168.0.2","metric":0,"weight":0,"path":"64513","origin":"IGP"},
+    "192.168.0.0/16":{"addrPrefix":"192.168.0.0","prefixLen":16,"network":"192.168.0.0/16","nextHop":"192.168.0.2","metric":0,"weight":0,"path":"64513","origin":"IGP"}
+  },
+  "totalPrefixCounter":3,
+  "filteredPrefixCounter":1
+}`)
+
+	ch := make(chan prometheus.Metric, 1024)
+	if err := processBGPReceivedRoutes(ch, receivedRoutes, "default", "ipv4", "unicast", "64512", "192.168.0.2", "64513"); err != nil {
+		t.Errorf("error calling processBGPReceivedRoutes: %s", err)
+	}
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, map[string]float64{
+		"frr_bgp_peer_prefixes_received_pre_policy_count_total{afi=ipv4,local_as=64512,peer=192.168.0.2,peer_as=64513,safi=unicast,vrf=default}": 3.0,
+		"frr_bgp_peer_prefixes_filtered_count_total{afi=ipv4,local_as=64512,peer=192.168.0.2,peer_as=64513,safi=unicast,vrf=default}":            1.0,
+	})
+}
+
+func TestProcessBGPReceivedRoutesSoftReconfigDisabled(t *testing.T) {
+	for _, receivedRoutes := range [][]byte{
+		[]byte(`{"warning":"Inbound soft reconfiguration not enabled"}`),
+		[]byte(`{}`),
+	} {
+		ch := make(chan prometheus.Metric, 1024)
+		if err := processBGPReceivedRoutes(ch, receivedRoutes, "default", "ipv4", "unicast", "64512", "192.168.0.3", "64514"); err != nil {
+			t.Errorf("error calling processBGPReceivedRoutes with %q: %s", receivedRoutes, err)
+		}
+		close(ch)
+
+		if gotMetrics := prepareMetrics(ch, t); len(gotMetrics) != 0 {
+			t.Errorf("expected no metrics for %q, got %v", receivedRoutes, gotMetrics)
+		}
+	}
+}
+
+func TestProcessBGPVPNRoutes(t *testing.T) {
+	ch := make(chan prometheus.Metric, 1024)
+	if err := processBGPVPNRoutes(ch, bgpVPNv4Routes, "ipv4"); err != nil {
+		t.Errorf("error calling processBGPVPNRoutes ipv4: %s", err)
+	}
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, expectedBGPVPNRouteMetrics)
+}
+
+func TestProcessBGPFlowspec(t *testing.T) {
+	ch := make(chan prometheus.Metric, 1024)
+	if err := processBGPFlowspec(ch, bgpFlowspecV4, "ipv4"); err != nil {
+		t.Errorf("error calling processBGPFlowspec ipv4: %s", err)
+	}
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, expectedBGPFlowspecMetrics)
+}
+
+func TestProcessBGPDampening(t *testing.T) {
+	ch := make(chan prometheus.Metric, 1024)
+	if err := processBGPDampening(ch, bgpDampenedPathsV4, "ipv4", "dampenedPaths"); err != nil {
+		t.Errorf("error calling processBGPDampening ipv4: %s", err)
+	}
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, expectedBGPDampeningMetrics)
+}
+
+func TestProcessBGPFailedSummary(t *testing.T) {
+	ch := make(chan prometheus.Metric, 1024)
+	if err := processBGPFailedSummary(ch, bgpSumFailedV4Unicast, "ipv4", "unicast"); err != nil {
+		t.Errorf("error calling processBGPFailedSummary ipv4unicast: %s", err)
+	}
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, expectedBGPFailedMetrics)
+}
+
+func TestProcessBGPListenRanges(t *testing.T) {
+	ch := make(chan prometheus.Metric, 1024)
+	if err := processBGPListenRanges(ch, bgpSumV4Dynamic, parseBGPListenRanges(bgpRunningConfig), "ipv4", "unicast"); err != nil {
+		t.Errorf("error calling processBGPListenRanges ipv4unicast: %s", err)
+	}
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, expectedBGPListenRangeMetrics)
+}
+
+func TestParseBGPViews(t *testing.T) {
+	output := []byte("Defined BGP views:\n\t(null) (AS64512)\n\trs-ipv4 (AS64512)\n\trs-ipv6 (AS64512)\n")
+	views := parseBGPViews(output)
+	if len(views) != 2 || views[0] != "rs-ipv4" || views[1] != "rs-ipv6" {
+		t.Errorf("expected views [rs-ipv4 rs-ipv6] got %v", views)
+	}
+}
+
+func TestAddBGPError(t *testing.T) {
+	bgpErrors, bgp6Errors, bgpL2VPNErrors = []error{}, []error{}, []error{}
+
+	addBGPError("ipv6", fmt.Errorf("ipv6 error"))
+	addBGPError("l2vpn", fmt.Errorf("l2vpn error"))
+	addBGPError("l2vpn", fmt.Errorf("l2vpn error"))
+
+	if len(bgpErrors) != 0 {
+		t.Errorf("expected 0 ipv4 errors, got %d", len(bgpErrors))
+	}
+	if len(bgp6Errors) != 1 {
+		t.Errorf("expected 1 ipv6 error, got %d", len(bgp6Errors))
+	}
+	if len(bgpL2VPNErrors) != 2 {
+		t.Errorf("expected 2 l2vpn errors, got %d", len(bgpL2VPNErrors))
+	}
+}
@@ -0,0 +1,194 @@
+package collector
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	bmpSubsystem = "bmp"
+
+	bmpLabels = []string{"vrf", "target", "remote"}
+	bmpDesc   = map[string]*prometheus.Desc{
+		"connectionUp":   colPromDesc(bmpSubsystem, "connection_up", "Whether the outbound connection to the BMP monitoring station is up (1 = up, 0 = down).", bmpLabels),
+		"monSent":        colPromDesc(bmpSubsystem, "monitoring_messages_sent_total", "Number of route monitoring messages sent to the BMP monitoring station.", bmpLabels),
+		"mirrSent":       colPromDesc(bmpSubsystem, "mirroring_messages_sent_total", "Number of route mirroring messages sent to the BMP monitoring station.", bmpLabels),
+		"mirrLost":       colPromDesc(bmpSubsystem, "mirroring_messages_lost_total", "Number of route mirroring messages lost.", bmpLabels),
+		"bytesSent":      colPromDesc(bmpSubsystem, "sent_bytes_total", "Number of bytes sent to the BMP monitoring station.", bmpLabels),
+		"bytesQueued":    colPromDesc(bmpSubsystem, "queued_bytes", "Number of bytes queued to be sent to the BMP monitoring station.", bmpLabels),
+		"bytesQueuedKrn": colPromDesc(bmpSubsystem, "kernel_queued_bytes", "Number of bytes queued in the kernel to be sent to the BMP monitoring station.", bmpLabels),
+	}
+	bmpErrors      = []error{}
+	totalBMPErrors = 0.0
+
+	bmpVRFRegexp     = regexp.MustCompile(`^BMP state for BGP VRF (\S+):`)
+	bmpTargetRegexp  = regexp.MustCompile(`^\s+Targets "(.+)":`)
+	bmpClientsRegexp = regexp.MustCompile(`^(\d+) connected clients:`)
+
+	// bmpClientColumns are the columns of the connected clients table that are exported, in the order of the values
+	// passed to the metrics.
+	bmpClientColumns = []string{"MonSent", "MirrSent", "MirrLost", "ByteSent", "ByteQ", "ByteQKernel"}
+)
+
+// BMPCollector collects BMP metrics, implemented as per prometheus.Collector interface.
+type BMPCollector struct{}
+
+// NewBMPCollector returns a BMPCollector struct.
+func NewBMPCollector() *BMPCollector {
+	return &BMPCollector{}
+}
+
+// Name of the collector. Used to populate flag name.
+func (*BMPCollector) Name() string {
+	return bmpSubsystem
+}
+
+// Help describes the metrics this collector scrapes. Used to populate flag help.
+func (*BMPCollector) Help() string {
+	return "Collect BMP Metrics"
+}
+
+// EnabledByDefault describes whether this collector is enabled by default. Used to populate flag default.
+func (*BMPCollector) EnabledByDefault() bool {
+	return false
+}
+
+// Describe implemented as per the prometheus.Collector interface.
+func (*BMPCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range bmpDesc {
+		ch <- desc
+	}
+}
+
+// Collect implemented as per the prometheus.Collector interface.
+func (c *BMPCollector) Collect(ch chan<- prometheus.Metric) {
+	bmpErrors = []error{}
+
+	output, err := execVtyshCommand("-c", "show bmp")
+	if err != nil {
+		bmpErrors = append(bmpErrors, fmt.Errorf("cannot get bmp state: %s", err))
+	} else {
+		if err := processBMP(ch, output); err != nil {
+			bmpErrors = append(bmpErrors, err)
+		}
+	}
+
+	totalBMPErrors += float64(len(bmpErrors))
+}
+
+// CollectErrors returns what errors have been gathered.
+func (*BMPCollector) CollectErrors() []error {
+	return bmpErrors
+}
+
+// CollectTotalErrors returns total errors.
+func (*BMPCollector) CollectTotalErrors() float64 {
+	return totalBMPErrors
+}
+
+func processBMP(ch chan<- prometheus.Metric, output []byte) error {
+	// 'show bmp' does not support JSON, so the text output is parsed. Each VRF contains a section per target, which
+	// contains a table of outbound connections and a table of connected clients. Newer versions of FRR add columns to
+	// the clients table, so the values are looked up by the position of the column in the table header.
+	vrfName, target, section := "", "", ""
+	clients, clientsParsed := 0, 0
+	clientColumns := map[string]int{}
+	// checkClients returns an error if the target has connected clients, but none of the rows could be parsed.
+	checkClients := func() error {
+		defer func() { clients, clientsParsed = 0, 0 }()
+		if clients > 0 && clientsParsed == 0 {
+			return fmt.Errorf("cannot parse any of the %d bmp clients of target %q in vrf %s", clients, target, vrfName)
+		}
+		return nil
+	}
+
+	for _, line := range strings.Split(string(output), "\n") {
+		if match := bmpVRFRegexp.FindStringSubmatch(line); match != nil {
+			if err := checkClients(); err != nil {
+				return err
+			}
+			vrfName, target, section = strings.ToLower(match[1]), "", ""
+			continue
+		}
+		if match := bmpTargetRegexp.FindStringSubmatch(line); match != nil {
+			if err := checkClients(); err != nil {
+				return err
+			}
+			target, section = match[1], ""
+			continue
+		}
+
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case target == "":
+			continue
+		case trimmed == "":
+			section = ""
+			continue
+		case strings.HasPrefix(trimmed, "Outbound connections:"):
+			section = "outbound"
+			continue
+		case strings.HasSuffix(trimmed, "connected clients:"):
+			section = "clients"
+			clientColumns = map[string]int{}
+			if match := bmpClientsRegexp.FindStringSubmatch(trimmed); match != nil {
+				clients, _ = strconv.Atoi(match[1])
+			}
+			continue
+		case strings.HasPrefix(trimmed, "remote"):
+			if section == "clients" {
+				for i, column := range strings.Fields(trimmed) {
+					clientColumns[column] = i
+				}
+			}
+			continue
+		case strings.HasPrefix(trimmed, "---"):
+			continue
+		}
+
+		switch section {
+		case "outbound":
+			fields := strings.Fields(trimmed)
+			if len(fields) < 2 {
+				continue
+			}
+			connUp := 0.0
+			if strings.ToLower(fields[1]) == "up" {
+				connUp = 1
+			}
+			// The labels are "vrf", "target", "remote"
+			newGauge(ch, bmpDesc["connectionUp"], connUp, vrfName, target, fields[0])
+		case "clients":
+			fields := strings.Fields(trimmed)
+			if len(fields) != len(clientColumns) {
+				continue
+			}
+			values := make([]float64, len(bmpClientColumns))
+			for i, column := range bmpClientColumns {
+				pos, exist := clientColumns[column]
+				if !exist {
+					return fmt.Errorf("cannot find column %s in bmp clients of target %q in vrf %s", column, target, vrfName)
+				}
+				value, err := strconv.ParseFloat(fields[pos], 64)
+				if err != nil {
+					return fmt.Errorf("cannot parse bmp client line %q: %s", line, err)
+				}
+				values[i] = value
+			}
+			clientsParsed++
+			// The labels are "vrf", "target", "remote"
+			labels := []string{vrfName, target, fields[clientColumns["remote"]]}
+			newCounter(ch, bmpDesc["monSent"], values[0], labels...)
+			newCounter(ch, bmpDesc["mirrSent"], values[1], labels...)
+			newCounter(ch, bmpDesc["mirrLost"], values[2], labels...)
+			newCounter(ch, bmpDesc["bytesSent"], values[3], labels...)
+			newGauge(ch, bmpDesc["bytesQueued"], values[4], labels...)
+			newGauge(ch, bmpDesc["bytesQueuedKrn"], values[5], labels...)
+		}
+	}
+	return checkClients()
+}
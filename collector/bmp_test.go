@@ -0,0 +1,100 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	bmpOutput = []byte(`BMP state for BGP VRF default:
+
+  Route Mirroring         0 bytes (0 messages) pending
+                          0 bytes maximum buffer used
+
+  Targets "collector1":
+    Route Mirroring disabled
+    Route Monitoring IPv4 unicast rib-in pre-policy
+
+    Listeners:
+
+    Outbound connections:
+      remote               state                          timer
+      ----------------------------------------------------------------------
+      192.168.20.1:5000    Up 192.168.20.1:5000           00:10:00
+      192.168.20.2:5000    ConnectRetry                   00:00:12
+
+    1 connected clients:
+      remote                 uptime        MonSent MirrSent MirrLost ByteSent ByteQ ByteQKernel
+      ---------------------------------------------------------------------------------
+      192.168.20.1:5000      00:10:00          120        0        0    45230     0           0
+`)
+
+	expectedBMPMetrics = map[string]float64{
+		"frr_bmp_connection_up{remote=192.168.20.1:5000,target=collector1,vrf=default}":                  1.0,
+		"frr_bmp_connection_up{remote=192.168.20.2:5000,target=collector1,vrf=default}":                  0.0,
+		"frr_bmp_monitoring_messages_sent_total{remote=192.168.20.1:5000,target=collector1,vrf=default}": 120.0,
+		"frr_bmp_mirroring_messages_sent_total{remote=192.168.20.1:5000,target=collector1,vrf=default}":  0.0,
+		"frr_bmp_mirroring_messages_lost_total{remote=192.168.20.1:5000,target=collector1,vrf=default}":  0.0,
+		"frr_bmp_sent_bytes_total{remote=192.168.20.1:5000,target=collector1,vrf=default}":               45230.0,
+		"frr_bmp_queued_bytes{remote=192.168.20.1:5000,target=collector1,vrf=default}":                   0.0,
+		"frr_bmp_kernel_queued_bytes{remote=192.168.20.1:5000,target=collector1,vrf=default}":            0.0,
+	}
+)
+
+func TestProcessBMP(t *testing.T) {
+	ch := make(chan prometheus.Metric, 1024)
+	if err := processBMP(ch, bmpOutput); err != nil {
+		t.Errorf("error calling processBMP: %s", err)
+	}
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, expectedBMPMetrics)
+}
+
+func TestProcessBMPExtraColumns(t *testing.T) {
+	bmpExtraColumns := []byte(`BMP state for BGP VRF Red:
+
+  Targets "collector2":
+    Route Mirroring disabled
+
+    1 connected clients:
+      remote                 uptime        state     MonSent MirrSent MirrLost ByteSent ByteQ ByteQKernel
+      ---------------------------------------------------------------------------------------------------
+      192.168.30.1:5000      00:05:00      Up             42        1        2     8192    16          32
+`)
+
+	ch := make(chan prometheus.Metric, 1024)
+	if err := processBMP(ch, bmpExtraColumns); err != nil {
+		t.Errorf("error calling processBMP: %s", err)
+	}
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, map[string]float64{
+		"frr_bmp_monitoring_messages_sent_total{remote=192.168.30.1:5000,target=collector2,vrf=red}": 42.0,
+		"frr_bmp_mirroring_messages_sent_total{remote=192.168.30.1:5000,target=collector2,vrf=red}":  1.0,
+		"frr_bmp_mirroring_messages_lost_total{remote=192.168.30.1:5000,target=collector2,vrf=red}":  2.0,
+		"frr_bmp_sent_bytes_total{remote=192.168.30.1:5000,target=collector2,vrf=red}":               8192.0,
+		"frr_bmp_queued_bytes{remote=192.168.30.1:5000,target=collector2,vrf=red}":                   16.0,
+		"frr_bmp_kernel_queued_bytes{remote=192.168.30.1:5000,target=collector2,vrf=red}":            32.0,
+	})
+}
+
+func TestProcessBMPUnparsedClients(t *testing.T) {
+	bmpUnparsed := []byte(`BMP state for BGP VRF default:
+
+  Targets "collector1":
+    1 connected clients:
+      remote                 uptime        MonSent MirrSent MirrLost ByteSent ByteQ ByteQKernel
+      ---------------------------------------------------------------------------------
+      192.168.20.1:5000      00:10:00          120        0
+`)
+
+	ch := make(chan prometheus.Metric, 1024)
+	if err := processBMP(ch, bmpUnparsed); err == nil {
+		t.Errorf("expected error calling processBMP with unparsed clients")
+	}
+	close(ch)
+}
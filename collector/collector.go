@@ -1,6 +1,12 @@
 package collector
 
 import (
+	"context"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -24,6 +30,21 @@ var (
 	}
 	vtyshPath    string
 	vtyshTimeout time.Duration
+
+	// timeNow is used to convert relative timers to timestamps, and is replaced in tests.
+	timeNow = time.Now
+
+	durationRegexp     = regexp.MustCompile(`(\d+)([YMwdhms])`)
+	durationDaysRegexp = regexp.MustCompile(`^(\d+)d(\d+:.*)$`)
+	durationUnits      = map[string]float64{
+		"Y": 365 * 24 * 60 * 60,
+		"M": 30 * 24 * 60 * 60,
+		"w": 7 * 24 * 60 * 60,
+		"d": 24 * 60 * 60,
+		"h": 60 * 60,
+		"m": 60,
+		"s": 1,
+	}
 )
 
 // CLIHelper is used to populate flags.
@@ -143,6 +164,17 @@ func runCollector(ch chan<- prometheus.Metric, errCh chan<- int, collector *Coll
 	ch <- prometheus.MustNewConstMetric(frrDesc["frrScrapeDuration"], prometheus.GaugeValue, float64(time.Since(startTime).Seconds()), collector.Name)
 }
 
+func execVtyshCommand(args ...string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), vtyshTimeout)
+	defer cancel()
+
+	output, err := exec.CommandContext(ctx, vtyshPath, args...).Output()
+	if err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
 func promDesc(metricName string, metricDescription string, labels []string) *prometheus.Desc {
 	return prometheus.NewDesc(namespace+"_"+metricName, metricDescription, labels, nil)
 }
@@ -158,3 +190,38 @@ func newGauge(ch chan<- prometheus.Metric, descName *prometheus.Desc, metric flo
 func newCounter(ch chan<- prometheus.Metric, descName *prometheus.Desc, metric float64, labels ...string) {
 	ch <- prometheus.MustNewConstMetric(descName, prometheus.CounterValue, metric, labels...)
 }
+
+// parseDuration parses the durations of FRR output into seconds. Short durations are formatted as HH:MM:SS, such as
+// 00:01:28, or with a leading number of days, such as 1d02:03:04, and others as years, months, weeks, days, hours,
+// minutes and seconds, such as 2d03h15m or 1m25s.
+func parseDuration(duration string) (float64, error) {
+	seconds := 0.0
+	// Durations without units, such as HH:MM:SS or a number of seconds, are parsed field by field.
+	if strings.Contains(duration, ":") || !durationRegexp.MatchString(duration) {
+		clock, days := duration, 0.0
+		if match := durationDaysRegexp.FindStringSubmatch(duration); match != nil {
+			value, err := strconv.ParseFloat(match[1], 64)
+			if err != nil {
+				return 0, fmt.Errorf("cannot parse duration %q: %s", duration, err)
+			}
+			clock, days = match[2], value
+		}
+		for _, field := range strings.Split(clock, ":") {
+			value, err := strconv.ParseFloat(field, 64)
+			if err != nil {
+				return 0, fmt.Errorf("cannot parse duration %q: %s", duration, err)
+			}
+			seconds = seconds*60 + value
+		}
+		return days*durationUnits["d"] + seconds, nil
+	}
+
+	for _, match := range durationRegexp.FindAllStringSubmatch(duration, -1) {
+		value, err := strconv.ParseFloat(match[1], 64)
+		if err != nil {
+			return 0, fmt.Errorf("cannot parse duration %q: %s", duration, err)
+		}
+		seconds += value * durationUnits[match[2]]
+	}
+	return seconds, nil
+}
@@ -0,0 +1,33 @@
+package collector
+
+import (
+	"testing"
+)
+
+func TestParseDuration(t *testing.T) {
+	for duration, expected := range map[string]float64{
+		"00:01:28":   88,
+		"12:00:00":   43200,
+		"1d02:03:04": 24*60*60 + 2*60*60 + 3*60 + 4,
+		"35.5":       35.5,
+		"2d03h15m":   2*24*60*60 + 3*60*60 + 15*60,
+		"1m25s":      85,
+		"1w2d":       9 * 24 * 60 * 60,
+		"1Y2M":       365*24*60*60 + 2*30*24*60*60,
+	} {
+		got, err := parseDuration(duration)
+		if err != nil {
+			t.Errorf("error calling parseDuration %q: %s", duration, err)
+			continue
+		}
+		if got != expected {
+			t.Errorf("unexpected duration for %q: got %v, expected %v", duration, got, expected)
+		}
+	}
+
+	for _, duration := range []string{"", "never", "00:aa:01"} {
+		if _, err := parseDuration(duration); err == nil {
+			t.Errorf("expected error calling parseDuration %q", duration)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package collector
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	configSubsystem = "config"
+
+	configDesc = map[string]*prometheus.Desc{
+		"hashInfo":   colPromDesc(configSubsystem, "hash_info", "SHA-256 hash of the running configuration of FRR, which changes whenever the running configuration changes. Value is always 1.", []string{"hash"}),
+		"lastChange": colPromDesc(configSubsystem, "last_change_timestamp_seconds", "Time of the scrape at which the exporter first saw the current running configuration, in seconds since the Unix epoch.", nil),
+	}
+	configErrors      = []error{}
+	totalConfigErrors = 0.0
+
+	// FRR does not expose when the running configuration last changed, so the hash of the running configuration is
+	// tracked between scrapes.
+	configLastHash   = ""
+	configLastChange = 0.0
+	configHashMu     sync.Mutex
+)
+
+// ConfigCollector collects running configuration metrics, implemented as per prometheus.Collector interface.
+type ConfigCollector struct{}
+
+// NewConfigCollector returns a ConfigCollector struct.
+func NewConfigCollector() *ConfigCollector {
+	return &ConfigCollector{}
+}
+
+// Name of the collector. Used to populate flag name.
+func (*ConfigCollector) Name() string {
+	return configSubsystem
+}
+
+// Help describes the metrics this collector scrapes. Used to populate flag help.
+func (*ConfigCollector) Help() string {
+	return "Collect Running Configuration Metrics"
+}
+
+// EnabledByDefault describes whether this collector is enabled by default. Used to populate flag default.
+func (*ConfigCollector) EnabledByDefault() bool {
+	return false
+}
+
+// Describe implemented as per the prometheus.Collector interface.
+func (*ConfigCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range configDesc {
+		ch <- desc
+	}
+}
+
+// Collect implemented as per the prometheus.Collector interface.
+func (c *ConfigCollector) Collect(ch chan<- prometheus.Metric) {
+	configErrors = []error{}
+
+	config, err := execVtyshCommand("-c", "show running-config")
+	if err != nil {
+		configErrors = append(configErrors, fmt.Errorf("cannot get running-config: %s", err))
+	} else {
+		processConfig(ch, config)
+	}
+
+	totalConfigErrors += float64(len(configErrors))
+}
+
+// CollectErrors returns what errors have been gathered.
+func (*ConfigCollector) CollectErrors() []error {
+	return configErrors
+}
+
+// CollectTotalErrors returns total errors.
+func (*ConfigCollector) CollectTotalErrors() float64 {
+	return totalConfigErrors
+}
+
+func processConfig(ch chan<- prometheus.Metric, config []byte) {
+	sum := sha256.Sum256(config)
+	hash := hex.EncodeToString(sum[:])
+	// The labels are "hash"
+	newGauge(ch, configDesc["hashInfo"], 1, hash)
+	newGauge(ch, configDesc["lastChange"], configChange(hash))
+}
+
+// configChange records the hash of the running configuration and returns the timestamp of when the hash was first
+// seen.
+func configChange(hash string) float64 {
+	configHashMu.Lock()
+	defer configHashMu.Unlock()
+
+	if hash != configLastHash {
+		configLastHash, configLastChange = hash, float64(timeNow().Unix())
+	}
+	return configLastChange
+}
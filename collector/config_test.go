@@ -0,0 +1,37 @@
+package collector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestProcessConfig(t *testing.T) {
+	defer func() { configLastHash, configLastChange = "", 0 }()
+	defer func() { timeNow = time.Now }()
+
+	timeNow = func() time.Time { return time.Unix(1600000000, 0) }
+	ch := make(chan prometheus.Metric, 1024)
+	processConfig(ch, []byte("Building configuration...\n\nCurrent configuration:\n!\nfrr version 8.4.2\nhostname r1\n!\nend\n"))
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, map[string]float64{
+		"frr_config_hash_info{hash=c3adada7ea73269e72482f42e4a224f87e61856a154ace125d720ccc5714601b}": 1,
+		"frr_config_last_change_timestamp_seconds{}":                                                  1600000000,
+	})
+}
+
+func TestConfigChange(t *testing.T) {
+	defer func() { configLastHash, configLastChange = "", 0 }()
+	defer func() { timeNow = time.Now }()
+
+	for i, hash := range []string{"a", "a", "b", "b", "a"} {
+		timeNow = func() time.Time { return time.Unix(1600000000+int64(i), 0) }
+		changed := configChange(hash)
+		if expected := []float64{1600000000, 1600000000, 1600000002, 1600000002, 1600000004}[i]; changed != expected {
+			t.Errorf("config change %d to %q expected %v got %v", i, hash, expected, changed)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package collector
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/procfs"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+var (
+	daemonSubsystem = "daemon"
+
+	daemonPIDDir = kingpin.Flag("collector.daemon.pid-dir", "Directory of the PID files of the FRR daemons, used by the daemon collector to find the processes of the daemons in /proc (default: /var/run/frr).").Default("/var/run/frr").String()
+
+	daemonLabels = []string{"daemon"}
+	daemonDesc   = map[string]*prometheus.Desc{
+		"pid":       colPromDesc(daemonSubsystem, "pid", "Process ID of the daemon.", daemonLabels),
+		"startTime": colPromDesc(daemonSubsystem, "start_time_seconds", "Start time of the process of the daemon, in seconds since the Unix epoch.", daemonLabels),
+		"uptime":    colPromDesc(daemonSubsystem, "uptime_seconds", "How long has the process of the daemon been running, which resets when the daemon restarts.", daemonLabels),
+	}
+	daemonErrors      = []error{}
+	totalDaemonErrors = 0.0
+)
+
+// DaemonCollector collects daemon metrics, implemented as per prometheus.Collector interface.
+type DaemonCollector struct{}
+
+// NewDaemonCollector returns a DaemonCollector struct.
+func NewDaemonCollector() *DaemonCollector {
+	return &DaemonCollector{}
+}
+
+// Name of the collector. Used to populate flag name.
+func (*DaemonCollector) Name() string {
+	return daemonSubsystem
+}
+
+// Help describes the metrics this collector scrapes. Used to populate flag help.
+func (*DaemonCollector) Help() string {
+	return "Collect Daemon Uptime and PID Metrics"
+}
+
+// EnabledByDefault describes whether this collector is enabled by default. Used to populate flag default.
+func (*DaemonCollector) EnabledByDefault() bool {
+	return false
+}
+
+// Describe implemented as per the prometheus.Collector interface.
+func (*DaemonCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range daemonDesc {
+		ch <- desc
+	}
+}
+
+// Collect implemented as per the prometheus.Collector interface.
+func (c *DaemonCollector) Collect(ch chan<- prometheus.Metric) {
+	daemonErrors = []error{}
+
+	daemons, err := execVtyshCommand("-c", "show daemons")
+	if err != nil {
+		daemonErrors = append(daemonErrors, fmt.Errorf("cannot get daemons: %s", err))
+	} else {
+		// FRR does not expose the uptime of the daemons, so it is taken from the processes of the daemons.
+		fs, err := procfs.NewDefaultFS()
+		if err != nil {
+			daemonErrors = append(daemonErrors, fmt.Errorf("cannot open procfs: %s", err))
+		} else {
+			daemonErrors = append(daemonErrors, processDaemons(ch, daemons, *daemonPIDDir, fs)...)
+		}
+	}
+
+	totalDaemonErrors += float64(len(daemonErrors))
+}
+
+// CollectErrors returns what errors have been gathered.
+func (*DaemonCollector) CollectErrors() []error {
+	return daemonErrors
+}
+
+// CollectTotalErrors returns total errors.
+func (*DaemonCollector) CollectTotalErrors() float64 {
+	return totalDaemonErrors
+}
+
+// processDaemons processes the output of 'show daemons', such as " zebra bgpd staticd watchfrr", and returns an error
+// for each daemon of which the process cannot be found.
+func processDaemons(ch chan<- prometheus.Metric, output []byte, pidDir string, fs procfs.FS) []error {
+	errors := []error{}
+	for _, daemon := range strings.Fields(string(output)) {
+		pidFile, err := ioutil.ReadFile(filepath.Join(pidDir, daemon+".pid"))
+		if err != nil {
+			errors = append(errors, fmt.Errorf("cannot read pid file of daemon %s: %s", daemon, err))
+			continue
+		}
+		pid, err := strconv.Atoi(strings.TrimSpace(string(pidFile)))
+		if err != nil {
+			errors = append(errors, fmt.Errorf("cannot parse pid file of daemon %s: %s", daemon, err))
+			continue
+		}
+		proc, err := fs.Proc(pid)
+		if err != nil {
+			errors = append(errors, fmt.Errorf("cannot get process of daemon %s: %s", daemon, err))
+			continue
+		}
+		stat, err := proc.Stat()
+		if err != nil {
+			errors = append(errors, fmt.Errorf("cannot get process stat of daemon %s: %s", daemon, err))
+			continue
+		}
+		startTime, err := stat.StartTime()
+		if err != nil {
+			errors = append(errors, fmt.Errorf("cannot get process start time of daemon %s: %s", daemon, err))
+			continue
+		}
+		// The labels are "daemon"
+		newGauge(ch, daemonDesc["pid"], float64(pid), daemon)
+		newGauge(ch, daemonDesc["startTime"], startTime, daemon)
+		newGauge(ch, daemonDesc["uptime"], float64(timeNow().Unix())-startTime, daemon)
+	}
+	return errors
+}
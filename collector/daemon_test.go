@@ -0,0 +1,63 @@
+package collector
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/procfs"
+)
+
+func TestProcessDaemons(t *testing.T) {
+	dir, err := ioutil.TempDir("", "frr_exporter")
+	if err != nil {
+		t.Fatalf("error creating temp dir: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	// The process of zebra started 500 seconds after boot, as the start time is in clock ticks of 1/100 seconds.
+	files := map[string]string{
+		"run/zebra.pid":   "1234\n",
+		"run/bgpd.pid":    "not a pid\n",
+		"proc/stat":       "cpu  1 2 3 4 5 6 7 8 9 10\nbtime 1600000000\n",
+		"proc/1234/stat":  "1234 (zebra) S 1 1234 1234 0 -1 4194560 100 0 0 0 10 20 0 0 20 0 4 0 50000 100000000 1000 18446744073709551615 1 1 0 0 0 0 0 0 0 0 0 0 17 0 0 0 0 0 0\n",
+		"proc/1235/stat":  "1235 (staticd) S 1 1235 1235 0 -1 4194560 100 0 0 0 10 20 0 0 20 0 4 0 70000 100000000 1000 18446744073709551615 1 1 0 0 0 0 0 0 0 0 0 0 17 0 0 0 0 0 0\n",
+		"run/staticd.pid": "1235\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("error creating dir of %s: %s", name, err)
+		}
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("error writing %s: %s", name, err)
+		}
+	}
+	fs, err := procfs.NewFS(filepath.Join(dir, "proc"))
+	if err != nil {
+		t.Fatalf("error opening procfs: %s", err)
+	}
+
+	timeNow = func() time.Time { return time.Unix(1600003600, 0) }
+	defer func() { timeNow = time.Now }()
+
+	ch := make(chan prometheus.Metric, 1024)
+	// There is no pid file of watchfrr, and the pid file of bgpd is invalid.
+	if errors := processDaemons(ch, []byte(" zebra bgpd staticd watchfrr\n"), filepath.Join(dir, "run"), fs); len(errors) != 2 {
+		t.Errorf("got %d errors calling processDaemons, want 2: %v", len(errors), errors)
+	}
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, map[string]float64{
+		"frr_daemon_pid{daemon=zebra}":                  1234,
+		"frr_daemon_pid{daemon=staticd}":                1235,
+		"frr_daemon_start_time_seconds{daemon=zebra}":   1600000500,
+		"frr_daemon_start_time_seconds{daemon=staticd}": 1600000700,
+		"frr_daemon_uptime_seconds{daemon=zebra}":       3100,
+		"frr_daemon_uptime_seconds{daemon=staticd}":     2900,
+	})
+}
@@ -0,0 +1,327 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	evpnSubsystem       = "evpn"
+	evpnVNIMetricPrefix = "evpn_vni"
+	evpnESMetricPrefix  = "evpn_es"
+
+	evpnVNILabels = []string{"vni", "type", "vrf"}
+	evpnDesc      = map[string]*prometheus.Desc{
+		"vniInfo":        colPromDesc(evpnVNIMetricPrefix, "info", "VxLAN interface of the VNI. Value is always 1.", append(evpnVNILabels, "vxlan_if")),
+		"vniUp":          colPromDesc(evpnVNIMetricPrefix, "up", "State of the VNI (1 = Up, 0 = Down).", evpnVNILabels),
+		"vniMACs":        colPromDesc(evpnVNIMetricPrefix, "macs_count_total", "Number of MACs of the VNI, the router MACs for L3 VNIs.", evpnVNILabels),
+		"vniARPND":       colPromDesc(evpnVNIMetricPrefix, "arp_nd_count_total", "Number of ARP and ND entries of the VNI, the nexthops for L3 VNIs.", evpnVNILabels),
+		"vniRemoteVTEPs": colPromDesc(evpnVNIMetricPrefix, "remote_vteps_count_total", "Number of remote VTEPs of the L2 VNI.", evpnVNILabels),
+
+		"macs":      colPromDesc(evpnSubsystem, "macs_count_total", "Number of MACs of the L2 VNI per type (local/remote).", []string{"vni", "type"}),
+		"neighbors": colPromDesc(evpnSubsystem, "neighbors_count_total", "Number of ARP (ipv4) and ND (ipv6) entries of the L2 VNI per type (local/remote).", []string{"vni", "type", "afi"}),
+
+		"esInfo":      colPromDesc(evpnESMetricPrefix, "info", "Access port of the Ethernet Segment, which is empty for remote Ethernet Segments. Value is always 1.", []string{"esi", "access_port"}),
+		"esFlagInfo":  colPromDesc(evpnESMetricPrefix, "flag_info", "Flags of the Ethernet Segment, such as local, remote or nonDF. Value is always 1.", []string{"esi", "flag"}),
+		"esDF":        colPromDesc(evpnESMetricPrefix, "df", "Whether the router is the designated forwarder (DF) of the local Ethernet Segment (1 = DF, 0 = Non-DF).", []string{"esi"}),
+		"esDFChanges": colPromDesc(evpnESMetricPrefix, "df_changes_total", "Number of times the DF election result of the local Ethernet Segment has changed since the exporter started.", []string{"esi"}),
+		"esVTEPs":     colPromDesc(evpnESMetricPrefix, "vteps_count_total", "Number of remote VTEPs that are members of the Ethernet Segment.", []string{"esi"}),
+	}
+	evpnErrors      = []error{}
+	totalEVPNErrors = 0.0
+
+	// FRR does not count the changes of the DF election result, so the DF of each Ethernet Segment is tracked between
+	// scrapes.
+	evpnLastDF    = map[string]bool{}
+	evpnDFChanges = map[string]float64{}
+	evpnDFMu      sync.Mutex
+)
+
+// EVPNCollector collects EVPN metrics, implemented as per prometheus.Collector interface.
+type EVPNCollector struct{}
+
+// NewEVPNCollector returns a EVPNCollector struct.
+func NewEVPNCollector() *EVPNCollector {
+	return &EVPNCollector{}
+}
+
+// Name of the collector. Used to populate flag name.
+func (*EVPNCollector) Name() string {
+	return evpnSubsystem
+}
+
+// Help describes the metrics this collector scrapes. Used to populate flag help.
+func (*EVPNCollector) Help() string {
+	return "Collect EVPN Metrics"
+}
+
+// EnabledByDefault describes whether this collector is enabled by default. Used to populate flag default.
+func (*EVPNCollector) EnabledByDefault() bool {
+	return false
+}
+
+// Describe implemented as per the prometheus.Collector interface.
+func (*EVPNCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range evpnDesc {
+		ch <- desc
+	}
+}
+
+// Collect implemented as per the prometheus.Collector interface.
+func (c *EVPNCollector) Collect(ch chan<- prometheus.Metric) {
+	evpnErrors = []error{}
+
+	jsonEVPNVNI, err := execVtyshCommand("-c", "show evpn vni json")
+	if err != nil {
+		evpnErrors = append(evpnErrors, fmt.Errorf("cannot get evpn vnis: %s", err))
+	} else {
+		if err := processEVPNVNI(ch, jsonEVPNVNI); err != nil {
+			evpnErrors = append(evpnErrors, err)
+		}
+	}
+
+	// The state of the VNIs is only included in the detail.
+	jsonEVPNVNIDetail, err := execVtyshCommand("-c", "show evpn vni detail json")
+	if err != nil {
+		evpnErrors = append(evpnErrors, fmt.Errorf("cannot get evpn vni detail: %s", err))
+	} else {
+		if err := processEVPNVNIDetail(ch, jsonEVPNVNIDetail); err != nil {
+			evpnErrors = append(evpnErrors, err)
+		}
+	}
+
+	jsonEVPNMAC, err := execVtyshCommand("-c", "show evpn mac vni all json")
+	if err != nil {
+		evpnErrors = append(evpnErrors, fmt.Errorf("cannot get evpn macs: %s", err))
+	} else {
+		if err := processEVPNMAC(ch, jsonEVPNMAC); err != nil {
+			evpnErrors = append(evpnErrors, err)
+		}
+	}
+
+	jsonEVPNARPCache, err := execVtyshCommand("-c", "show evpn arp-cache vni all json")
+	if err != nil {
+		evpnErrors = append(evpnErrors, fmt.Errorf("cannot get evpn arp-cache: %s", err))
+	} else {
+		if err := processEVPNARPCache(ch, jsonEVPNARPCache); err != nil {
+			evpnErrors = append(evpnErrors, err)
+		}
+	}
+
+	jsonEVPNES, err := execVtyshCommand("-c", "show evpn es json")
+	if err != nil {
+		evpnErrors = append(evpnErrors, fmt.Errorf("cannot get evpn es: %s", err))
+	} else {
+		if err := processEVPNES(ch, jsonEVPNES); err != nil {
+			evpnErrors = append(evpnErrors, err)
+		}
+	}
+
+	totalEVPNErrors += float64(len(evpnErrors))
+}
+
+// CollectErrors returns what errors have been gathered.
+func (*EVPNCollector) CollectErrors() []error {
+	return evpnErrors
+}
+
+// CollectTotalErrors returns total errors.
+func (*EVPNCollector) CollectTotalErrors() float64 {
+	return totalEVPNErrors
+}
+
+func processEVPNVNI(ch chan<- prometheus.Metric, jsonEVPNVNI []byte) error {
+	// The VNIs are keyed by the VNI.
+	var evpnVNIs map[string]struct {
+		evpnVNI
+		VxlanIf string
+		NumMacs float64
+		// The number of ARP and ND entries and remote VTEPs are strings for L3 VNIs without them, such as "n/a".
+		NumArpNd       interface{}
+		NumRemoteVteps interface{}
+	}
+	if err := json.Unmarshal(jsonEVPNVNI, &evpnVNIs); err != nil {
+		return fmt.Errorf("cannot unmarshal evpn vni json: %s", err)
+	}
+
+	for _, vni := range evpnVNIs {
+		// The labels are "vni", "type", "vrf"
+		labels := vni.labels()
+		newGauge(ch, evpnDesc["vniInfo"], 1, append(labels, vni.VxlanIf)...)
+		newGauge(ch, evpnDesc["vniMACs"], vni.NumMacs, labels...)
+		if arpNd, ok := vni.NumArpNd.(float64); ok {
+			newGauge(ch, evpnDesc["vniARPND"], arpNd, labels...)
+		}
+		if remoteVTEPs, ok := vni.NumRemoteVteps.(float64); ok {
+			newGauge(ch, evpnDesc["vniRemoteVTEPs"], remoteVTEPs, labels...)
+		}
+	}
+	return nil
+}
+
+func processEVPNVNIDetail(ch chan<- prometheus.Metric, jsonEVPNVNIDetail []byte) error {
+	// The VNIs are keyed by the VNI.
+	var evpnVNIs map[string]struct {
+		evpnVNI
+		State string
+	}
+	if err := json.Unmarshal(jsonEVPNVNIDetail, &evpnVNIs); err != nil {
+		return fmt.Errorf("cannot unmarshal evpn vni detail json: %s", err)
+	}
+
+	for _, vni := range evpnVNIs {
+		// Older FRR versions only include the state for L3 VNIs.
+		if vni.State == "" {
+			continue
+		}
+		up := 0.0
+		if vni.State == "Up" {
+			up = 1
+		}
+		// The labels are "vni", "type", "vrf"
+		newGauge(ch, evpnDesc["vniUp"], up, vni.labels()...)
+	}
+	return nil
+}
+
+func processEVPNMAC(ch chan<- prometheus.Metric, jsonEVPNMAC []byte) error {
+	// The VNIs are keyed by the VNI, and the MACs of each VNI are keyed by the MAC.
+	var evpnMACs map[string]struct {
+		Macs map[string]struct {
+			Type string
+		}
+	}
+	if err := json.Unmarshal(jsonEVPNMAC, &evpnMACs); err != nil {
+		return fmt.Errorf("cannot unmarshal evpn mac json: %s", err)
+	}
+
+	for vni, vniData := range evpnMACs {
+		macs := map[string]float64{"local": 0, "remote": 0}
+		for _, mac := range vniData.Macs {
+			macs[mac.Type]++
+		}
+		for macType, count := range macs {
+			// The labels are "vni", "type"
+			newGauge(ch, evpnDesc["macs"], count, vni, macType)
+		}
+	}
+	return nil
+}
+
+func processEVPNARPCache(ch chan<- prometheus.Metric, jsonEVPNARPCache []byte) error {
+	// The VNIs are keyed by the VNI, and the ARP and ND entries of each VNI are keyed by the IP address alongside the
+	// number of entries.
+	var evpnARPCache map[string]map[string]json.RawMessage
+	if err := json.Unmarshal(jsonEVPNARPCache, &evpnARPCache); err != nil {
+		return fmt.Errorf("cannot unmarshal evpn arp-cache json: %s", err)
+	}
+
+	for vni, vniData := range evpnARPCache {
+		neighbors := map[[2]string]float64{{"local", "ipv4"}: 0, {"local", "ipv6"}: 0, {"remote", "ipv4"}: 0, {"remote", "ipv6"}: 0}
+		for ip, jsonNeighbor := range vniData {
+			var neighbor struct {
+				Type string
+			}
+			// Keys other than the entries, such as numArpNd, are not objects.
+			if err := json.Unmarshal(jsonNeighbor, &neighbor); err != nil {
+				continue
+			}
+			afi := "ipv4"
+			if strings.Contains(ip, ":") {
+				afi = "ipv6"
+			}
+			neighbors[[2]string{neighbor.Type, afi}]++
+		}
+		for key, count := range neighbors {
+			// The labels are "vni", "type", "afi"
+			newGauge(ch, evpnDesc["neighbors"], count, vni, key[0], key[1])
+		}
+	}
+	return nil
+}
+
+func processEVPNES(ch chan<- prometheus.Metric, jsonEVPNES []byte) error {
+	var evpnESs []struct {
+		Esi        string
+		AccessPort string
+		Flags      []string
+		Vteps      []struct {
+			Vtep string
+		}
+	}
+	if err := json.Unmarshal(jsonEVPNES, &evpnESs); err != nil {
+		return fmt.Errorf("cannot unmarshal evpn es json: %s", err)
+	}
+
+	seen := map[string]bool{}
+	for _, es := range evpnESs {
+		// The labels are "esi", "access_port"
+		newGauge(ch, evpnDesc["esInfo"], 1, es.Esi, es.AccessPort)
+		local, df := false, true
+		for _, flag := range es.Flags {
+			// The labels are "esi", "flag"
+			newGauge(ch, evpnDesc["esFlagInfo"], 1, es.Esi, flag)
+			switch flag {
+			case "local":
+				local = true
+			case "nonDF":
+				df = false
+			}
+		}
+		// The labels are "esi"
+		newGauge(ch, evpnDesc["esVTEPs"], float64(len(es.Vteps)), es.Esi)
+		// The DF election only applies to Ethernet Segments with a local access port.
+		if !local {
+			continue
+		}
+		isDF := 0.0
+		if df {
+			isDF = 1
+		}
+		newGauge(ch, evpnDesc["esDF"], isDF, es.Esi)
+		seen[es.Esi] = true
+		newCounter(ch, evpnDesc["esDFChanges"], evpnDFChange(es.Esi, df), es.Esi)
+	}
+	evpnDFPrune(seen)
+	return nil
+}
+
+// evpnDFChange records the DF election result of an Ethernet Segment and returns the number of times it has changed.
+func evpnDFChange(esi string, df bool) float64 {
+	evpnDFMu.Lock()
+	defer evpnDFMu.Unlock()
+
+	if lastDF, exist := evpnLastDF[esi]; exist && lastDF != df {
+		evpnDFChanges[esi]++
+	}
+	evpnLastDF[esi] = df
+	return evpnDFChanges[esi]
+}
+
+// evpnDFPrune removes the Ethernet Segments that no longer exist or no longer have a local access port.
+func evpnDFPrune(seen map[string]bool) {
+	evpnDFMu.Lock()
+	defer evpnDFMu.Unlock()
+
+	for esi := range evpnLastDF {
+		if !seen[esi] {
+			delete(evpnLastDF, esi)
+			delete(evpnDFChanges, esi)
+		}
+	}
+}
+
+type evpnVNI struct {
+	Vni       float64
+	Type      string
+	TenantVrf string
+}
+
+func (v evpnVNI) labels() []string {
+	return []string{strconv.FormatFloat(v.Vni, 'f', -1, 64), v.Type, strings.ToLower(v.TenantVrf)}
+}
@@ -0,0 +1,201 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestProcessEVPNVNI(t *testing.T) {
+	jsonEVPNVNI := []byte(`{
+  "10100":{"vni":10100,"type":"L2","vxlanIf":"vni10100","numMacs":5,"numArpNd":3,"numRemoteVteps":2,"tenantVrf":"Red","remoteVteps":["10.0.0.2","10.0.0.3"]},
+  "10200":{"vni":10200,"type":"L2","vxlanIf":"vni10200","numMacs":0,"numArpNd":0,"numRemoteVteps":0,"tenantVrf":"default"},
+  "4001":{"vni":4001,"vxlanIf":"vni4001","numMacs":2,"numArpNd":2,"numRemoteVteps":"n\/a","type":"L3","tenantVrf":"Red"}
+}`)
+
+	ch := make(chan prometheus.Metric, 1024)
+	if err := processEVPNVNI(ch, jsonEVPNVNI); err != nil {
+		t.Errorf("error calling processEVPNVNI: %s", err)
+	}
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, map[string]float64{
+		"frr_evpn_vni_info{type=L2,vni=10100,vrf=red,vxlan_if=vni10100}":       1,
+		"frr_evpn_vni_info{type=L2,vni=10200,vrf=default,vxlan_if=vni10200}":   1,
+		"frr_evpn_vni_info{type=L3,vni=4001,vrf=red,vxlan_if=vni4001}":         1,
+		"frr_evpn_vni_macs_count_total{type=L2,vni=10100,vrf=red}":             5,
+		"frr_evpn_vni_macs_count_total{type=L2,vni=10200,vrf=default}":         0,
+		"frr_evpn_vni_macs_count_total{type=L3,vni=4001,vrf=red}":              2,
+		"frr_evpn_vni_arp_nd_count_total{type=L2,vni=10100,vrf=red}":           3,
+		"frr_evpn_vni_arp_nd_count_total{type=L2,vni=10200,vrf=default}":       0,
+		"frr_evpn_vni_arp_nd_count_total{type=L3,vni=4001,vrf=red}":            2,
+		"frr_evpn_vni_remote_vteps_count_total{type=L2,vni=10100,vrf=red}":     2,
+		"frr_evpn_vni_remote_vteps_count_total{type=L2,vni=10200,vrf=default}": 0,
+	})
+}
+
+func TestProcessEVPNVNIDetail(t *testing.T) {
+	jsonEVPNVNIDetail := []byte(`{
+  "10100":{"vni":10100,"type":"L2","tenantVrf":"Red","vxlanInterface":"vni10100","ifindex":9,"vtepIp":"10.0.0.1","state":"Up"},
+  "10200":{"vni":10200,"type":"L2","tenantVrf":"default","vxlanInterface":"vni10200","ifindex":10,"vtepIp":"10.0.0.1"},
+  "4001":{"vni":4001,"type":"L3","tenantVrf":"Red","localVtepIp":"10.0.0.1","vxlanIntf":"vni4001","sviIntf":"br4001","state":"Down"}
+}`)
+
+	ch := make(chan prometheus.Metric, 1024)
+	if err := processEVPNVNIDetail(ch, jsonEVPNVNIDetail); err != nil {
+		t.Errorf("error calling processEVPNVNIDetail: %s", err)
+	}
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, map[string]float64{
+		"frr_evpn_vni_up{type=L2,vni=10100,vrf=red}": 1,
+		"frr_evpn_vni_up{type=L3,vni=4001,vrf=red}":  0,
+	})
+}
+
+func TestProcessEVPNMAC(t *testing.T) {
+	jsonEVPNMAC := []byte(`{
+  "10100":{
+    "numMacs":3,
+    "macs":{
+      "00:00:00:00:01:01":{"type":"local","intf":"swp1","vlan":100,"localSequence":0,"remoteSequence":0,"detectionCount":0,"isDuplicate":false},
+      "00:00:00:00:01:02":{"type":"local","intf":"swp2","vlan":100,"localSequence":0,"remoteSequence":0,"detectionCount":0,"isDuplicate":false},
+      "00:00:00:00:02:01":{"type":"remote","remoteVtep":"10.0.0.2","localSequence":0,"remoteSequence":0,"detectionCount":0,"isDuplicate":false}
+    }
+  },
+  "10200":{
+    "numMacs":0,
+    "macs":{}
+  }
+}`)
+
+	ch := make(chan prometheus.Metric, 1024)
+	if err := processEVPNMAC(ch, jsonEVPNMAC); err != nil {
+		t.Errorf("error calling processEVPNMAC: %s", err)
+	}
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, map[string]float64{
+		"frr_evpn_macs_count_total{type=local,vni=10100}":  2,
+		"frr_evpn_macs_count_total{type=remote,vni=10100}": 1,
+		"frr_evpn_macs_count_total{type=local,vni=10200}":  0,
+		"frr_evpn_macs_count_total{type=remote,vni=10200}": 0,
+	})
+}
+
+func TestProcessEVPNARPCache(t *testing.T) {
+	jsonEVPNARPCache := []byte(`{
+  "10100":{
+    "numArpNd":4,
+    "10.1.1.1":{"type":"local","state":"active","mac":"00:00:00:00:01:01","localSequence":0,"remoteSequence":0,"detectionCount":0,"isDuplicate":false},
+    "10.1.1.2":{"type":"remote","state":"active","mac":"00:00:00:00:02:01","remoteVtep":"10.0.0.2","localSequence":0,"remoteSequence":0,"detectionCount":0,"isDuplicate":false},
+    "fe80::1":{"type":"local","state":"active","mac":"00:00:00:00:01:01","localSequence":0,"remoteSequence":0,"detectionCount":0,"isDuplicate":false},
+    "2001:db8::2":{"type":"remote","state":"active","mac":"00:00:00:00:02:01","remoteVtep":"10.0.0.2","localSequence":0,"remoteSequence":0,"detectionCount":0,"isDuplicate":false}
+  }
+}`)
+
+	ch := make(chan prometheus.Metric, 1024)
+	if err := processEVPNARPCache(ch, jsonEVPNARPCache); err != nil {
+		t.Errorf("error calling processEVPNARPCache: %s", err)
+	}
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, map[string]float64{
+		"frr_evpn_neighbors_count_total{afi=ipv4,type=local,vni=10100}":  1,
+		"frr_evpn_neighbors_count_total{afi=ipv6,type=local,vni=10100}":  1,
+		"frr_evpn_neighbors_count_total{afi=ipv4,type=remote,vni=10100}": 1,
+		"frr_evpn_neighbors_count_total{afi=ipv6,type=remote,vni=10100}": 1,
+	})
+}
+
+func TestProcessEVPNES(t *testing.T) {
+	defer func() { evpnLastDF, evpnDFChanges = map[string]bool{}, map[string]float64{} }()
+
+	jsonEVPNES := []byte(`[
+  {
+    "esi":"03:44:38:39:ff:ff:01:00:00:01",
+    "accessPort":"hostbond1",
+    "flags":["local","remote","readyForBgp","bridgePort","operUp"],
+    "vniCount":2,
+    "macCount":3,
+    "dfPreference":50000,
+    "nexthopGroup":536870913,
+    "vteps":[{"vtep":"10.0.0.2","dfAlgorithm":"preference","dfPreference":32767,"nexthopId":268435457}]
+  },
+  {
+    "esi":"03:44:38:39:ff:ff:01:00:00:02",
+    "accessPort":"hostbond2",
+    "flags":["local","remote","nonDF","readyForBgp","bridgePort","operUp"],
+    "vniCount":2,
+    "macCount":1,
+    "dfPreference":32767,
+    "vteps":[{"vtep":"10.0.0.2","dfAlgorithm":"preference","dfPreference":50000},{"vtep":"10.0.0.3","dfAlgorithm":"preference","dfPreference":40000}]
+  },
+  {
+    "esi":"03:44:38:39:ff:ff:02:00:00:01",
+    "flags":["remote"],
+    "vniCount":0,
+    "macCount":2,
+    "vteps":[{"vtep":"10.0.0.4"}]
+  }
+]`)
+
+	ch := make(chan prometheus.Metric, 1024)
+	if err := processEVPNES(ch, jsonEVPNES); err != nil {
+		t.Errorf("error calling processEVPNES: %s", err)
+	}
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, map[string]float64{
+		"frr_evpn_es_info{access_port=hostbond1,esi=03:44:38:39:ff:ff:01:00:00:01}": 1,
+		"frr_evpn_es_info{access_port=hostbond2,esi=03:44:38:39:ff:ff:01:00:00:02}": 1,
+		"frr_evpn_es_info{access_port=,esi=03:44:38:39:ff:ff:02:00:00:01}":          1,
+		"frr_evpn_es_flag_info{esi=03:44:38:39:ff:ff:01:00:00:01,flag=local}":       1,
+		"frr_evpn_es_flag_info{esi=03:44:38:39:ff:ff:01:00:00:01,flag=remote}":      1,
+		"frr_evpn_es_flag_info{esi=03:44:38:39:ff:ff:01:00:00:01,flag=readyForBgp}": 1,
+		"frr_evpn_es_flag_info{esi=03:44:38:39:ff:ff:01:00:00:01,flag=bridgePort}":  1,
+		"frr_evpn_es_flag_info{esi=03:44:38:39:ff:ff:01:00:00:01,flag=operUp}":      1,
+		"frr_evpn_es_flag_info{esi=03:44:38:39:ff:ff:01:00:00:02,flag=local}":       1,
+		"frr_evpn_es_flag_info{esi=03:44:38:39:ff:ff:01:00:00:02,flag=remote}":      1,
+		"frr_evpn_es_flag_info{esi=03:44:38:39:ff:ff:01:00:00:02,flag=nonDF}":       1,
+		"frr_evpn_es_flag_info{esi=03:44:38:39:ff:ff:01:00:00:02,flag=readyForBgp}": 1,
+		"frr_evpn_es_flag_info{esi=03:44:38:39:ff:ff:01:00:00:02,flag=bridgePort}":  1,
+		"frr_evpn_es_flag_info{esi=03:44:38:39:ff:ff:01:00:00:02,flag=operUp}":      1,
+		"frr_evpn_es_flag_info{esi=03:44:38:39:ff:ff:02:00:00:01,flag=remote}":      1,
+		"frr_evpn_es_df{esi=03:44:38:39:ff:ff:01:00:00:01}":                         1,
+		"frr_evpn_es_df{esi=03:44:38:39:ff:ff:01:00:00:02}":                         0,
+		"frr_evpn_es_df_changes_total{esi=03:44:38:39:ff:ff:01:00:00:01}":           0,
+		"frr_evpn_es_df_changes_total{esi=03:44:38:39:ff:ff:01:00:00:02}":           0,
+		"frr_evpn_es_vteps_count_total{esi=03:44:38:39:ff:ff:01:00:00:01}":          1,
+		"frr_evpn_es_vteps_count_total{esi=03:44:38:39:ff:ff:01:00:00:02}":          2,
+		"frr_evpn_es_vteps_count_total{esi=03:44:38:39:ff:ff:02:00:00:01}":          1,
+	})
+}
+
+func TestEVPNDFChange(t *testing.T) {
+	defer func() { evpnLastDF, evpnDFChanges = map[string]bool{}, map[string]float64{} }()
+
+	for i, df := range []bool{true, true, false, true, true} {
+		changes := evpnDFChange("03:44:38:39:ff:ff:01:00:00:01", df)
+		if expected := []float64{0, 0, 1, 2, 2}[i]; changes != expected {
+			t.Errorf("df change %d to %v expected %v got %v", i, df, expected, changes)
+		}
+	}
+
+	evpnDFChange("03:44:38:39:ff:ff:01:00:00:02", true)
+	evpnDFPrune(map[string]bool{"03:44:38:39:ff:ff:01:00:00:02": true})
+	if _, exist := evpnLastDF["03:44:38:39:ff:ff:01:00:00:01"]; exist {
+		t.Errorf("expected the df of the removed ethernet segment to be removed")
+	}
+	if _, exist := evpnDFChanges["03:44:38:39:ff:ff:01:00:00:01"]; exist {
+		t.Errorf("expected the df changes of the removed ethernet segment to be removed")
+	}
+	if _, exist := evpnLastDF["03:44:38:39:ff:ff:01:00:00:02"]; !exist {
+		t.Errorf("expected the df of the local ethernet segment to be kept")
+	}
+}
@@ -0,0 +1,139 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	interfaceSubsystem = "interface"
+
+	interfaceLabels = []string{"vrf", "iface"}
+	interfaceDesc   = map[string]*prometheus.Desc{
+		"adminUp":   colPromDesc(interfaceSubsystem, "admin_up", "Administrative state of the interface (1 = Up, 0 = Down).", interfaceLabels),
+		"operUp":    colPromDesc(interfaceSubsystem, "oper_up", "Operational state of the interface (1 = Up, 0 = Down).", interfaceLabels),
+		"mtu":       colPromDesc(interfaceSubsystem, "mtu_bytes", "MTU of the interface.", interfaceLabels),
+		"speed":     colPromDesc(interfaceSubsystem, "speed_bytes", "Speed of the interface in bytes per second, which is 0 if the speed is unknown.", interfaceLabels),
+		"addresses": colPromDesc(interfaceSubsystem, "addresses_count_total", "Number of addresses configured on the interface per address family.", append(interfaceLabels, "afi")),
+		"linkUps":   colPromDesc(interfaceSubsystem, "link_ups_total", "Number of times the link of the interface has come up.", interfaceLabels),
+		"linkDowns": colPromDesc(interfaceSubsystem, "link_downs_total", "Number of times the link of the interface has gone down.", interfaceLabels),
+	}
+	interfaceErrors      = []error{}
+	totalInterfaceErrors = 0.0
+)
+
+// InterfaceCollector collects interface metrics, implemented as per prometheus.Collector interface.
+type InterfaceCollector struct{}
+
+// NewInterfaceCollector returns a InterfaceCollector struct.
+func NewInterfaceCollector() *InterfaceCollector {
+	return &InterfaceCollector{}
+}
+
+// Name of the collector. Used to populate flag name.
+func (*InterfaceCollector) Name() string {
+	return interfaceSubsystem
+}
+
+// Help describes the metrics this collector scrapes. Used to populate flag help.
+func (*InterfaceCollector) Help() string {
+	return "Collect Interface Metrics"
+}
+
+// EnabledByDefault describes whether this collector is enabled by default. Used to populate flag default.
+func (*InterfaceCollector) EnabledByDefault() bool {
+	return false
+}
+
+// Describe implemented as per the prometheus.Collector interface.
+func (*InterfaceCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range interfaceDesc {
+		ch <- desc
+	}
+}
+
+// Collect implemented as per the prometheus.Collector interface.
+func (c *InterfaceCollector) Collect(ch chan<- prometheus.Metric) {
+	interfaceErrors = []error{}
+
+	jsonInterface, err := execVtyshCommand("-c", "show interface vrf all json")
+	if err != nil {
+		interfaceErrors = append(interfaceErrors, fmt.Errorf("cannot get interfaces: %s", err))
+	} else {
+		if err := processInterface(ch, jsonInterface); err != nil {
+			interfaceErrors = append(interfaceErrors, err)
+		}
+	}
+
+	totalInterfaceErrors += float64(len(interfaceErrors))
+}
+
+// CollectErrors returns what errors have been gathered.
+func (*InterfaceCollector) CollectErrors() []error {
+	return interfaceErrors
+}
+
+// CollectTotalErrors returns total errors.
+func (*InterfaceCollector) CollectTotalErrors() float64 {
+	return totalInterfaceErrors
+}
+
+func processInterface(ch chan<- prometheus.Metric, jsonInterface []byte) error {
+	// The interfaces of all VRFs are keyed by the interface name.
+	var interfaces map[string]struct {
+		AdministrativeStatus string
+		OperationalStatus    string
+		VrfName              string
+		Mtu                  float64
+		Speed                float64
+		LinkUps              float64
+		LinkDowns            float64
+		IPAddresses          []struct {
+			Address string
+		} `json:"ipAddresses"`
+	}
+	if err := json.Unmarshal(jsonInterface, &interfaces); err != nil {
+		return fmt.Errorf("cannot unmarshal interface json: %s", err)
+	}
+
+	for iface, ifaceData := range interfaces {
+		// The labels are "vrf", "iface"
+		labels := []string{strings.ToLower(ifaceData.VrfName), iface}
+		adminUp, operUp := 0.0, 0.0
+		if ifaceData.AdministrativeStatus == "up" {
+			adminUp = 1
+		}
+		if ifaceData.OperationalStatus == "up" {
+			operUp = 1
+		}
+		newGauge(ch, interfaceDesc["adminUp"], adminUp, labels...)
+		newGauge(ch, interfaceDesc["operUp"], operUp, labels...)
+		newGauge(ch, interfaceDesc["mtu"], ifaceData.Mtu, labels...)
+		// The speed is in Mbps, and is UINT32_MAX if the kernel does not report the speed of the interface.
+		speed := ifaceData.Speed
+		if speed == math.MaxUint32 {
+			speed = 0
+		}
+		newGauge(ch, interfaceDesc["speed"], speed*1000000/8, labels...)
+		newCounter(ch, interfaceDesc["linkUps"], ifaceData.LinkUps, labels...)
+		newCounter(ch, interfaceDesc["linkDowns"], ifaceData.LinkDowns, labels...)
+
+		addresses := map[string]float64{"ipv4": 0, "ipv6": 0}
+		for _, address := range ifaceData.IPAddresses {
+			if strings.Contains(address.Address, ":") {
+				addresses["ipv6"]++
+			} else {
+				addresses["ipv4"]++
+			}
+		}
+		for afi, count := range addresses {
+			// The labels are "vrf", "iface", "afi"
+			newGauge(ch, interfaceDesc["addresses"], count, append(labels, afi)...)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestProcessInterface(t *testing.T) {
+	jsonInterface := []byte(`{
+  "lo":{
+    "administrativeStatus":"up",
+    "operationalStatus":"up",
+    "linkDetection":true,
+    "linkUps":0,
+    "linkDowns":0,
+    "lastLinkUp":"",
+    "lastLinkDown":"",
+    "vrfName":"default",
+    "mtu":65536,
+    "mtu6":65536,
+    "speed":0,
+    "ifIndex":1,
+    "type":"Loopback",
+    "ipAddresses":[
+      {"address":"1.1.1.1/32","secondary":false,"unnumbered":false},
+      {"address":"::1/128","secondary":false,"unnumbered":false}
+    ]
+  },
+  "swp1":{
+    "administrativeStatus":"up",
+    "operationalStatus":"down",
+    "linkDetection":true,
+    "linkUps":3,
+    "linkDowns":4,
+    "vrfName":"Red",
+    "mtu":9216,
+    "mtu6":9216,
+    "speed":10000,
+    "ifIndex":3,
+    "type":"Ethernet",
+    "ipAddresses":[
+      {"address":"10.0.0.1/31","secondary":false,"unnumbered":false},
+      {"address":"10.0.1.1/31","secondary":true,"unnumbered":false},
+      {"address":"fe80::1/64","secondary":false,"unnumbered":false}
+    ]
+  },
+  "swp2":{
+    "administrativeStatus":"down",
+    "operationalStatus":"down",
+    "linkUps":0,
+    "linkDowns":0,
+    "vrfName":"default",
+    "mtu":1500,
+    "speed":4294967295,
+    "ifIndex":4,
+    "type":"Ethernet"
+  }
+}`)
+
+	ch := make(chan prometheus.Metric, 1024)
+	if err := processInterface(ch, jsonInterface); err != nil {
+		t.Errorf("error calling processInterface: %s", err)
+	}
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, map[string]float64{
+		"frr_interface_admin_up{iface=lo,vrf=default}":                         1,
+		"frr_interface_admin_up{iface=swp1,vrf=red}":                           1,
+		"frr_interface_admin_up{iface=swp2,vrf=default}":                       0,
+		"frr_interface_oper_up{iface=lo,vrf=default}":                          1,
+		"frr_interface_oper_up{iface=swp1,vrf=red}":                            0,
+		"frr_interface_oper_up{iface=swp2,vrf=default}":                        0,
+		"frr_interface_mtu_bytes{iface=lo,vrf=default}":                        65536,
+		"frr_interface_mtu_bytes{iface=swp1,vrf=red}":                          9216,
+		"frr_interface_mtu_bytes{iface=swp2,vrf=default}":                      1500,
+		"frr_interface_speed_bytes{iface=lo,vrf=default}":                      0,
+		"frr_interface_speed_bytes{iface=swp1,vrf=red}":                        1250000000,
+		"frr_interface_speed_bytes{iface=swp2,vrf=default}":                    0,
+		"frr_interface_link_ups_total{iface=lo,vrf=default}":                   0,
+		"frr_interface_link_ups_total{iface=swp1,vrf=red}":                     3,
+		"frr_interface_link_ups_total{iface=swp2,vrf=default}":                 0,
+		"frr_interface_link_downs_total{iface=lo,vrf=default}":                 0,
+		"frr_interface_link_downs_total{iface=swp1,vrf=red}":                   4,
+		"frr_interface_link_downs_total{iface=swp2,vrf=default}":               0,
+		"frr_interface_addresses_count_total{afi=ipv4,iface=lo,vrf=default}":   1,
+		"frr_interface_addresses_count_total{afi=ipv6,iface=lo,vrf=default}":   1,
+		"frr_interface_addresses_count_total{afi=ipv4,iface=swp1,vrf=red}":     2,
+		"frr_interface_addresses_count_total{afi=ipv6,iface=swp1,vrf=red}":     1,
+		"frr_interface_addresses_count_total{afi=ipv4,iface=swp2,vrf=default}": 0,
+		"frr_interface_addresses_count_total{afi=ipv6,iface=swp2,vrf=default}": 0,
+	})
+}
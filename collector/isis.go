@@ -0,0 +1,329 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	isisSubsystem = "isis"
+
+	isisNeighborLabels = []string{"area", "iface", "neighbor", "level", "neighbor_hostname"}
+	isisLevelLabels    = []string{"area", "level"}
+	isisSPFLabels      = append(isisLevelLabels, "afi", "safi")
+	isisIfaceLabels    = []string{"area", "iface", "level"}
+	isisDesc           = map[string]*prometheus.Desc{
+		"isisNeighState":    colPromDesc(isisSubsystem, "neighbor_state_info", "State of the adjacency, such as Up or Initializing, and SNPA of the neighbor. Value is always 1.", append(isisNeighborLabels, "state", "snpa")),
+		"isisNeighHoldTime": colPromDesc(isisSubsystem, "neighbor_hold_time_remaining_seconds", "Time remaining until the adjacency expires if no hello is received.", isisNeighborLabels),
+		"isisNeighFlaps":    colPromDesc(isisSubsystem, "neighbor_flaps_total", "Number of times the adjacency has changed state.", isisNeighborLabels),
+
+		"isisSPFRuns":         colPromDesc(isisSubsystem, "spf_runs_total", "Number of SPF calculations run.", isisSPFLabels),
+		"isisSPFLastDuration": colPromDesc(isisSubsystem, "spf_last_duration_seconds", "Duration of the last SPF calculation.", isisSPFLabels),
+		"isisSPFScheduled":    colPromDesc(isisSubsystem, "spf_scheduled", "Whether an SPF calculation is scheduled to run (1 = scheduled, 0 = not scheduled).", isisLevelLabels),
+		"isisPDUs":            colPromDesc(isisSubsystem, "pdus_total", "Number of IS-IS PDUs sent and received per PDU type, such as iih or csnp. PDU types that are not specific to a level, such as p2p_iih, have the level L1L2.", append(isisLevelLabels, "type", "direction")),
+
+		"isisIfaceState":        colPromDesc(isisSubsystem, "interface_state_info", "State and circuit type of the interface, such as Up and lan or p2p. Value is always 1.", append(isisIfaceLabels, "state", "type")),
+		"isisIfaceMetric":       colPromDesc(isisSubsystem, "interface_metric", "IS-IS metric of the interface.", isisIfaceLabels),
+		"isisIfaceActiveNeigh":  colPromDesc(isisSubsystem, "interface_active_neighbors", "Number of neighbors with an adjacency in the up state on the interface.", isisIfaceLabels),
+		"isisIfaceHello":        colPromDesc(isisSubsystem, "interface_hello_interval_seconds", "Hello (IIH) interval of the interface.", isisIfaceLabels),
+		"isisIfaceCSNPInterval": colPromDesc(isisSubsystem, "interface_csnp_interval_seconds", "CSNP interval of the interface.", isisIfaceLabels),
+		"isisIfacePSNPInterval": colPromDesc(isisSubsystem, "interface_psnp_interval_seconds", "PSNP interval of the interface.", isisIfaceLabels),
+	}
+	isisErrors      = []error{}
+	totalISISErrors = 0.0
+
+	// isisLevels maps the circuit type of IS-IS to the level label.
+	isisLevels = map[string]string{
+		"1": "L1",
+		"2": "L2",
+		"3": "L1L2",
+	}
+	isisSystemIDRegexp = regexp.MustCompile(`^[0-9a-fA-F]{4}\.[0-9a-fA-F]{4}\.[0-9a-fA-F]{4}$`)
+	isisHostnameRegexp = regexp.MustCompile(`([0-9a-fA-F]{4}\.[0-9a-fA-F]{4}\.[0-9a-fA-F]{4})\s+(\S+)\s*$`)
+)
+
+// ISISCollector collects IS-IS metrics, implemented as per prometheus.Collector interface.
+type ISISCollector struct{}
+
+// NewISISCollector returns a ISISCollector struct.
+func NewISISCollector() *ISISCollector {
+	return &ISISCollector{}
+}
+
+// Name of the collector. Used to populate flag name.
+func (*ISISCollector) Name() string {
+	return isisSubsystem
+}
+
+// Help describes the metrics this collector scrapes. Used to populate flag help.
+func (*ISISCollector) Help() string {
+	return "Collect IS-IS Metrics"
+}
+
+// EnabledByDefault describes whether this collector is enabled by default. Used to populate flag default.
+func (*ISISCollector) EnabledByDefault() bool {
+	return false
+}
+
+// Describe implemented as per the prometheus.Collector interface.
+func (*ISISCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range isisDesc {
+		ch <- desc
+	}
+}
+
+// Collect implemented as per the prometheus.Collector interface.
+func (c *ISISCollector) Collect(ch chan<- prometheus.Metric) {
+	isisErrors = []error{}
+
+	hostnames := map[string]string{}
+	isisHostname, err := execVtyshCommand("-c", "show isis vrf all hostname")
+	if err != nil {
+		isisErrors = append(isisErrors, fmt.Errorf("cannot get isis hostnames: %s", err))
+	} else {
+		hostnames = processISISHostname(isisHostname)
+	}
+
+	jsonISISNeighbor, err := execVtyshCommand("-c", "show isis vrf all neighbor detail json")
+	if err != nil {
+		isisErrors = append(isisErrors, fmt.Errorf("cannot get isis neighbors: %s", err))
+	} else {
+		if err := processISISNeighbor(ch, jsonISISNeighbor, hostnames); err != nil {
+			isisErrors = append(isisErrors, err)
+		}
+	}
+
+	jsonISISInterface, err := execVtyshCommand("-c", "show isis vrf all interface detail json")
+	if err != nil {
+		isisErrors = append(isisErrors, fmt.Errorf("cannot get isis interfaces: %s", err))
+	} else {
+		if err := processISISInterface(ch, jsonISISInterface); err != nil {
+			isisErrors = append(isisErrors, err)
+		}
+	}
+
+	jsonISISSummary, err := execVtyshCommand("-c", "show isis vrf all summary json")
+	if err != nil {
+		isisErrors = append(isisErrors, fmt.Errorf("cannot get isis summary: %s", err))
+	} else {
+		if err := processISISSummary(ch, jsonISISSummary); err != nil {
+			isisErrors = append(isisErrors, err)
+		}
+	}
+
+	totalISISErrors += float64(len(isisErrors))
+}
+
+// CollectErrors returns what errors have been gathered.
+func (*ISISCollector) CollectErrors() []error {
+	return isisErrors
+}
+
+// CollectTotalErrors returns total errors.
+func (*ISISCollector) CollectTotalErrors() float64 {
+	return totalISISErrors
+}
+
+// processISISHostname returns the dynamic hostnames of 'show isis hostname' keyed by system ID. 'show isis hostname'
+// does not support JSON in all FRR versions, so the text output is parsed, such as:
+//
+//	Level  System ID      Dynamic Hostname
+//	2      0000.0000.0002 r2
+//	     * 0000.0000.0001 r1
+func processISISHostname(output []byte) map[string]string {
+	hostnames := map[string]string{}
+	for _, line := range strings.Split(string(output), "\n") {
+		if match := isisHostnameRegexp.FindStringSubmatch(line); match != nil {
+			hostnames[strings.ToLower(match[1])] = match[2]
+		}
+	}
+	return hostnames
+}
+
+func processISISNeighbor(ch chan<- prometheus.Metric, jsonISISNeighbor []byte, hostnames map[string]string) error {
+	var isisNeighbors struct {
+		Areas []struct {
+			Area     string
+			Circuits []isisAdjacency
+		}
+	}
+	if err := json.Unmarshal(jsonISISNeighbor, &isisNeighbors); err != nil {
+		return fmt.Errorf("cannot unmarshal isis neighbor json: %s", err)
+	}
+
+	for _, area := range isisNeighbors.Areas {
+		for _, adj := range area.Circuits {
+			// Circuits without an adjacency only include the circuit ID.
+			if adj.Adj == "" {
+				continue
+			}
+			// FRR reports adj as the dynamic hostname of the neighbor when it is known, otherwise as its system ID.
+			hostname, exist := hostnames[strings.ToLower(adj.Adj)]
+			if !exist && !isisSystemIDRegexp.MatchString(adj.Adj) {
+				hostname = adj.Adj
+			}
+			// The labels are "area", "iface", "neighbor", "level", "neighbor_hostname"
+			labels := []string{area.Area, adj.Interface, adj.Adj, isisLevel(adj.Level), hostname}
+			newGauge(ch, isisDesc["isisNeighState"], 1, append(labels, adj.State, adj.Snpa)...)
+			if holdTime, err := parseDuration(adj.ExpiresIn); err == nil {
+				newGauge(ch, isisDesc["isisNeighHoldTime"], holdTime, labels...)
+			}
+			newCounter(ch, isisDesc["isisNeighFlaps"], adj.AdjFlaps, labels...)
+		}
+	}
+	return nil
+}
+
+func processISISInterface(ch chan<- prometheus.Metric, jsonISISInterface []byte) error {
+	var isisInterfaces struct {
+		Areas []struct {
+			Area     string
+			Circuits []struct {
+				Interface *isisCircuit
+			}
+		}
+	}
+	if err := json.Unmarshal(jsonISISInterface, &isisInterfaces); err != nil {
+		return fmt.Errorf("cannot unmarshal isis interface json: %s", err)
+	}
+
+	for _, area := range isisInterfaces.Areas {
+		for _, circuit := range area.Circuits {
+			iface := circuit.Interface
+			if iface == nil {
+				continue
+			}
+			// The labels are "area", "iface", "level", "state", "type"
+			newGauge(ch, isisDesc["isisIfaceState"], 1, area.Area, iface.Name, iface.Level, iface.State, iface.Type)
+			for _, level := range iface.Levels {
+				// The labels are "area", "iface", "level"
+				labels := []string{area.Area, iface.Name, level.Level}
+				newGauge(ch, isisDesc["isisIfaceMetric"], level.Metric, labels...)
+				// The timers and active neighbors are not included for passive interfaces.
+				if level.HelloInterval == nil {
+					continue
+				}
+				newGauge(ch, isisDesc["isisIfaceActiveNeigh"], level.ActiveNeighbors, labels...)
+				newGauge(ch, isisDesc["isisIfaceHello"], *level.HelloInterval, labels...)
+				newGauge(ch, isisDesc["isisIfaceCSNPInterval"], level.CnspInterval, labels...)
+				newGauge(ch, isisDesc["isisIfacePSNPInterval"], level.PsnpInterval, labels...)
+			}
+		}
+	}
+	return nil
+}
+
+func processISISSummary(ch chan<- prometheus.Metric, jsonISISSummary []byte) error {
+	var isisSummary struct {
+		Areas []struct {
+			Area      string
+			TxPduType map[string]float64 `json:"tx-pdu-type"`
+			RxPduType map[string]float64 `json:"rx-pdu-type"`
+			Levels    []map[string]json.RawMessage
+		}
+	}
+	if err := json.Unmarshal(jsonISISSummary, &isisSummary); err != nil {
+		return fmt.Errorf("cannot unmarshal isis summary json: %s", err)
+	}
+
+	for _, area := range isisSummary.Areas {
+		for direction, pdus := range map[string]map[string]float64{"out": area.TxPduType, "in": area.RxPduType} {
+			for pduType, count := range pdus {
+				level, pduType := isisPDUType(pduType)
+				// The labels are "area", "level", "type", "direction"
+				newCounter(ch, isisDesc["isisPDUs"], count, area.Area, level, pduType, direction)
+			}
+		}
+
+		for _, level := range area.Levels {
+			var levelID json.Number
+			if err := json.Unmarshal(level["id"], &levelID); err != nil {
+				return fmt.Errorf("cannot unmarshal isis summary level id json: %s", err)
+			}
+			// The labels are "area", "level"
+			labels := []string{area.Area, isisLevel(levelID)}
+			var spf string
+			if jsonSPF, exist := level["spf"]; exist {
+				if err := json.Unmarshal(jsonSPF, &spf); err != nil {
+					return fmt.Errorf("cannot unmarshal isis summary spf json: %s", err)
+				}
+			}
+			spfScheduled := 0.0
+			if spf == "pending" {
+				spfScheduled = 1
+			}
+			newGauge(ch, isisDesc["isisSPFScheduled"], spfScheduled, labels...)
+
+			// The SPF statistics are keyed by the address family of the SPF calculation, such as ipv4-unicast.
+			for key, value := range level {
+				afi := strings.SplitN(key, "-", 2)
+				if len(afi) != 2 || !strings.HasPrefix(afi[0], "ipv") {
+					continue
+				}
+				var spfStats isisSPFStats
+				if err := json.Unmarshal(value, &spfStats); err != nil {
+					return fmt.Errorf("cannot unmarshal isis summary %s spf json: %s", key, err)
+				}
+				// The labels are "area", "level", "afi", "safi"
+				spfLabels := append(labels, afi[0], afi[1])
+				newCounter(ch, isisDesc["isisSPFRuns"], spfStats.LastRunCount, spfLabels...)
+				newGauge(ch, isisDesc["isisSPFLastDuration"], spfStats.LastRunDurationUsec*0.000001, spfLabels...)
+			}
+		}
+	}
+	return nil
+}
+
+// isisLevel returns the level label of an IS-IS circuit type, such as L1L2 for 3.
+func isisLevel(level json.Number) string {
+	if label, exist := isisLevels[level.String()]; exist {
+		return label
+	}
+	return level.String()
+}
+
+// isisPDUType returns the level and type labels of an IS-IS PDU counter, such as L1 and iih for "L1 IIH".
+func isisPDUType(pduType string) (string, string) {
+	pduType = strings.NewReplacer(" ", "_", "-", "_").Replace(strings.ToLower(pduType))
+	for _, level := range []string{"l1", "l2"} {
+		if strings.HasPrefix(pduType, level+"_") {
+			return strings.ToUpper(level), strings.TrimPrefix(pduType, level+"_")
+		}
+	}
+	return "L1L2", pduType
+}
+
+type isisAdjacency struct {
+	Adj       string
+	Interface string
+	Level     json.Number
+	State     string
+	ExpiresIn string `json:"expires-in"`
+	Snpa      string
+	AdjFlaps  float64 `json:"adj-flaps"`
+}
+
+type isisSPFStats struct {
+	LastRunDurationUsec float64 `json:"last-run-duration-usec"`
+	LastRunCount        float64 `json:"last-run-count"`
+}
+
+type isisCircuit struct {
+	Name   string
+	State  string
+	Type   string
+	Level  string
+	Levels []struct {
+		Level           string
+		Metric          float64
+		ActiveNeighbors float64  `json:"active-neighbors"`
+		HelloInterval   *float64 `json:"hello-interval"`
+		// The typo is in the FRR JSON key.
+		CnspInterval float64 `json:"cnsp-interval"`
+		PsnpInterval float64 `json:"psnp-interval"`
+	}
+}
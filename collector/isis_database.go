@@ -0,0 +1,136 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	isisDatabaseMetricPrefix = "isis_database"
+
+	isisDatabaseLabels = []string{"area", "level"}
+	isisDatabaseDesc   = map[string]*prometheus.Desc{
+		"lsps":           colPromDesc(isisDatabaseMetricPrefix, "lsps_count_total", "Number of LSPs in the IS-IS database.", isisDatabaseLabels),
+		"overloadedLSPs": colPromDesc(isisDatabaseMetricPrefix, "overloaded_lsps_count_total", "Number of LSPs in the IS-IS database with the overload bit set.", isisDatabaseLabels),
+		"localOverload":  colPromDesc(isisDatabaseMetricPrefix, "local_overload", "Whether the router has set the overload bit in its LSP, either as configured or during set-overload-bit on-startup (1 = overloaded, 0 = not overloaded).", isisDatabaseLabels),
+		"localSeqNumber": colPromDesc(isisDatabaseMetricPrefix, "local_lsp_sequence_number", "Sequence number of the LSPs originated by the router, which increases each time the LSP is regenerated.", append(isisDatabaseLabels, "lsp_id")),
+	}
+	isisDatabaseErrors      = []error{}
+	totalISISDatabaseErrors = 0.0
+)
+
+// ISISDatabaseCollector collects IS-IS LSP database metrics, implemented as per prometheus.Collector interface.
+type ISISDatabaseCollector struct{}
+
+// NewISISDatabaseCollector returns a ISISDatabaseCollector struct.
+func NewISISDatabaseCollector() *ISISDatabaseCollector {
+	return &ISISDatabaseCollector{}
+}
+
+// Name of the collector. Used to populate flag name.
+func (*ISISDatabaseCollector) Name() string {
+	return isisSubsystem + "database"
+}
+
+// Help describes the metrics this collector scrapes. Used to populate flag help.
+func (*ISISDatabaseCollector) Help() string {
+	return "Collect IS-IS LSP Database Metrics"
+}
+
+// EnabledByDefault describes whether this collector is enabled by default. Used to populate flag default.
+func (*ISISDatabaseCollector) EnabledByDefault() bool {
+	return false
+}
+
+// Describe implemented as per the prometheus.Collector interface.
+func (*ISISDatabaseCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range isisDatabaseDesc {
+		ch <- desc
+	}
+}
+
+// Collect implemented as per the prometheus.Collector interface.
+func (c *ISISDatabaseCollector) Collect(ch chan<- prometheus.Metric) {
+	isisDatabaseErrors = []error{}
+
+	jsonISISDatabase, err := execVtyshCommand("-c", "show isis vrf all database json")
+	if err != nil {
+		isisDatabaseErrors = append(isisDatabaseErrors, fmt.Errorf("cannot get isis database: %s", err))
+	} else {
+		if err := processISISDatabase(ch, jsonISISDatabase); err != nil {
+			isisDatabaseErrors = append(isisDatabaseErrors, err)
+		}
+	}
+
+	totalISISDatabaseErrors += float64(len(isisDatabaseErrors))
+}
+
+// CollectErrors returns what errors have been gathered.
+func (*ISISDatabaseCollector) CollectErrors() []error {
+	return isisDatabaseErrors
+}
+
+// CollectTotalErrors returns total errors.
+func (*ISISDatabaseCollector) CollectTotalErrors() float64 {
+	return totalISISDatabaseErrors
+}
+
+func processISISDatabase(ch chan<- prometheus.Metric, jsonISISDatabase []byte) error {
+	var isisDatabase struct {
+		Areas []struct {
+			Area   string
+			Levels []struct {
+				ID   json.Number
+				Lsps []isisLSP
+			}
+		}
+	}
+	if err := json.Unmarshal(jsonISISDatabase, &isisDatabase); err != nil {
+		return fmt.Errorf("cannot unmarshal isis database json: %s", err)
+	}
+
+	for _, area := range isisDatabase.Areas {
+		for _, level := range area.Levels {
+			// The labels are "area", "level"
+			labels := []string{area.Area, isisLevel(level.ID)}
+			overloadedLSPs, localOverload := 0.0, 0.0
+			for _, lsp := range level.Lsps {
+				// att-p-ol is the attached, partition repair and overload bits of the LSP, such as 0/0/1.
+				overloaded := strings.HasSuffix(lsp.AttPOl, "/1")
+				if overloaded {
+					overloadedLSPs++
+				}
+				if strings.TrimSpace(lsp.Lsp.Own) != "*" {
+					continue
+				}
+				// The overload bit is only significant in fragment zero of the LSP with pseudonode ID zero.
+				if overloaded && strings.HasSuffix(lsp.Lsp.ID, ".00-00") {
+					localOverload = 1
+				}
+				seqNumber, err := strconv.ParseUint(strings.TrimPrefix(lsp.SeqNumber, "0x"), 16, 32)
+				if err != nil {
+					return fmt.Errorf("cannot parse sequence number of isis lsp %s: %s", lsp.Lsp.ID, err)
+				}
+				// The labels are "area", "level", "lsp_id"
+				newGauge(ch, isisDatabaseDesc["localSeqNumber"], float64(seqNumber), append(labels, lsp.Lsp.ID)...)
+			}
+			newGauge(ch, isisDatabaseDesc["lsps"], float64(len(level.Lsps)), labels...)
+			newGauge(ch, isisDatabaseDesc["overloadedLSPs"], overloadedLSPs, labels...)
+			newGauge(ch, isisDatabaseDesc["localOverload"], localOverload, labels...)
+		}
+	}
+	return nil
+}
+
+type isisLSP struct {
+	Lsp struct {
+		ID  string
+		Own string
+	}
+	SeqNumber string `json:"seq-number"`
+	AttPOl    string `json:"att-p-ol"`
+}
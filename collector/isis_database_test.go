@@ -0,0 +1,55 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var isisDatabaseJSON = []byte(`{
+  "areas":[
+    {
+      "area":"1",
+      "levels":[
+        {
+          "id":1,
+          "lsps":[
+            {"lsp":{"id":"r1.00-00","own":"*"},"pdu-len":120,"seq-number":"0x0000000a","chksum":"0x1b2c","holdtime":1050,"att-p-ol":"0/0/0"},
+            {"lsp":{"id":"r2.00-00","own":" "},"pdu-len":120,"seq-number":"0x00000004","chksum":"0x3d4e","holdtime":980,"att-p-ol":"0/0/1"}
+          ],
+          "count":2
+        },
+        {
+          "id":2,
+          "lsps":[
+            {"lsp":{"id":"r1.00-00","own":"*"},"pdu-len":134,"seq-number":"0x0000000c","chksum":"0x5f60","holdtime":1050,"att-p-ol":"0/0/1"},
+            {"lsp":{"id":"r1.01-00","own":"*"},"pdu-len":51,"seq-number":"0x00000002","chksum":"0x7182","holdtime":1050,"att-p-ol":"0/0/0"},
+            {"lsp":{"id":"r3.00-00","own":" "},"pdu-len":134,"seq-number":"0x00000007","chksum":"0x93a4","holdtime":"(12)","att-p-ol":"0/0/0"}
+          ],
+          "count":3
+        }
+      ]
+    }
+  ]
+}`)
+
+func TestProcessISISDatabase(t *testing.T) {
+	ch := make(chan prometheus.Metric, 1024)
+	if err := processISISDatabase(ch, isisDatabaseJSON); err != nil {
+		t.Errorf("error calling processISISDatabase: %s", err)
+	}
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, map[string]float64{
+		"frr_isis_database_lsps_count_total{area=1,level=L1}":                          2,
+		"frr_isis_database_lsps_count_total{area=1,level=L2}":                          3,
+		"frr_isis_database_overloaded_lsps_count_total{area=1,level=L1}":               1,
+		"frr_isis_database_overloaded_lsps_count_total{area=1,level=L2}":               1,
+		"frr_isis_database_local_overload{area=1,level=L1}":                            0,
+		"frr_isis_database_local_overload{area=1,level=L2}":                            1,
+		"frr_isis_database_local_lsp_sequence_number{area=1,level=L1,lsp_id=r1.00-00}": 10,
+		"frr_isis_database_local_lsp_sequence_number{area=1,level=L2,lsp_id=r1.00-00}": 12,
+		"frr_isis_database_local_lsp_sequence_number{area=1,level=L2,lsp_id=r1.01-00}": 2,
+	})
+}
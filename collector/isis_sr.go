@@ -0,0 +1,180 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	isisSRMetricPrefix = "isis_sr"
+
+	isisSRNodeLabels = []string{"area", "level", "system_id"}
+	isisSRDesc       = map[string]*prometheus.Desc{
+		"capable":   colPromDesc(isisSRMetricPrefix, "capable", "Whether the node advertises the SR-Capabilities sub-TLV with an SRGB (1 = SR capable, 0 = Not SR capable).", isisSRNodeLabels),
+		"srgbStart": colPromDesc(isisSRMetricPrefix, "srgb_start_label", "First label of the segment routing global block (SRGB) advertised by the node.", isisSRNodeLabels),
+		"srgbSize":  colPromDesc(isisSRMetricPrefix, "srgb_size", "Number of labels in the segment routing global block (SRGB) advertised by the node.", isisSRNodeLabels),
+		"srlbStart": colPromDesc(isisSRMetricPrefix, "srlb_start_label", "First label of the segment routing local block (SRLB) advertised by the node.", isisSRNodeLabels),
+		"srlbSize":  colPromDesc(isisSRMetricPrefix, "srlb_size", "Number of labels in the segment routing local block (SRLB) advertised by the node.", isisSRNodeLabels),
+		"msd":       colPromDesc(isisSRMetricPrefix, "msd", "Maximum SID depth (MSD) advertised by the node.", isisSRNodeLabels),
+		"algorithm": colPromDesc(isisSRMetricPrefix, "algorithm_info", "Algorithms the node participates in, such as 0 for SPF or 128 to 255 for flex-algo. Value is always 1.", append(isisSRNodeLabels, "algorithm")),
+
+		"prefixSIDs": colPromDesc(isisSRMetricPrefix, "prefix_sids_count_total", "Number of prefix SIDs per algorithm, such as 0 for SPF or 128 to 255 for flex-algo.", []string{"area", "level", "algorithm"}),
+	}
+	isisSRErrors      = []error{}
+	totalISISSRErrors = 0.0
+)
+
+// ISISSRCollector collects IS-IS segment routing metrics, implemented as per prometheus.Collector interface.
+type ISISSRCollector struct{}
+
+// NewISISSRCollector returns a ISISSRCollector struct.
+func NewISISSRCollector() *ISISSRCollector {
+	return &ISISSRCollector{}
+}
+
+// Name of the collector. Used to populate flag name.
+func (*ISISSRCollector) Name() string {
+	return isisSubsystem + "sr"
+}
+
+// Help describes the metrics this collector scrapes. Used to populate flag help.
+func (*ISISSRCollector) Help() string {
+	return "Collect IS-IS Segment Routing Metrics"
+}
+
+// EnabledByDefault describes whether this collector is enabled by default. Used to populate flag default.
+func (*ISISSRCollector) EnabledByDefault() bool {
+	return false
+}
+
+// Describe implemented as per the prometheus.Collector interface.
+func (*ISISSRCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range isisSRDesc {
+		ch <- desc
+	}
+}
+
+// Collect implemented as per the prometheus.Collector interface.
+func (c *ISISSRCollector) Collect(ch chan<- prometheus.Metric) {
+	isisSRErrors = []error{}
+
+	jsonISISSRNode, err := execVtyshCommand("-c", "show isis segment-routing node json")
+	if err != nil {
+		isisSRErrors = append(isisSRErrors, fmt.Errorf("cannot get isis segment routing nodes: %s", err))
+	} else {
+		if err := processISISSRNode(ch, jsonISISSRNode); err != nil {
+			isisSRErrors = append(isisSRErrors, err)
+		}
+	}
+
+	jsonISISSRPrefixSIDs, err := execVtyshCommand("-c", "show isis segment-routing prefix-sids json")
+	if err != nil {
+		isisSRErrors = append(isisSRErrors, fmt.Errorf("cannot get isis segment routing prefix sids: %s", err))
+	} else {
+		if err := processISISSRPrefixSIDs(ch, jsonISISSRPrefixSIDs); err != nil {
+			isisSRErrors = append(isisSRErrors, err)
+		}
+	}
+
+	totalISISSRErrors += float64(len(isisSRErrors))
+}
+
+// CollectErrors returns what errors have been gathered.
+func (*ISISSRCollector) CollectErrors() []error {
+	return isisSRErrors
+}
+
+// CollectTotalErrors returns total errors.
+func (*ISISSRCollector) CollectTotalErrors() float64 {
+	return totalISISSRErrors
+}
+
+func processISISSRNode(ch chan<- prometheus.Metric, jsonISISSRNode []byte) error {
+	var isisSRNodes struct {
+		Areas []struct {
+			Area   string
+			Levels []struct {
+				Level json.Number
+				Nodes []isisSRNode
+			}
+		}
+	}
+	if err := json.Unmarshal(jsonISISSRNode, &isisSRNodes); err != nil {
+		return fmt.Errorf("cannot unmarshal isis segment routing node json: %s", err)
+	}
+
+	for _, area := range isisSRNodes.Areas {
+		for _, level := range area.Levels {
+			for _, node := range level.Nodes {
+				// The labels are "area", "level", "system_id"
+				labels := []string{area.Area, isisLevel(level.Level), node.SystemID}
+				// The SRGB is advertised in the SR-Capabilities sub-TLV, which is only included by SR capable nodes.
+				capable := 0.0
+				if node.Srgb.Size > 0 {
+					capable = 1
+				}
+				newGauge(ch, isisSRDesc["capable"], capable, labels...)
+				newGauge(ch, isisSRDesc["srgbStart"], node.Srgb.Start, labels...)
+				newGauge(ch, isisSRDesc["srgbSize"], node.Srgb.Size, labels...)
+				newGauge(ch, isisSRDesc["srlbStart"], node.Srlb.Start, labels...)
+				newGauge(ch, isisSRDesc["srlbSize"], node.Srlb.Size, labels...)
+				newGauge(ch, isisSRDesc["msd"], node.Msd, labels...)
+				for _, algorithm := range node.Algorithms {
+					// The labels are "area", "level", "system_id", "algorithm"
+					newGauge(ch, isisSRDesc["algorithm"], 1, append(labels, strconv.Itoa(algorithm))...)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func processISISSRPrefixSIDs(ch chan<- prometheus.Metric, jsonISISSRPrefixSIDs []byte) error {
+	var isisSRPrefixSIDs struct {
+		Areas []struct {
+			Area   string
+			Levels []struct {
+				Level      json.Number
+				PrefixSIDs []struct {
+					Prefix string
+					// The algorithm is 0 (SPF) unless the prefix SID is advertised for a flex-algo.
+					Algorithm int
+				} `json:"prefix-sids"`
+			}
+		}
+	}
+	if err := json.Unmarshal(jsonISISSRPrefixSIDs, &isisSRPrefixSIDs); err != nil {
+		return fmt.Errorf("cannot unmarshal isis segment routing prefix sids json: %s", err)
+	}
+
+	for _, area := range isisSRPrefixSIDs.Areas {
+		for _, level := range area.Levels {
+			prefixSIDs := map[int]float64{}
+			for _, prefixSID := range level.PrefixSIDs {
+				prefixSIDs[prefixSID.Algorithm]++
+			}
+			for algorithm, count := range prefixSIDs {
+				// The labels are "area", "level", "algorithm"
+				newGauge(ch, isisSRDesc["prefixSIDs"], count, area.Area, isisLevel(level.Level), strconv.Itoa(algorithm))
+			}
+		}
+	}
+	return nil
+}
+
+type isisSRNode struct {
+	SystemID string `json:"system-id"`
+	Srgb     isisSRLabelBlock
+	Srlb     isisSRLabelBlock
+	Msd      float64
+	// Algorithms includes the flex-algos the node participates in.
+	Algorithms []int
+}
+
+type isisSRLabelBlock struct {
+	Start float64
+	Size  float64
+}
@@ -0,0 +1,98 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var isisSRNodeJSON = []byte(`{
+  "areas":[
+    {
+      "area":"1",
+      "levels":[
+        {
+          "level":2,
+          "nodes":[
+            {"system-id":"0000.0000.0001","srgb":{"start":16000,"size":8000},"srlb":{"start":15000,"size":1000},"algorithms":[0,128],"msd":16},
+            {"system-id":"0000.0000.0002","srgb":{"start":20000,"size":4000},"srlb":{"start":15000,"size":1000},"algorithms":[0],"msd":8},
+            {"system-id":"0000.0000.0003","srgb":{"start":0,"size":0},"srlb":{"start":0,"size":0},"algorithms":[],"msd":0}
+          ]
+        }
+      ]
+    }
+  ]
+}`)
+
+func TestProcessISISSRNode(t *testing.T) {
+	ch := make(chan prometheus.Metric, 1024)
+	if err := processISISSRNode(ch, isisSRNodeJSON); err != nil {
+		t.Errorf("error calling processISISSRNode: %s", err)
+	}
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, map[string]float64{
+		"frr_isis_sr_srgb_start_label{area=1,level=L2,system_id=0000.0000.0001}":             16000,
+		"frr_isis_sr_srgb_size{area=1,level=L2,system_id=0000.0000.0001}":                    8000,
+		"frr_isis_sr_srlb_start_label{area=1,level=L2,system_id=0000.0000.0001}":             15000,
+		"frr_isis_sr_srlb_size{area=1,level=L2,system_id=0000.0000.0001}":                    1000,
+		"frr_isis_sr_msd{area=1,level=L2,system_id=0000.0000.0001}":                          16,
+		"frr_isis_sr_algorithm_info{algorithm=0,area=1,level=L2,system_id=0000.0000.0001}":   1,
+		"frr_isis_sr_algorithm_info{algorithm=128,area=1,level=L2,system_id=0000.0000.0001}": 1,
+		"frr_isis_sr_srgb_start_label{area=1,level=L2,system_id=0000.0000.0002}":             20000,
+		"frr_isis_sr_srgb_size{area=1,level=L2,system_id=0000.0000.0002}":                    4000,
+		"frr_isis_sr_srlb_start_label{area=1,level=L2,system_id=0000.0000.0002}":             15000,
+		"frr_isis_sr_srlb_size{area=1,level=L2,system_id=0000.0000.0002}":                    1000,
+		"frr_isis_sr_msd{area=1,level=L2,system_id=0000.0000.0002}":                          8,
+		"frr_isis_sr_algorithm_info{algorithm=0,area=1,level=L2,system_id=0000.0000.0002}":   1,
+		"frr_isis_sr_capable{area=1,level=L2,system_id=0000.0000.0001}":                      1,
+		"frr_isis_sr_capable{area=1,level=L2,system_id=0000.0000.0002}":                      1,
+		"frr_isis_sr_capable{area=1,level=L2,system_id=0000.0000.0003}":                      0,
+		"frr_isis_sr_srgb_start_label{area=1,level=L2,system_id=0000.0000.0003}":             0,
+		"frr_isis_sr_srgb_size{area=1,level=L2,system_id=0000.0000.0003}":                    0,
+		"frr_isis_sr_srlb_start_label{area=1,level=L2,system_id=0000.0000.0003}":             0,
+		"frr_isis_sr_srlb_size{area=1,level=L2,system_id=0000.0000.0003}":                    0,
+		"frr_isis_sr_msd{area=1,level=L2,system_id=0000.0000.0003}":                          0,
+	})
+}
+
+func TestProcessISISSRPrefixSIDs(t *testing.T) {
+	isisSRPrefixSIDs := []byte(`{
+  "areas":[
+    {
+      "area":"1",
+      "levels":[
+        {
+          "level":1,
+          "prefix-sids":[
+            {"prefix":"10.0.0.1/32","sid":1,"type":"Index","algorithm":0},
+            {"prefix":"10.0.0.2/32","sid":2,"type":"Index","algorithm":0}
+          ]
+        },
+        {
+          "level":2,
+          "prefix-sids":[
+            {"prefix":"10.0.0.1/32","sid":1,"type":"Index"},
+            {"prefix":"10.0.0.2/32","sid":2,"type":"Index"},
+            {"prefix":"10.0.0.1/32","sid":101,"type":"Index","algorithm":128}
+          ]
+        }
+      ]
+    }
+  ]
+}`)
+
+	ch := make(chan prometheus.Metric, 1024)
+	if err := processISISSRPrefixSIDs(ch, isisSRPrefixSIDs); err != nil {
+		t.Errorf("error calling processISISSRPrefixSIDs: %s", err)
+	}
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, map[string]float64{
+		"frr_isis_sr_prefix_sids_count_total{algorithm=0,area=1,level=L1}":   2,
+		"frr_isis_sr_prefix_sids_count_total{algorithm=0,area=1,level=L2}":   2,
+		"frr_isis_sr_prefix_sids_count_total{algorithm=128,area=1,level=L2}": 1,
+	})
+}
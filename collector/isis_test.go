@@ -0,0 +1,240 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var isisNeighborDetail = []byte(`{
+  "areas":[
+    {
+      "area":"1",
+      "circuits":[
+        {
+          "circuit":0,
+          "adj":"r2",
+          "interface":"swp1",
+          "level":3,
+          "state":"Up",
+          "expires-in":"28s",
+          "adj-flaps":2,
+          "last-ago":"1d2h3m",
+          "circuit-type":"L1L2",
+          "speaks":"IPv4",
+          "snpa":"2c2c.2c2c.2c2c",
+          "lan-id":"r1.01",
+          "lan-prio":64,
+          "dis-flaps":1,
+          "area-address":"49.0001",
+          "ipv4-address":"192.168.1.2"
+        },
+        {
+          "circuit":1,
+          "adj":"0000.0000.0003",
+          "interface":"swp2",
+          "level":2,
+          "state":"Initializing",
+          "expires-in":"1m5s",
+          "adj-flaps":0,
+          "circuit-type":"L2",
+          "snpa":"p2p"
+        },
+        {
+          "circuit":2
+        }
+      ]
+    }
+  ]
+}`)
+
+func TestProcessISISNeighbor(t *testing.T) {
+	ch := make(chan prometheus.Metric, 1024)
+	if err := processISISNeighbor(ch, isisNeighborDetail, map[string]string{"0000.0000.0003": "r3"}); err != nil {
+		t.Errorf("error calling processISISNeighbor: %s", err)
+	}
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, map[string]float64{
+		"frr_isis_neighbor_state_info{area=1,iface=swp1,level=L1L2,neighbor=r2,neighbor_hostname=r2,snpa=2c2c.2c2c.2c2c,state=Up}":          1,
+		"frr_isis_neighbor_state_info{area=1,iface=swp2,level=L2,neighbor=0000.0000.0003,neighbor_hostname=r3,snpa=p2p,state=Initializing}": 1,
+		"frr_isis_neighbor_hold_time_remaining_seconds{area=1,iface=swp1,level=L1L2,neighbor=r2,neighbor_hostname=r2}":                      28,
+		"frr_isis_neighbor_hold_time_remaining_seconds{area=1,iface=swp2,level=L2,neighbor=0000.0000.0003,neighbor_hostname=r3}":            65,
+		"frr_isis_neighbor_flaps_total{area=1,iface=swp1,level=L1L2,neighbor=r2,neighbor_hostname=r2}":                                      2,
+		"frr_isis_neighbor_flaps_total{area=1,iface=swp2,level=L2,neighbor=0000.0000.0003,neighbor_hostname=r3}":                            0,
+	})
+}
+
+func TestProcessISISHostname(t *testing.T) {
+	isisHostname := []byte(`vrf     : default
+Level  System ID      Dynamic Hostname
+2      0000.0000.0002 r2
+2      0000.0000.000A r10
+     * 0000.0000.0001 r1
+`)
+
+	got := processISISHostname(isisHostname)
+	want := map[string]string{"0000.0000.0001": "r1", "0000.0000.0002": "r2", "0000.0000.000a": "r10"}
+	if len(got) != len(want) {
+		t.Errorf("got %d hostnames, want %d: %v", len(got), len(want), got)
+	}
+	for systemID, hostname := range want {
+		if got[systemID] != hostname {
+			t.Errorf("hostname of %s: got %q, want %q", systemID, got[systemID], hostname)
+		}
+	}
+}
+
+func TestProcessISISSummary(t *testing.T) {
+	isisSummary := []byte(`{
+  "vrf":"default",
+  "process-id":1852,
+  "system-id":"0000.0000.0001",
+  "up-time":"01:02:03",
+  "number-areas":1,
+  "areas":[
+    {
+      "area":"1",
+      "tx-pdu-type":{
+        "L2 IIH":1200,
+        "P2P IIH":600,
+        "L2 LSP":40,
+        "L2 CSNP":120,
+        "L2 PSNP":15,
+        "LSP RXMT":3
+      },
+      "rx-pdu-type":{
+        "L2 IIH":1199,
+        "P2P IIH":598,
+        "L2 LSP":52,
+        "L2 CSNP":118,
+        "L2 PSNP":20
+      },
+      "levels":[
+        {
+          "id":1,
+          "lsp0-regenerated":4,
+          "lsp-purged":0,
+          "spf":"no pending",
+          "minimum-interval":1,
+          "ipv4-unicast":{
+            "last-run-elapsed":"00:10:00",
+            "last-run-duration-usec":250,
+            "last-run-count":12
+          },
+          "ipv6-unicast":{
+            "last-run-elapsed":"00:10:00",
+            "last-run-duration-usec":125,
+            "last-run-count":11
+          }
+        },
+        {
+          "id":2,
+          "lsp0-regenerated":5,
+          "lsp-purged":1,
+          "spf":"pending",
+          "minimum-interval":1,
+          "ipv4-unicast":{
+            "last-run-elapsed":"00:00:01",
+            "last-run-duration-usec":1500,
+            "last-run-count":20
+          }
+        }
+      ]
+    }
+  ]
+}`)
+
+	ch := make(chan prometheus.Metric, 1024)
+	if err := processISISSummary(ch, isisSummary); err != nil {
+		t.Errorf("error calling processISISSummary: %s", err)
+	}
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, map[string]float64{
+		"frr_isis_pdus_total{area=1,direction=out,level=L2,type=iih}":               1200,
+		"frr_isis_pdus_total{area=1,direction=out,level=L1L2,type=p2p_iih}":         600,
+		"frr_isis_pdus_total{area=1,direction=out,level=L2,type=lsp}":               40,
+		"frr_isis_pdus_total{area=1,direction=out,level=L2,type=csnp}":              120,
+		"frr_isis_pdus_total{area=1,direction=out,level=L2,type=psnp}":              15,
+		"frr_isis_pdus_total{area=1,direction=out,level=L1L2,type=lsp_rxmt}":        3,
+		"frr_isis_pdus_total{area=1,direction=in,level=L2,type=iih}":                1199,
+		"frr_isis_pdus_total{area=1,direction=in,level=L1L2,type=p2p_iih}":          598,
+		"frr_isis_pdus_total{area=1,direction=in,level=L2,type=lsp}":                52,
+		"frr_isis_pdus_total{area=1,direction=in,level=L2,type=csnp}":               118,
+		"frr_isis_pdus_total{area=1,direction=in,level=L2,type=psnp}":               20,
+		"frr_isis_spf_scheduled{area=1,level=L1}":                                   0,
+		"frr_isis_spf_scheduled{area=1,level=L2}":                                   1,
+		"frr_isis_spf_runs_total{afi=ipv4,area=1,level=L1,safi=unicast}":            12,
+		"frr_isis_spf_runs_total{afi=ipv6,area=1,level=L1,safi=unicast}":            11,
+		"frr_isis_spf_runs_total{afi=ipv4,area=1,level=L2,safi=unicast}":            20,
+		"frr_isis_spf_last_duration_seconds{afi=ipv4,area=1,level=L1,safi=unicast}": 0.00025,
+		"frr_isis_spf_last_duration_seconds{afi=ipv6,area=1,level=L1,safi=unicast}": 0.000125,
+		"frr_isis_spf_last_duration_seconds{afi=ipv4,area=1,level=L2,safi=unicast}": 0.0015,
+	})
+}
+
+func TestProcessISISInterface(t *testing.T) {
+	isisInterfaceDetail := []byte(`{
+  "areas":[
+    {
+      "area":"1",
+      "circuits":[
+        {
+          "circuit":0,
+          "interface":{
+            "name":"swp1",
+            "circuit-id":"0x1",
+            "state":"Up",
+            "type":"lan",
+            "level":"L1L2",
+            "snpa":"2c2c.2c2c.2c2c",
+            "levels":[
+              {"level":"L1","metric":10,"active-neighbors":1,"hello-interval":3,"holddown":{"count":10,"pad":"yes"},"cnsp-interval":10,"psnp-interval":2,"lan":{"priority":64,"is-dis":"yes"}},
+              {"level":"L2","metric":20,"active-neighbors":0,"hello-interval":3,"holddown":{"count":10,"pad":"yes"},"cnsp-interval":10,"psnp-interval":2,"lan":{"priority":64,"is-dis":"no"}}
+            ]
+          }
+        },
+        {
+          "circuit":1,
+          "interface":{
+            "name":"lo",
+            "circuit-id":"0x0",
+            "state":"Up",
+            "type":"loopback",
+            "level":"L2",
+            "levels":[
+              {"level":"L2","metric":0}
+            ]
+          }
+        }
+      ]
+    }
+  ]
+}`)
+
+	ch := make(chan prometheus.Metric, 1024)
+	if err := processISISInterface(ch, isisInterfaceDetail); err != nil {
+		t.Errorf("error calling processISISInterface: %s", err)
+	}
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, map[string]float64{
+		"frr_isis_interface_state_info{area=1,iface=swp1,level=L1L2,state=Up,type=lan}":  1,
+		"frr_isis_interface_state_info{area=1,iface=lo,level=L2,state=Up,type=loopback}": 1,
+		"frr_isis_interface_metric{area=1,iface=swp1,level=L1}":                          10,
+		"frr_isis_interface_metric{area=1,iface=swp1,level=L2}":                          20,
+		"frr_isis_interface_metric{area=1,iface=lo,level=L2}":                            0,
+		"frr_isis_interface_active_neighbors{area=1,iface=swp1,level=L1}":                1,
+		"frr_isis_interface_active_neighbors{area=1,iface=swp1,level=L2}":                0,
+		"frr_isis_interface_hello_interval_seconds{area=1,iface=swp1,level=L1}":          3,
+		"frr_isis_interface_hello_interval_seconds{area=1,iface=swp1,level=L2}":          3,
+		"frr_isis_interface_csnp_interval_seconds{area=1,iface=swp1,level=L1}":           10,
+		"frr_isis_interface_csnp_interval_seconds{area=1,iface=swp1,level=L2}":           10,
+		"frr_isis_interface_psnp_interval_seconds{area=1,iface=swp1,level=L1}":           2,
+		"frr_isis_interface_psnp_interval_seconds{area=1,iface=swp1,level=L2}":           2,
+	})
+}
@@ -0,0 +1,263 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	ldpSubsystem = "ldp"
+
+	ldpNeighborLabels = []string{"afi", "neighbor"}
+	ldpBindingLabels  = []string{"afi", "in_use"}
+	ldpPWLabels       = []string{"iface", "vpn", "peer", "vc_id"}
+	ldpDesc           = map[string]*prometheus.Desc{
+		"neighborState":     colPromDesc(ldpSubsystem, "neighbor_state", "State of the LDP session (1 = Operational, 0 = Down).", ldpNeighborLabels),
+		"neighborStateInfo": colPromDesc(ldpSubsystem, "neighbor_state_info", "State of the LDP session, such as OPERATIONAL or OPENSENT. Value is always 1.", append(ldpNeighborLabels, "state")),
+		"neighborUptime":    colPromDesc(ldpSubsystem, "neighbor_uptime_seconds", "How long has the LDP session been operational.", ldpNeighborLabels),
+		"neighborLabels":    colPromDesc(ldpSubsystem, "neighbor_labels_count_total", "Number of label bindings received from the LDP neighbor.", []string{"neighbor"}),
+
+		"localBindings":  colPromDesc(ldpSubsystem, "local_bindings_count_total", "Number of prefixes with a local label binding, and whether a remote binding of the prefix is in use for forwarding.", ldpBindingLabels),
+		"remoteBindings": colPromDesc(ldpSubsystem, "remote_bindings_count_total", "Number of remote label bindings received from LDP neighbors, and whether the binding is in use for forwarding.", ldpBindingLabels),
+
+		"pwState":       colPromDesc(ldpSubsystem, "pseudowire_state", "State of the LDP signaled pseudowire (1 = Up, 0 = Down).", ldpPWLabels),
+		"pwRemoteLabel": colPromDesc(ldpSubsystem, "pseudowire_remote_label", "Label received from the peer for the pseudowire, which is not included until the peer has assigned a label.", ldpPWLabels),
+	}
+	ldpErrors      = []error{}
+	totalLDPErrors = 0.0
+)
+
+// LDPCollector collects LDP metrics, implemented as per prometheus.Collector interface.
+type LDPCollector struct{}
+
+// NewLDPCollector returns a LDPCollector struct.
+func NewLDPCollector() *LDPCollector {
+	return &LDPCollector{}
+}
+
+// Name of the collector. Used to populate flag name.
+func (*LDPCollector) Name() string {
+	return ldpSubsystem
+}
+
+// Help describes the metrics this collector scrapes. Used to populate flag help.
+func (*LDPCollector) Help() string {
+	return "Collect LDP Metrics"
+}
+
+// EnabledByDefault describes whether this collector is enabled by default. Used to populate flag default.
+func (*LDPCollector) EnabledByDefault() bool {
+	return false
+}
+
+// Describe implemented as per the prometheus.Collector interface.
+func (*LDPCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range ldpDesc {
+		ch <- desc
+	}
+}
+
+// Collect implemented as per the prometheus.Collector interface.
+func (c *LDPCollector) Collect(ch chan<- prometheus.Metric) {
+	ldpErrors = []error{}
+
+	jsonLDPNeighbor, err := execVtyshCommand("-c", "show mpls ldp neighbor json")
+	if err != nil {
+		ldpErrors = append(ldpErrors, fmt.Errorf("cannot get ldp neighbors: %s", err))
+	} else {
+		if err := processLDPNeighbor(ch, jsonLDPNeighbor); err != nil {
+			ldpErrors = append(ldpErrors, err)
+		}
+	}
+
+	jsonLDPBinding, err := execVtyshCommand("-c", "show mpls ldp binding json")
+	if err != nil {
+		ldpErrors = append(ldpErrors, fmt.Errorf("cannot get ldp bindings: %s", err))
+	} else {
+		if err := processLDPBinding(ch, jsonLDPBinding); err != nil {
+			ldpErrors = append(ldpErrors, err)
+		}
+	}
+
+	// The remote labels of the pseudowires are not included in the VCs, so they are taken from the bindings.
+	remoteLabels := map[string]float64{}
+	jsonL2VPNBinding, err := execVtyshCommand("-c", "show l2vpn atom binding json")
+	if err != nil {
+		ldpErrors = append(ldpErrors, fmt.Errorf("cannot get l2vpn bindings: %s", err))
+	} else {
+		if remoteLabels, err = processL2VPNBinding(jsonL2VPNBinding); err != nil {
+			ldpErrors = append(ldpErrors, err)
+		}
+	}
+
+	jsonL2VPNVC, err := execVtyshCommand("-c", "show l2vpn atom vc json")
+	if err != nil {
+		ldpErrors = append(ldpErrors, fmt.Errorf("cannot get l2vpn vcs: %s", err))
+	} else {
+		if err := processL2VPNVC(ch, jsonL2VPNVC, remoteLabels); err != nil {
+			ldpErrors = append(ldpErrors, err)
+		}
+	}
+
+	totalLDPErrors += float64(len(ldpErrors))
+}
+
+// CollectErrors returns what errors have been gathered.
+func (*LDPCollector) CollectErrors() []error {
+	return ldpErrors
+}
+
+// CollectTotalErrors returns total errors.
+func (*LDPCollector) CollectTotalErrors() float64 {
+	return totalLDPErrors
+}
+
+func processLDPNeighbor(ch chan<- prometheus.Metric, jsonLDPNeighbor []byte) error {
+	var ldpNeighbors struct {
+		Neighbors []struct {
+			AddressFamily string
+			NeighborID    string `json:"neighborId"`
+			State         string
+			UpTime        string
+		}
+	}
+	if err := json.Unmarshal(jsonLDPNeighbor, &ldpNeighbors); err != nil {
+		return fmt.Errorf("cannot unmarshal ldp neighbor json: %s", err)
+	}
+
+	for _, neighbor := range ldpNeighbors.Neighbors {
+		// The labels are "afi", "neighbor"
+		labels := []string{neighbor.AddressFamily, neighbor.NeighborID}
+		neighborState, uptime := 0.0, 0.0
+		if neighbor.State == "OPERATIONAL" {
+			neighborState = 1
+			// The uptime is formatted the same as the PIM durations, such as 00:10:02 or 2d03h15m.
+			if duration, err := parseDuration(neighbor.UpTime); err == nil {
+				uptime = duration
+			}
+		}
+		newGauge(ch, ldpDesc["neighborState"], neighborState, labels...)
+		newGauge(ch, ldpDesc["neighborStateInfo"], 1, append(labels, neighbor.State)...)
+		newGauge(ch, ldpDesc["neighborUptime"], uptime, labels...)
+	}
+	return nil
+}
+
+func processLDPBinding(ch chan<- prometheus.Metric, jsonLDPBinding []byte) error {
+	var ldpBindings struct {
+		Bindings []ldpBinding
+	}
+	if err := json.Unmarshal(jsonLDPBinding, &ldpBindings); err != nil {
+		return fmt.Errorf("cannot unmarshal ldp binding json: %s", err)
+	}
+
+	// The bindings are listed per prefix and neighbor, so the local binding of a prefix is repeated for each neighbor.
+	neighborLabels := map[string]float64{}
+	localBindings := map[string]map[string]bool{}
+	remoteBindings := map[string]map[bool]float64{}
+	for _, binding := range ldpBindings.Bindings {
+		if _, exist := localBindings[binding.AddressFamily]; !exist {
+			localBindings[binding.AddressFamily] = map[string]bool{}
+			remoteBindings[binding.AddressFamily] = map[bool]float64{}
+		}
+		inUse := binding.InUse == 1
+		if ldpLabelBound(binding.LocalLabel) {
+			localBindings[binding.AddressFamily][binding.Prefix] = localBindings[binding.AddressFamily][binding.Prefix] || inUse
+		}
+		// Prefixes that are only bound locally do not have a remote label.
+		if !ldpLabelBound(binding.RemoteLabel) {
+			continue
+		}
+		neighborLabels[binding.NeighborID]++
+		remoteBindings[binding.AddressFamily][inUse]++
+	}
+	for neighbor, labels := range neighborLabels {
+		// The labels are "neighbor"
+		newGauge(ch, ldpDesc["neighborLabels"], labels, neighbor)
+	}
+	for afi, prefixes := range localBindings {
+		local := map[bool]float64{}
+		for _, inUse := range prefixes {
+			local[inUse]++
+		}
+		for _, inUse := range []bool{true, false} {
+			// The labels are "afi", "in_use"
+			labels := []string{afi, strconv.FormatBool(inUse)}
+			newGauge(ch, ldpDesc["localBindings"], local[inUse], labels...)
+			newGauge(ch, ldpDesc["remoteBindings"], remoteBindings[afi][inUse], labels...)
+		}
+	}
+	return nil
+}
+
+// processL2VPNBinding returns the remote labels of the pseudowires keyed by ldpPWKey(), for pseudowires that the peer
+// has assigned a label to.
+func processL2VPNBinding(jsonL2VPNBinding []byte) (map[string]float64, error) {
+	// The bindings are keyed by the peer and VC ID, such as "2.2.2.2: 100".
+	var l2vpnBindings map[string]struct {
+		Destination string
+		VcID        float64 `json:"vcId"`
+		// The remote label is "unassigned" until the peer has assigned a label.
+		RemoteLabel interface{}
+	}
+	if err := json.Unmarshal(jsonL2VPNBinding, &l2vpnBindings); err != nil {
+		return nil, fmt.Errorf("cannot unmarshal l2vpn binding json: %s", err)
+	}
+
+	remoteLabels := map[string]float64{}
+	for _, binding := range l2vpnBindings {
+		if label, ok := binding.RemoteLabel.(float64); ok {
+			remoteLabels[ldpPWKey(binding.Destination, binding.VcID)] = label
+		}
+	}
+	return remoteLabels, nil
+}
+
+func processL2VPNVC(ch chan<- prometheus.Metric, jsonL2VPNVC []byte, remoteLabels map[string]float64) error {
+	// The VCs are keyed by the pseudowire interface.
+	var l2vpnVCs map[string]struct {
+		PeerID  string  `json:"peerId"`
+		VcID    float64 `json:"vcId"`
+		VpnName string  `json:"VpnName"`
+		Status  string
+	}
+	if err := json.Unmarshal(jsonL2VPNVC, &l2vpnVCs); err != nil {
+		return fmt.Errorf("cannot unmarshal l2vpn vc json: %s", err)
+	}
+
+	for iface, vc := range l2vpnVCs {
+		// The labels are "iface", "vpn", "peer", "vc_id"
+		labels := []string{iface, vc.VpnName, vc.PeerID, strconv.FormatFloat(vc.VcID, 'f', -1, 64)}
+		state := 0.0
+		if vc.Status == "up" {
+			state = 1
+		}
+		newGauge(ch, ldpDesc["pwState"], state, labels...)
+		if label, exist := remoteLabels[ldpPWKey(vc.PeerID, vc.VcID)]; exist {
+			newGauge(ch, ldpDesc["pwRemoteLabel"], label, labels...)
+		}
+	}
+	return nil
+}
+
+// ldpPWKey returns the key of a pseudowire, as pseudowires are identified by the peer and VC ID.
+func ldpPWKey(peer string, vcID float64) string {
+	return fmt.Sprintf("%s|%v", peer, vcID)
+}
+
+// ldpLabelBound returns whether a label of 'show mpls ldp binding', such as 16 or imp-null, is bound.
+func ldpLabelBound(label string) bool {
+	return label != "" && label != "-"
+}
+
+type ldpBinding struct {
+	AddressFamily string
+	Prefix        string
+	NeighborID    string `json:"neighborId"`
+	LocalLabel    string
+	RemoteLabel   string
+	InUse         float64
+}
@@ -0,0 +1,93 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var ldpBindingJSON = []byte(`{
+  "bindings":[
+    {"addressFamily":"ipv4","prefix":"1.1.1.1/32","neighborId":"2.2.2.2","localLabel":"imp-null","remoteLabel":"16","inUse":0},
+    {"addressFamily":"ipv4","prefix":"1.1.1.1/32","neighborId":"3.3.3.3","localLabel":"imp-null","remoteLabel":"17","inUse":0},
+    {"addressFamily":"ipv4","prefix":"2.2.2.2/32","neighborId":"2.2.2.2","localLabel":"16","remoteLabel":"imp-null","inUse":1},
+    {"addressFamily":"ipv4","prefix":"2.2.2.2/32","neighborId":"3.3.3.3","localLabel":"16","remoteLabel":"18","inUse":0},
+    {"addressFamily":"ipv4","prefix":"3.3.3.3/32","neighborId":"3.3.3.3","localLabel":"17","remoteLabel":"imp-null","inUse":1},
+    {"addressFamily":"ipv4","prefix":"10.0.0.0/24","neighborId":"0.0.0.0","localLabel":"imp-null","remoteLabel":"-","inUse":0}
+  ]
+}`)
+
+func TestProcessLDPNeighbor(t *testing.T) {
+	ldpNeighbor := []byte(`{
+  "neighbors":[
+    {"addressFamily":"ipv4","neighborId":"2.2.2.2","state":"OPERATIONAL","transportAddress":"2.2.2.2","upTime":"01:02:03"},
+    {"addressFamily":"ipv4","neighborId":"3.3.3.3","state":"OPERATIONAL","transportAddress":"3.3.3.3","upTime":"1d02h03m"},
+    {"addressFamily":"ipv6","neighborId":"4.4.4.4","state":"OPENSENT","transportAddress":"2001:db8::4","upTime":"00:00:05"}
+  ]
+}`)
+
+	ch := make(chan prometheus.Metric, 1024)
+	if err := processLDPNeighbor(ch, ldpNeighbor); err != nil {
+		t.Errorf("error calling processLDPNeighbor: %s", err)
+	}
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, map[string]float64{
+		"frr_ldp_neighbor_state{afi=ipv4,neighbor=2.2.2.2}":                        1,
+		"frr_ldp_neighbor_state{afi=ipv4,neighbor=3.3.3.3}":                        1,
+		"frr_ldp_neighbor_state{afi=ipv6,neighbor=4.4.4.4}":                        0,
+		"frr_ldp_neighbor_state_info{afi=ipv4,neighbor=2.2.2.2,state=OPERATIONAL}": 1,
+		"frr_ldp_neighbor_state_info{afi=ipv4,neighbor=3.3.3.3,state=OPERATIONAL}": 1,
+		"frr_ldp_neighbor_state_info{afi=ipv6,neighbor=4.4.4.4,state=OPENSENT}":    1,
+		"frr_ldp_neighbor_uptime_seconds{afi=ipv4,neighbor=2.2.2.2}":               3723,
+		"frr_ldp_neighbor_uptime_seconds{afi=ipv4,neighbor=3.3.3.3}":               93780,
+		"frr_ldp_neighbor_uptime_seconds{afi=ipv6,neighbor=4.4.4.4}":               0,
+	})
+}
+
+func TestProcessLDPBinding(t *testing.T) {
+	ch := make(chan prometheus.Metric, 1024)
+	if err := processLDPBinding(ch, ldpBindingJSON); err != nil {
+		t.Errorf("error calling processLDPBinding: %s", err)
+	}
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, map[string]float64{
+		"frr_ldp_neighbor_labels_count_total{neighbor=2.2.2.2}":      2,
+		"frr_ldp_neighbor_labels_count_total{neighbor=3.3.3.3}":      3,
+		"frr_ldp_local_bindings_count_total{afi=ipv4,in_use=true}":   2,
+		"frr_ldp_local_bindings_count_total{afi=ipv4,in_use=false}":  2,
+		"frr_ldp_remote_bindings_count_total{afi=ipv4,in_use=true}":  2,
+		"frr_ldp_remote_bindings_count_total{afi=ipv4,in_use=false}": 3,
+	})
+}
+
+func TestProcessL2VPNVC(t *testing.T) {
+	l2vpnBinding := []byte(`{
+  "2.2.2.2: 100":{"destination":"2.2.2.2","vcId":100,"localLabel":16,"localControlWord":1,"localVcType":"Ethernet","localGroupID":0,"localIfMtu":1500,"remoteLabel":20,"remoteControlWord":1,"remoteVcType":"Ethernet","remoteGroupID":0,"remoteIfMtu":1500},
+  "3.3.3.3: 200":{"destination":"3.3.3.3","vcId":200,"localLabel":17,"localControlWord":1,"localVcType":"Ethernet","localGroupID":0,"localIfMtu":1500,"remoteLabel":"unassigned"}
+}`)
+	l2vpnVC := []byte(`{
+  "mpw0":{"peerId":"2.2.2.2","vcId":100,"VpnName":"CUST_A","status":"up"},
+  "mpw1":{"peerId":"3.3.3.3","vcId":200,"VpnName":"CUST_B","status":"down"}
+}`)
+
+	remoteLabels, err := processL2VPNBinding(l2vpnBinding)
+	if err != nil {
+		t.Errorf("error calling processL2VPNBinding: %s", err)
+	}
+	ch := make(chan prometheus.Metric, 1024)
+	if err := processL2VPNVC(ch, l2vpnVC, remoteLabels); err != nil {
+		t.Errorf("error calling processL2VPNVC: %s", err)
+	}
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, map[string]float64{
+		"frr_ldp_pseudowire_state{iface=mpw0,peer=2.2.2.2,vc_id=100,vpn=CUST_A}":        1,
+		"frr_ldp_pseudowire_state{iface=mpw1,peer=3.3.3.3,vc_id=200,vpn=CUST_B}":        0,
+		"frr_ldp_pseudowire_remote_label{iface=mpw0,peer=2.2.2.2,vc_id=100,vpn=CUST_A}": 20,
+	})
+}
@@ -0,0 +1,166 @@
+package collector
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+var (
+	memorySubsystem = "memory"
+
+	memoryMTypes = kingpin.Flag("collector.memory.mtypes", "Collect the allocated bytes and allocations of each memory type (MTYPE) of the daemons with the memory collector, in addition to the daemon totals (default: disabled).").Default("False").Bool()
+
+	memoryMTypeLabels = []string{"daemon", "group", "mtype"}
+	memoryDesc        = map[string]*prometheus.Desc{
+		"heapAllocated": colPromDesc(memorySubsystem, "heap_allocated_bytes", "Total heap allocated by the daemon, as reported by the system allocator.", []string{"daemon"}),
+		"allocated":     colPromDesc(memorySubsystem, "allocated_bytes", "Bytes currently allocated by the daemon across all memory types.", []string{"daemon"}),
+		"allocations":   colPromDesc(memorySubsystem, "allocations_count_total", "Number of current allocations of the daemon across all memory types.", []string{"daemon"}),
+
+		"mtypeAllocated":   colPromDesc(memorySubsystem, "mtype_allocated_bytes", "Bytes currently allocated by the daemon for the memory type.", memoryMTypeLabels),
+		"mtypeAllocations": colPromDesc(memorySubsystem, "mtype_allocations_count_total", "Number of current allocations of the daemon for the memory type.", memoryMTypeLabels),
+	}
+	memoryErrors      = []error{}
+	totalMemoryErrors = 0.0
+
+	memoryDaemonRegexp = regexp.MustCompile(`^Memory statistics for (\S+):`)
+	memoryHeapRegexp   = regexp.MustCompile(`^\s*Total heap allocated:\s+(\d+) (bytes|KiB|MiB|GiB)`)
+	memoryGroupRegexp  = regexp.MustCompile(`^--- qmem (.+) ---`)
+	// The total is not included by older FRR versions, in which case it is calculated from the size.
+	memoryMTypeRegexp = regexp.MustCompile(`^(.+?)\s*:\s+(\d+)\s+(\d+|variable)(?:\s+(\d+))?`)
+	memoryUnits       = map[string]float64{
+		"bytes": 1,
+		"KiB":   1024,
+		"MiB":   1024 * 1024,
+		"GiB":   1024 * 1024 * 1024,
+	}
+)
+
+// MemoryCollector collects memory metrics, implemented as per prometheus.Collector interface.
+type MemoryCollector struct{}
+
+// NewMemoryCollector returns a MemoryCollector struct.
+func NewMemoryCollector() *MemoryCollector {
+	return &MemoryCollector{}
+}
+
+// Name of the collector. Used to populate flag name.
+func (*MemoryCollector) Name() string {
+	return memorySubsystem
+}
+
+// Help describes the metrics this collector scrapes. Used to populate flag help.
+func (*MemoryCollector) Help() string {
+	return "Collect Memory Metrics"
+}
+
+// EnabledByDefault describes whether this collector is enabled by default. Used to populate flag default.
+func (*MemoryCollector) EnabledByDefault() bool {
+	return false
+}
+
+// Describe implemented as per the prometheus.Collector interface.
+func (*MemoryCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range memoryDesc {
+		ch <- desc
+	}
+}
+
+// Collect implemented as per the prometheus.Collector interface.
+func (c *MemoryCollector) Collect(ch chan<- prometheus.Metric) {
+	memoryErrors = []error{}
+
+	// The memory statistics are only available as text, vtysh prints the statistics of each daemon.
+	memory, err := execVtyshCommand("-c", "show memory")
+	if err != nil {
+		memoryErrors = append(memoryErrors, fmt.Errorf("cannot get memory: %s", err))
+	} else {
+		processMemory(ch, memory, *memoryMTypes)
+	}
+
+	totalMemoryErrors += float64(len(memoryErrors))
+}
+
+// CollectErrors returns what errors have been gathered.
+func (*MemoryCollector) CollectErrors() []error {
+	return memoryErrors
+}
+
+// CollectTotalErrors returns total errors.
+func (*MemoryCollector) CollectTotalErrors() float64 {
+	return totalMemoryErrors
+}
+
+// processMemory processes the output of 'show memory', such as:
+//
+//	Memory statistics for zebra:
+//	System allocator statistics:
+//	  Total heap allocated:  6336 KiB
+//	...
+//	--- qmem libfrr ---
+//	Type                          : Current#   Size       Total     Max#  MaxBytes
+//	Buffer                        :        3         24        72        3        72
+//	Host config                   :        3   variable        80        3        80
+func processMemory(ch chan<- prometheus.Metric, output []byte, mtypes bool) {
+	daemons := []string{}
+	heapAllocated, allocated, allocations := map[string]float64{}, map[string]float64{}, map[string]float64{}
+	daemon, group := "", ""
+	for _, line := range strings.Split(string(output), "\n") {
+		if match := memoryDaemonRegexp.FindStringSubmatch(line); match != nil {
+			daemon, group = match[1], ""
+			daemons = append(daemons, daemon)
+			continue
+		}
+		if daemon == "" {
+			continue
+		}
+		if match := memoryHeapRegexp.FindStringSubmatch(line); match != nil {
+			if heap, err := strconv.ParseFloat(match[1], 64); err == nil {
+				heapAllocated[daemon] = heap * memoryUnits[match[2]]
+			}
+			continue
+		}
+		if match := memoryGroupRegexp.FindStringSubmatch(line); match != nil {
+			group = match[1]
+			continue
+		}
+		// Only the lines of the qmem groups are memory types.
+		if group == "" {
+			continue
+		}
+		match := memoryMTypeRegexp.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		count, err := strconv.ParseFloat(match[2], 64)
+		if err != nil {
+			continue
+		}
+		bytes := 0.0
+		if match[4] != "" {
+			bytes, _ = strconv.ParseFloat(match[4], 64)
+		} else if size, err := strconv.ParseFloat(match[3], 64); err == nil {
+			bytes = count * size
+		}
+		allocated[daemon] += bytes
+		allocations[daemon] += count
+		if mtypes {
+			// The labels are "daemon", "group", "mtype"
+			labels := []string{daemon, group, strings.TrimSpace(match[1])}
+			newGauge(ch, memoryDesc["mtypeAllocated"], bytes, labels...)
+			newGauge(ch, memoryDesc["mtypeAllocations"], count, labels...)
+		}
+	}
+	for _, daemon := range daemons {
+		// The labels are "daemon"
+		if heap, exist := heapAllocated[daemon]; exist {
+			newGauge(ch, memoryDesc["heapAllocated"], heap, daemon)
+		}
+		newGauge(ch, memoryDesc["allocated"], allocated[daemon], daemon)
+		newGauge(ch, memoryDesc["allocations"], allocations[daemon], daemon)
+	}
+}
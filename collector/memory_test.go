@@ -0,0 +1,79 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var memoryOutput = []byte(`Memory statistics for zebra:
+System allocator statistics:
+  Total heap allocated:  6336 KiB
+  Holding block headers: 0 bytes
+  Used small blocks:     0 bytes
+  Used ordinary blocks:  5888 KiB
+  Free small blocks:     1792 bytes
+  Free ordinary blocks:  448 KiB
+  Ordinary blocks:       24
+  Small blocks:          56
+  Holding blocks:        0
+(see system documentation for 'mallinfo' for meaning)
+--- qmem libfrr ---
+Type                          : Current#   Size       Total     Max#  MaxBytes
+Buffer                        :        3         24        72        3        72
+Host config                   :        3   variable        80        3        80
+--- qmem Zebra ---
+Type                          : Current#   Size       Total     Max#  MaxBytes
+Route Entry                   :       10         80       800       12       960
+
+Memory statistics for bgpd:
+System allocator statistics:
+  Total heap allocated:  2 MiB
+(see system documentation for 'mallinfo' for meaning)
+--- qmem libfrr ---
+Type                          : Current#   Size
+Buffer                        :        2         24
+Host config                   :        1   variable
+`)
+
+func TestProcessMemory(t *testing.T) {
+	ch := make(chan prometheus.Metric, 1024)
+	processMemory(ch, memoryOutput, false)
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, map[string]float64{
+		"frr_memory_heap_allocated_bytes{daemon=zebra}":    6488064,
+		"frr_memory_heap_allocated_bytes{daemon=bgpd}":     2097152,
+		"frr_memory_allocated_bytes{daemon=zebra}":         952,
+		"frr_memory_allocated_bytes{daemon=bgpd}":          48,
+		"frr_memory_allocations_count_total{daemon=zebra}": 16,
+		"frr_memory_allocations_count_total{daemon=bgpd}":  3,
+	})
+}
+
+func TestProcessMemoryMTypes(t *testing.T) {
+	ch := make(chan prometheus.Metric, 1024)
+	processMemory(ch, memoryOutput, true)
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, map[string]float64{
+		"frr_memory_heap_allocated_bytes{daemon=zebra}":                                         6488064,
+		"frr_memory_heap_allocated_bytes{daemon=bgpd}":                                          2097152,
+		"frr_memory_allocated_bytes{daemon=zebra}":                                              952,
+		"frr_memory_allocated_bytes{daemon=bgpd}":                                               48,
+		"frr_memory_allocations_count_total{daemon=zebra}":                                      16,
+		"frr_memory_allocations_count_total{daemon=bgpd}":                                       3,
+		"frr_memory_mtype_allocated_bytes{daemon=zebra,group=libfrr,mtype=Buffer}":              72,
+		"frr_memory_mtype_allocated_bytes{daemon=zebra,group=libfrr,mtype=Host config}":         80,
+		"frr_memory_mtype_allocated_bytes{daemon=zebra,group=Zebra,mtype=Route Entry}":          800,
+		"frr_memory_mtype_allocated_bytes{daemon=bgpd,group=libfrr,mtype=Buffer}":               48,
+		"frr_memory_mtype_allocated_bytes{daemon=bgpd,group=libfrr,mtype=Host config}":          0,
+		"frr_memory_mtype_allocations_count_total{daemon=zebra,group=libfrr,mtype=Buffer}":      3,
+		"frr_memory_mtype_allocations_count_total{daemon=zebra,group=libfrr,mtype=Host config}": 3,
+		"frr_memory_mtype_allocations_count_total{daemon=zebra,group=Zebra,mtype=Route Entry}":  10,
+		"frr_memory_mtype_allocations_count_total{daemon=bgpd,group=libfrr,mtype=Buffer}":       2,
+		"frr_memory_mtype_allocations_count_total{daemon=bgpd,group=libfrr,mtype=Host config}":  1,
+	})
+}
@@ -0,0 +1,118 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	mplsSubsystem = "mpls"
+
+	mplsLabelLabels = []string{"type"}
+	mplsDesc        = map[string]*prometheus.Desc{
+		"labels":          colPromDesc(mplsSubsystem, "labels_count_total", "Number of incoming labels in the MPLS label table per label type, such as LDP, BGP, Static or SR (IS-IS).", mplsLabelLabels),
+		"installedLabels": colPromDesc(mplsSubsystem, "installed_labels_count_total", "Number of incoming labels per label type that are installed in the kernel forwarding table (LFIB).", mplsLabelLabels),
+		"nexthops":        colPromDesc(mplsSubsystem, "nexthops_count_total", "Number of nexthops of the labels in the MPLS label table per label type.", mplsLabelLabels),
+	}
+	mplsErrors      = []error{}
+	totalMPLSErrors = 0.0
+)
+
+// MPLSCollector collects MPLS metrics, implemented as per prometheus.Collector interface.
+type MPLSCollector struct{}
+
+// NewMPLSCollector returns a MPLSCollector struct.
+func NewMPLSCollector() *MPLSCollector {
+	return &MPLSCollector{}
+}
+
+// Name of the collector. Used to populate flag name.
+func (*MPLSCollector) Name() string {
+	return mplsSubsystem
+}
+
+// Help describes the metrics this collector scrapes. Used to populate flag help.
+func (*MPLSCollector) Help() string {
+	return "Collect MPLS Label Table Metrics"
+}
+
+// EnabledByDefault describes whether this collector is enabled by default. Used to populate flag default.
+func (*MPLSCollector) EnabledByDefault() bool {
+	return false
+}
+
+// Describe implemented as per the prometheus.Collector interface.
+func (*MPLSCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range mplsDesc {
+		ch <- desc
+	}
+}
+
+// Collect implemented as per the prometheus.Collector interface.
+func (c *MPLSCollector) Collect(ch chan<- prometheus.Metric) {
+	mplsErrors = []error{}
+
+	jsonMPLSTable, err := execVtyshCommand("-c", "show mpls table json")
+	if err != nil {
+		mplsErrors = append(mplsErrors, fmt.Errorf("cannot get mpls table: %s", err))
+	} else {
+		if err := processMPLSTable(ch, jsonMPLSTable); err != nil {
+			mplsErrors = append(mplsErrors, err)
+		}
+	}
+
+	totalMPLSErrors += float64(len(mplsErrors))
+}
+
+// CollectErrors returns what errors have been gathered.
+func (*MPLSCollector) CollectErrors() []error {
+	return mplsErrors
+}
+
+// CollectTotalErrors returns total errors.
+func (*MPLSCollector) CollectTotalErrors() float64 {
+	return totalMPLSErrors
+}
+
+func processMPLSTable(ch chan<- prometheus.Metric, jsonMPLSTable []byte) error {
+	// The label table is keyed by the incoming label.
+	var mplsTable map[string]mplsLabel
+	if err := json.Unmarshal(jsonMPLSTable, &mplsTable); err != nil {
+		return fmt.Errorf("cannot unmarshal mpls table json: %s", err)
+	}
+
+	labels, installedLabels, nexthops := map[string]float64{}, map[string]float64{}, map[string]float64{}
+	for _, label := range mplsTable {
+		if len(label.Nexthops) == 0 {
+			continue
+		}
+		// The type is included per nexthop, the label is counted as the type of its first nexthop.
+		labelType := label.Nexthops[0].Type
+		labels[labelType]++
+		if label.Installed {
+			installedLabels[labelType]++
+		}
+		for _, nexthop := range label.Nexthops {
+			nexthops[nexthop.Type]++
+		}
+	}
+	for labelType, count := range labels {
+		// The labels are "type"
+		newGauge(ch, mplsDesc["labels"], count, labelType)
+		newGauge(ch, mplsDesc["installedLabels"], installedLabels[labelType], labelType)
+	}
+	for labelType, count := range nexthops {
+		// The labels are "type"
+		newGauge(ch, mplsDesc["nexthops"], count, labelType)
+	}
+	return nil
+}
+
+type mplsLabel struct {
+	Installed bool
+	Nexthops  []struct {
+		Type string
+	}
+}
@@ -0,0 +1,60 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestProcessMPLSTable(t *testing.T) {
+	mplsTable := []byte(`{
+  "16":{
+    "inLabel":16,
+    "installed":true,
+    "nexthops":[
+      {"type":"LDP","outLabel":3,"outLabelStack":[3],"distance":150,"installed":true,"nexthop":"10.0.1.2"},
+      {"type":"LDP","outLabel":3,"outLabelStack":[3],"distance":150,"installed":true,"nexthop":"10.0.2.2"}
+    ]
+  },
+  "17":{
+    "inLabel":17,
+    "installed":true,
+    "nexthops":[
+      {"type":"LDP","outLabel":18,"outLabelStack":[18],"distance":150,"installed":true,"nexthop":"10.0.1.2"}
+    ]
+  },
+  "16002":{
+    "inLabel":16002,
+    "installed":true,
+    "nexthops":[
+      {"type":"SR (IS-IS)","outLabel":3,"outLabelStack":[3],"distance":150,"installed":true,"nexthop":"10.0.1.2"}
+    ]
+  },
+  "100":{
+    "inLabel":100,
+    "installed":false,
+    "nexthops":[
+      {"type":"Static","outLabel":200,"outLabelStack":[200],"distance":150,"nexthop":"10.0.3.2"}
+    ]
+  }
+}`)
+
+	ch := make(chan prometheus.Metric, 1024)
+	if err := processMPLSTable(ch, mplsTable); err != nil {
+		t.Errorf("error calling processMPLSTable: %s", err)
+	}
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, map[string]float64{
+		"frr_mpls_labels_count_total{type=LDP}":                  2,
+		"frr_mpls_labels_count_total{type=SR (IS-IS)}":           1,
+		"frr_mpls_labels_count_total{type=Static}":               1,
+		"frr_mpls_installed_labels_count_total{type=LDP}":        2,
+		"frr_mpls_installed_labels_count_total{type=SR (IS-IS)}": 1,
+		"frr_mpls_installed_labels_count_total{type=Static}":     0,
+		"frr_mpls_nexthops_count_total{type=LDP}":                3,
+		"frr_mpls_nexthops_count_total{type=SR (IS-IS)}":         1,
+		"frr_mpls_nexthops_count_total{type=Static}":             1,
+	})
+}
@@ -0,0 +1,107 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	nexthopGroupSubsystem    = "nexthopgroup"
+	nexthopGroupMetricPrefix = "nexthop_group"
+
+	nexthopGroupDesc = map[string]*prometheus.Desc{
+		"groups": colPromDesc(nexthopGroupMetricPrefix, "count_total", "Number of nexthop groups in the RIB per owner, such as zebra or bgp, and whether they are valid and installed in the kernel.", []string{"type", "valid", "installed"}),
+		"width":  colPromDesc(nexthopGroupMetricPrefix, "width_count_total", "Number of installed nexthop groups per number of nexthops, which is the ECMP width of the group.", []string{"nexthops"}),
+	}
+	nexthopGroupErrors      = []error{}
+	totalNexthopGroupErrors = 0.0
+)
+
+// NexthopGroupCollector collects nexthop group metrics, implemented as per prometheus.Collector interface.
+type NexthopGroupCollector struct{}
+
+// NewNexthopGroupCollector returns a NexthopGroupCollector struct.
+func NewNexthopGroupCollector() *NexthopGroupCollector {
+	return &NexthopGroupCollector{}
+}
+
+// Name of the collector. Used to populate flag name.
+func (*NexthopGroupCollector) Name() string {
+	return nexthopGroupSubsystem
+}
+
+// Help describes the metrics this collector scrapes. Used to populate flag help.
+func (*NexthopGroupCollector) Help() string {
+	return "Collect Nexthop Group Metrics"
+}
+
+// EnabledByDefault describes whether this collector is enabled by default. Used to populate flag default.
+func (*NexthopGroupCollector) EnabledByDefault() bool {
+	return false
+}
+
+// Describe implemented as per the prometheus.Collector interface.
+func (*NexthopGroupCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range nexthopGroupDesc {
+		ch <- desc
+	}
+}
+
+// Collect implemented as per the prometheus.Collector interface.
+func (c *NexthopGroupCollector) Collect(ch chan<- prometheus.Metric) {
+	nexthopGroupErrors = []error{}
+
+	jsonNexthopGroup, err := execVtyshCommand("-c", "show nexthop-group rib json")
+	if err != nil {
+		nexthopGroupErrors = append(nexthopGroupErrors, fmt.Errorf("cannot get nexthop groups: %s", err))
+	} else {
+		if err := processNexthopGroup(ch, jsonNexthopGroup); err != nil {
+			nexthopGroupErrors = append(nexthopGroupErrors, err)
+		}
+	}
+
+	totalNexthopGroupErrors += float64(len(nexthopGroupErrors))
+}
+
+// CollectErrors returns what errors have been gathered.
+func (*NexthopGroupCollector) CollectErrors() []error {
+	return nexthopGroupErrors
+}
+
+// CollectTotalErrors returns total errors.
+func (*NexthopGroupCollector) CollectTotalErrors() float64 {
+	return totalNexthopGroupErrors
+}
+
+func processNexthopGroup(ch chan<- prometheus.Metric, jsonNexthopGroup []byte) error {
+	// The nexthop groups are keyed by the nexthop group ID.
+	var nexthopGroups map[string]struct {
+		Type      string
+		Valid     bool
+		Installed bool
+		Nexthops  []json.RawMessage
+	}
+	if err := json.Unmarshal(jsonNexthopGroup, &nexthopGroups); err != nil {
+		return fmt.Errorf("cannot unmarshal nexthop group json: %s", err)
+	}
+
+	groups, widths := map[[3]string]float64{}, map[int]float64{}
+	for _, group := range nexthopGroups {
+		groups[[3]string{group.Type, strconv.FormatBool(group.Valid), strconv.FormatBool(group.Installed)}]++
+		if group.Installed {
+			widths[len(group.Nexthops)]++
+		}
+	}
+	for key, count := range groups {
+		// The labels are "type", "valid", "installed"
+		newGauge(ch, nexthopGroupDesc["groups"], count, key[:]...)
+	}
+	for width, count := range widths {
+		// The labels are "nexthops"
+		newGauge(ch, nexthopGroupDesc["width"], count, strconv.Itoa(width))
+	}
+	return nil
+}
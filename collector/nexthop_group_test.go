@@ -0,0 +1,51 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestProcessNexthopGroup(t *testing.T) {
+	jsonNexthopGroup := []byte(`{
+  "10":{
+    "type":"zebra","refCount":2,"uptime":"00:10:04","vrf":"default","valid":true,"installed":true,
+    "nexthops":[{"flags":3,"ip":"10.0.0.2","afi":"ipv4","interfaceIndex":3,"interfaceName":"swp1","vrf":"default","active":true}]
+  },
+  "11":{
+    "type":"zebra","refCount":2,"uptime":"00:10:04","vrf":"default","valid":true,"installed":true,
+    "nexthops":[{"flags":3,"ip":"10.0.1.2","afi":"ipv4","interfaceIndex":4,"interfaceName":"swp2","vrf":"default","active":true}]
+  },
+  "12":{
+    "type":"zebra","refCount":120,"uptime":"00:10:04","vrf":"default","valid":true,"installed":true,
+    "depends":[10,11],
+    "nexthops":[
+      {"flags":3,"ip":"10.0.0.2","afi":"ipv4","interfaceIndex":3,"interfaceName":"swp1","vrf":"default","active":true},
+      {"flags":3,"ip":"10.0.1.2","afi":"ipv4","interfaceIndex":4,"interfaceName":"swp2","vrf":"default","active":true}
+    ]
+  },
+  "13":{
+    "type":"bgp","refCount":1,"uptime":"00:01:00","vrf":"default","valid":true,"installed":false,
+    "nexthops":[{"flags":1,"ip":"10.0.2.2","afi":"ipv4","vrf":"default"}]
+  },
+  "14":{
+    "type":"sharp","refCount":1,"uptime":"00:01:00","vrf":"default","valid":false,"installed":false,
+    "nexthops":[{"flags":0,"ip":"10.0.3.2","afi":"ipv4","vrf":"default"}]
+  }
+}`)
+
+	ch := make(chan prometheus.Metric, 1024)
+	if err := processNexthopGroup(ch, jsonNexthopGroup); err != nil {
+		t.Errorf("error calling processNexthopGroup: %s", err)
+	}
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, map[string]float64{
+		"frr_nexthop_group_count_total{installed=true,type=zebra,valid=true}":   3,
+		"frr_nexthop_group_count_total{installed=false,type=bgp,valid=true}":    1,
+		"frr_nexthop_group_count_total{installed=false,type=sharp,valid=false}": 1,
+		"frr_nexthop_group_width_count_total{nexthops=1}":                       2,
+		"frr_nexthop_group_width_count_total{nexthops=2}":                       1,
+	})
+}
@@ -0,0 +1,136 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	nhrpSubsystem = "nhrp"
+
+	nhrpNHSLabels = []string{"afi", "iface", "nhs", "nbma"}
+	nhrpDesc      = map[string]*prometheus.Desc{
+		"cacheEntries": colPromDesc(nhrpSubsystem, "cache_entries_count_total", "Number of entries in the NHRP cache per interface and entry type, such as dynamic, static, incomplete or nhs.", []string{"afi", "iface", "type"}),
+
+		"nhsUp": colPromDesc(nhrpSubsystem, "nhs_up", "Whether the Next Hop Server (NHS) is resolved and registered to (1 = Up, 0 = Down).", nhrpNHSLabels),
+	}
+	nhrpErrors      = []error{}
+	totalNHRPErrors = 0.0
+)
+
+// NHRPCollector collects NHRP metrics, implemented as per prometheus.Collector interface.
+type NHRPCollector struct{}
+
+// NewNHRPCollector returns a NHRPCollector struct.
+func NewNHRPCollector() *NHRPCollector {
+	return &NHRPCollector{}
+}
+
+// Name of the collector. Used to populate flag name.
+func (*NHRPCollector) Name() string {
+	return nhrpSubsystem
+}
+
+// Help describes the metrics this collector scrapes. Used to populate flag help.
+func (*NHRPCollector) Help() string {
+	return "Collect NHRP Metrics"
+}
+
+// EnabledByDefault describes whether this collector is enabled by default. Used to populate flag default.
+func (*NHRPCollector) EnabledByDefault() bool {
+	return false
+}
+
+// Describe implemented as per the prometheus.Collector interface.
+func (*NHRPCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range nhrpDesc {
+		ch <- desc
+	}
+}
+
+// Collect implemented as per the prometheus.Collector interface.
+func (c *NHRPCollector) Collect(ch chan<- prometheus.Metric) {
+	nhrpErrors = []error{}
+
+	for afi, ip := range routeAFIs {
+		jsonNHRPCache, err := execVtyshCommand("-c", fmt.Sprintf("show %s nhrp cache json", ip))
+		if err != nil {
+			nhrpErrors = append(nhrpErrors, fmt.Errorf("cannot get %s nhrp cache: %s", afi, err))
+		} else {
+			if err := processNHRPCache(ch, jsonNHRPCache, afi); err != nil {
+				nhrpErrors = append(nhrpErrors, err)
+			}
+		}
+
+		jsonNHRPNHS, err := execVtyshCommand("-c", fmt.Sprintf("show %s nhrp nhs json", ip))
+		if err != nil {
+			nhrpErrors = append(nhrpErrors, fmt.Errorf("cannot get %s nhrp nhs: %s", afi, err))
+		} else {
+			if err := processNHRPNHS(ch, jsonNHRPNHS, afi); err != nil {
+				nhrpErrors = append(nhrpErrors, err)
+			}
+		}
+	}
+
+	totalNHRPErrors += float64(len(nhrpErrors))
+}
+
+// CollectErrors returns what errors have been gathered.
+func (*NHRPCollector) CollectErrors() []error {
+	return nhrpErrors
+}
+
+// CollectTotalErrors returns total errors.
+func (*NHRPCollector) CollectTotalErrors() float64 {
+	return totalNHRPErrors
+}
+
+func processNHRPCache(ch chan<- prometheus.Metric, jsonNHRPCache []byte, afi string) error {
+	var nhrpCache struct {
+		Table []struct {
+			Interface string
+			Type      string
+		}
+	}
+	if err := json.Unmarshal(jsonNHRPCache, &nhrpCache); err != nil {
+		return fmt.Errorf("cannot unmarshal nhrp cache json: %s", err)
+	}
+
+	entries := map[[2]string]float64{}
+	for _, entry := range nhrpCache.Table {
+		entries[[2]string{entry.Interface, entry.Type}]++
+	}
+	for key, count := range entries {
+		// The labels are "afi", "iface", "type"
+		newGauge(ch, nhrpDesc["cacheEntries"], count, afi, key[0], key[1])
+	}
+	return nil
+}
+
+func processNHRPNHS(ch chan<- prometheus.Metric, jsonNHRPNHS []byte, afi string) error {
+	var nhrpNHS struct {
+		Table []struct {
+			Interface string
+			Fqdn      string
+			Nbma      string
+			Proto     string
+		}
+	}
+	if err := json.Unmarshal(jsonNHRPNHS, &nhrpNHS); err != nil {
+		return fmt.Errorf("cannot unmarshal nhrp nhs json: %s", err)
+	}
+
+	for _, nhs := range nhrpNHS.Table {
+		// The NBMA and protocol address of the NHS are "-" until the NHS is resolved and registered to.
+		up := 0.0
+		if nhs.Nbma != "-" && nhs.Nbma != "" && nhs.Proto != "-" && nhs.Proto != "" {
+			up = 1
+		}
+		// An NHS configured by FQDN has a row per address it resolves to, so the NBMA address is included in the labels.
+		// The labels are "afi", "iface", "nhs", "nbma"
+		newGauge(ch, nhrpDesc["nhsUp"], up, afi, nhs.Interface, nhs.Fqdn, nhs.Nbma)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestProcessNHRPCache(t *testing.T) {
+	nhrpCache := []byte(`{
+  "attr":{"entriesCount":5},
+  "table":[
+    {"interface":"gre1","type":"nhs","protocol":"10.255.0.1","nbma":"192.0.2.1","claimed_nbma":"192.0.2.1","used":true,"timeout":true,"auth":false,"identity":""},
+    {"interface":"gre1","type":"dynamic","protocol":"10.255.0.2","nbma":"198.51.100.2","claimed_nbma":"198.51.100.2","used":false,"timeout":true,"auth":false,"identity":""},
+    {"interface":"gre1","type":"dynamic","protocol":"10.255.0.3","nbma":"198.51.100.3","claimed_nbma":"198.51.100.3","used":true,"timeout":true,"auth":false,"identity":""},
+    {"interface":"gre1","type":"incomplete","protocol":"10.255.0.4","nbma":"-","claimed_nbma":"-","used":false,"timeout":true,"auth":false,"identity":""},
+    {"interface":"gre2","type":"static","protocol":"10.254.0.1","nbma":"203.0.113.1","claimed_nbma":"203.0.113.1","used":false,"timeout":false,"auth":false,"identity":""}
+  ]
+}`)
+
+	ch := make(chan prometheus.Metric, 1024)
+	if err := processNHRPCache(ch, nhrpCache, "ipv4"); err != nil {
+		t.Errorf("error calling processNHRPCache: %s", err)
+	}
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, map[string]float64{
+		"frr_nhrp_cache_entries_count_total{afi=ipv4,iface=gre1,type=nhs}":        1,
+		"frr_nhrp_cache_entries_count_total{afi=ipv4,iface=gre1,type=dynamic}":    2,
+		"frr_nhrp_cache_entries_count_total{afi=ipv4,iface=gre1,type=incomplete}": 1,
+		"frr_nhrp_cache_entries_count_total{afi=ipv4,iface=gre2,type=static}":     1,
+	})
+}
+
+func TestProcessNHRPNHS(t *testing.T) {
+	nhrpNHS := []byte(`{
+  "attr":{"entriesCount":3},
+  "table":[
+    {"interface":"gre1","fqdn":"hub1.example.com","nbma":"192.0.2.1","proto":"10.255.0.1"},
+    {"interface":"gre1","fqdn":"hub1.example.com","nbma":"192.0.2.11","proto":"10.255.0.11"},
+    {"interface":"gre1","fqdn":"hub2.example.com","nbma":"-","proto":"-"}
+  ]
+}`)
+
+	ch := make(chan prometheus.Metric, 1024)
+	if err := processNHRPNHS(ch, nhrpNHS, "ipv4"); err != nil {
+		t.Errorf("error calling processNHRPNHS: %s", err)
+	}
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, map[string]float64{
+		"frr_nhrp_nhs_up{afi=ipv4,iface=gre1,nbma=192.0.2.1,nhs=hub1.example.com}":  1,
+		"frr_nhrp_nhs_up{afi=ipv4,iface=gre1,nbma=192.0.2.11,nhs=hub1.example.com}": 1,
+		"frr_nhrp_nhs_up{afi=ipv4,iface=gre1,nbma=-,nhs=hub2.example.com}":          0,
+	})
+}
@@ -1,25 +1,84 @@
 package collector
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
-	"os/exec"
 	"strings"
+	"sync"
 
 	"github.com/prometheus/client_golang/prometheus"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
 )
 
 var (
 	ospfSubsystem = "ospf"
 
-	ospfIfaceLabels = []string{"vrf", "iface", "area"}
-	ospfDesc        = map[string]*prometheus.Desc{
-		"ospfIfaceNeigh":    colPromDesc(ospfSubsystem, "neighbors", "Number of neighbors deteceted.", ospfIfaceLabels),
-		"ospfIfaceNeighAdj": colPromDesc(ospfSubsystem, "neighbor_adjacencies", "Number of neighbor adjacencies formed.", ospfIfaceLabels),
+	ospfInstances = kingpin.Flag("collector.ospf.instances", "Collect OSPF metrics from the instances of a multi-instance OSPF deployment (ospfd -n), instead of from all VRFs. Supports multiple values.").Strings()
+	ospfGRHelper  = kingpin.Flag("collector.ospf.gr-helper", "Collect OSPF graceful restart helper metrics with the ospf collector (default: disabled).").Default("False").Bool()
+
+	ospfLabels         = []string{"vrf", "instance"}
+	ospfAreaLabels     = []string{"vrf", "instance", "area"}
+	ospfIfaceLabels    = []string{"vrf", "instance", "iface", "area"}
+	ospfNeighborLabels = append(ospfIfaceLabels, "neighbor")
+	ospfDesc           = map[string]*prometheus.Desc{
+		"ospfIfaceNeigh":     colPromDesc(ospfSubsystem, "neighbors", "Number of neighbors deteceted.", ospfIfaceLabels),
+		"ospfIfaceNeighAdj":  colPromDesc(ospfSubsystem, "neighbor_adjacencies", "Number of neighbor adjacencies formed.", ospfIfaceLabels),
+		"ospfIfaceCost":      colPromDesc(ospfSubsystem, "interface_cost", "OSPF cost of the interface.", ospfIfaceLabels),
+		"ospfIfaceState":     colPromDesc(ospfSubsystem, "interface_state_info", "State of the interface, such as DR, Backup or DROther. Value is always 1.", append(ospfIfaceLabels, "state")),
+		"ospfIfaceHello":     colPromDesc(ospfSubsystem, "interface_hello_interval_seconds", "Hello interval of the interface.", ospfIfaceLabels),
+		"ospfIfacePassive":   colPromDesc(ospfSubsystem, "interface_passive", "Whether the interface is passive (1 = passive, 0 = not passive).", ospfIfaceLabels),
+		"ospfVirtualLinkUp":  colPromDesc(ospfSubsystem, "virtual_link_up", "Whether the virtual link to the peer is fully adjacent (1 = up, 0 = down).", append(ospfIfaceLabels, "peer")),
+		"ospfIfaceDR":        colPromDesc(ospfSubsystem, "interface_dr_info", "Router ID of the designated router and backup designated router of the broadcast or NBMA interface. Value is always 1.", append(ospfIfaceLabels, "dr", "bdr")),
+		"ospfIfaceDRChanges": colPromDesc(ospfSubsystem, "interface_dr_changes_total", "Number of times the designated router of the broadcast or NBMA interface has changed since the exporter started.", ospfIfaceLabels),
+
+		"ospfIfaceDead":       colPromDesc(ospfSubsystem, "interface_dead_interval_seconds", "Dead interval of the interface.", ospfIfaceLabels),
+		"ospfIfaceRetransmit": colPromDesc(ospfSubsystem, "interface_retransmit_interval_seconds", "Interval between LSA retransmissions on the interface.", ospfIfaceLabels),
+		"ospfIfacePackets":    colPromDesc(ospfSubsystem, "interface_packets_total", "Number of OSPF packets sent and received on the interface per packet type, such as hello or ls_ack.", append(ospfIfaceTrafficLabels, "type", "direction")),
+
+		"ospfAreaInfo":            colPromDesc(ospfSubsystem, "area_info", "Type of the area, such as normal, stub or nssa. Value is always 1.", append(ospfAreaLabels, "type")),
+		"ospfAreaIfaces":          colPromDesc(ospfSubsystem, "area_interfaces_count_total", "Number of interfaces in the area.", ospfAreaLabels),
+		"ospfAreaActiveIfaces":    colPromDesc(ospfSubsystem, "area_active_interfaces_count_total", "Number of active interfaces in the area.", ospfAreaLabels),
+		"ospfAreaFullAdjacencies": colPromDesc(ospfSubsystem, "area_full_adjacencies_count_total", "Number of fully adjacent neighbors in the area.", ospfAreaLabels),
+		"ospfSPFExecutions":       colPromDesc(ospfSubsystem, "spf_executions_total", "Number of SPF calculations executed for the area.", ospfAreaLabels),
+		"ospfSPFLastRun":          colPromDesc(ospfSubsystem, "spf_last_run_timestamp_seconds", "Unix timestamp of the last SPF calculation.", ospfLabels),
+		"ospfSPFLastDuration":     colPromDesc(ospfSubsystem, "spf_last_duration_seconds", "Duration of the last SPF calculation.", ospfLabels),
+		"ospfSPFDelay":            colPromDesc(ospfSubsystem, "spf_delay_seconds", "Configured delay before an SPF calculation is run.", ospfLabels),
+		"ospfSPFHoldMin":          colPromDesc(ospfSubsystem, "spf_holdtime_min_seconds", "Configured minimum hold time between SPF calculations.", ospfLabels),
+		"ospfSPFHoldMax":          colPromDesc(ospfSubsystem, "spf_holdtime_max_seconds", "Configured maximum hold time between SPF calculations.", ospfLabels),
+		"ospfSPFHoldMultiple":     colPromDesc(ospfSubsystem, "spf_holdtime_multiplier", "Current multiplier of the minimum hold time, which increases while SPF calculations are triggered in succession.", ospfLabels),
+		"ospfSPFScheduled":        colPromDesc(ospfSubsystem, "spf_scheduled", "Whether an SPF calculation is scheduled to run (1 = scheduled, 0 = not scheduled).", ospfLabels),
+
+		"ospfABR":          colPromDesc(ospfSubsystem, "abr", "Whether the router is an area border router (1 = ABR, 0 = not ABR).", ospfLabels),
+		"ospfASBR":         colPromDesc(ospfSubsystem, "asbr", "Whether the router is an autonomous system boundary router, i.e. redistributes external routes (1 = ASBR, 0 = not ASBR).", ospfLabels),
+		"ospfExternalLSAs": colPromDesc(ospfSubsystem, "external_lsas_count_total", "Number of AS external (type 5) LSAs in the OSPF database.", ospfLabels),
+
+		"ospfGRHelperEnabled":     colPromDesc(ospfSubsystem, "gr_helper_enabled", "Whether graceful restart helper support is enabled (1 = enabled, 0 = disabled).", ospfLabels),
+		"ospfGRHelperGracePeriod": colPromDesc(ospfSubsystem, "gr_helper_supported_grace_period_seconds", "Maximum grace period supported when helping a neighbor through a graceful restart.", ospfLabels),
+		"ospfGRHelperRestarters":  colPromDesc(ospfSubsystem, "gr_helper_active_restarters_count_total", "Number of neighbors currently being helped through a graceful restart.", ospfLabels),
+
+		"ospfNeighState":        colPromDesc(ospfSubsystem, "neighbor_state_info", "State of the neighbor, such as Full or 2-Way. Value is always 1.", append(ospfNeighborLabels, "state", "role")),
+		"ospfNeighStateChanges": colPromDesc(ospfSubsystem, "neighbor_state_changes_total", "Number of state changes of the neighbor.", ospfNeighborLabels),
+		"ospfNeighDeadTimer":    colPromDesc(ospfSubsystem, "neighbor_dead_timer_remaining_seconds", "Time remaining until the neighbor is declared dead if no hello is received.", ospfNeighborLabels),
+		"ospfNeighRetransmit":   colPromDesc(ospfSubsystem, "neighbor_retransmission_queue_length", "Number of LSAs waiting to be acknowledged by the neighbor.", ospfNeighborLabels),
 	}
 	ospfErrors      = []error{}
 	totalOSPFErrors = 0.0
+
+	// The output of 'show ip ospf interface traffic json' does not include the area of the interface.
+	ospfIfaceTrafficLabels = []string{"vrf", "instance", "iface"}
+	// ospfPacketTypes maps the prefix of the keys in 'show ip ospf interface traffic json' to the type label.
+	ospfPacketTypes = map[string]string{
+		"hello":  "hello",
+		"dbDesc": "db_desc",
+		"lsReq":  "ls_request",
+		"lsUpd":  "ls_update",
+		"lsAck":  "ls_ack",
+	}
+
+	// FRR does not count DR changes, so the DR of each interface is tracked between scrapes.
+	ospfLastDR    = map[string]string{}
+	ospfDRChanges = map[string]float64{}
+	ospfDRMu      sync.Mutex
 )
 
 // OSPFCollector collects OSPF metrics, implemented as per prometheus.Collector interface.
@@ -54,14 +113,64 @@ func (*OSPFCollector) Describe(ch chan<- *prometheus.Desc) {
 
 // Collect implemented as per the prometheus.Collector interface.
 func (c *OSPFCollector) Collect(ch chan<- prometheus.Metric) {
-	jsonOSPFInterface, err := getOSPFInterface()
-	if err != nil {
-		totalOSPFErrors++
-		ospfErrors = append(ospfErrors, fmt.Errorf("cannot get ospf interface summary: %s", err))
-	} else {
-		if err = processOSPFInterface(ch, jsonOSPFInterface); err != nil {
+	ospfErrors = []error{}
+
+	for _, instance := range getOSPFInstances() {
+		jsonOSPFInterface, err := getOSPFInterface(instance)
+		if err != nil {
+			totalOSPFErrors++
+			ospfErrors = append(ospfErrors, fmt.Errorf("cannot get ospf interface summary: %s", err))
+		} else {
+			if err = processOSPFInterface(ch, jsonOSPFInterface, instance); err != nil {
+				totalOSPFErrors++
+				ospfErrors = append(ospfErrors, fmt.Errorf("%s", err))
+			}
+		}
+
+		jsonOSPFTraffic, err := execOSPFCommand(instance, "interface traffic json")
+		if err != nil {
+			totalOSPFErrors++
+			ospfErrors = append(ospfErrors, fmt.Errorf("cannot get ospf interface traffic: %s", err))
+		} else {
+			if err = processOSPFInterfaceTraffic(ch, jsonOSPFTraffic, instance); err != nil {
+				totalOSPFErrors++
+				ospfErrors = append(ospfErrors, err)
+			}
+		}
+
+		jsonOSPF, err := execOSPFCommand(instance, "json")
+		if err != nil {
+			totalOSPFErrors++
+			ospfErrors = append(ospfErrors, fmt.Errorf("cannot get ospf summary: %s", err))
+		} else {
+			if err = processOSPF(ch, jsonOSPF, instance); err != nil {
+				totalOSPFErrors++
+				ospfErrors = append(ospfErrors, err)
+			}
+		}
+
+		jsonOSPFNeighbor, err := execOSPFCommand(instance, "neighbor detail json")
+		if err != nil {
 			totalOSPFErrors++
-			ospfErrors = append(ospfErrors, fmt.Errorf("%s", err))
+			ospfErrors = append(ospfErrors, fmt.Errorf("cannot get ospf neighbors: %s", err))
+		} else {
+			if err = processOSPFNeighbor(ch, jsonOSPFNeighbor, instance); err != nil {
+				totalOSPFErrors++
+				ospfErrors = append(ospfErrors, err)
+			}
+		}
+
+		if *ospfGRHelper {
+			jsonOSPFGRHelper, err := execOSPFCommand(instance, "graceful-restart helper json")
+			if err != nil {
+				totalOSPFErrors++
+				ospfErrors = append(ospfErrors, fmt.Errorf("cannot get ospf graceful restart helper: %s", err))
+			} else {
+				if err = processOSPFGRHelper(ch, jsonOSPFGRHelper, instance); err != nil {
+					totalOSPFErrors++
+					ospfErrors = append(ospfErrors, err)
+				}
+			}
 		}
 	}
 }
@@ -76,19 +185,34 @@ func (*OSPFCollector) CollectTotalErrors() float64 {
 	return totalOSPFErrors
 }
 
-func getOSPFInterface() ([]byte, error) {
-	args := []string{"-c", "show ip ospf vrf all interface json"}
-	ctx, cancel := context.WithTimeout(context.Background(), vtyshTimeout)
-	defer cancel()
+// getOSPFInstances returns the instances configured with the --collector.ospf.instances flag, or a single empty
+// instance if OSPF is not deployed as multi-instance.
+func getOSPFInstances() []string {
+	if len(*ospfInstances) == 0 {
+		return []string{""}
+	}
+	return *ospfInstances
+}
 
-	output, err := exec.CommandContext(ctx, vtyshPath, args...).Output()
+// execOSPFCommand runs 'show ip ospf vrf all <command>', or 'show ip ospf <instance> <command>' for an instance of a
+// multi-instance OSPF deployment. Multi-instance OSPF only supports the default VRF, so the output of an instance is
+// keyed by the default VRF to match the output of 'show ip ospf vrf all'.
+func execOSPFCommand(instance string, command string) ([]byte, error) {
+	if instance == "" {
+		return execVtyshCommand("-c", fmt.Sprintf("show ip ospf vrf all %s", command))
+	}
+	output, err := execVtyshCommand("-c", fmt.Sprintf("show ip ospf %s %s", instance, command))
 	if err != nil {
 		return nil, err
 	}
-	return output, nil
+	return []byte(fmt.Sprintf(`{"default":%s}`, output)), nil
 }
 
-func processOSPFInterface(ch chan<- prometheus.Metric, jsonOSPFInterface []byte) error {
+func getOSPFInterface(instance string) ([]byte, error) {
+	return execOSPFCommand(instance, "interface json")
+}
+
+func processOSPFInterface(ch chan<- prometheus.Metric, jsonOSPFInterface []byte, instance string) error {
 	// Unfortunately, the 'show ip ospf vrf all interface json' JSON  output is poorly structured. Instead
 	// of all interfaces being in a list, each interface is added as a key on the same level of vrfName and
 	// vrfId. As such, we have to loop through each key and apply logic to determine whether the key is an
@@ -98,6 +222,7 @@ func processOSPFInterface(ch chan<- prometheus.Metric, jsonOSPFInterface []byte)
 		return fmt.Errorf("cannot unmarshal ospf interface json: %s", err)
 	}
 
+	seen := map[string]bool{}
 	for vrfName, vrfData := range jsonMap {
 		var _tempvrfInstance map[string]json.RawMessage
 		if err := json.Unmarshal(vrfData, &_tempvrfInstance); err != nil {
@@ -106,7 +231,7 @@ func processOSPFInterface(ch chan<- prometheus.Metric, jsonOSPFInterface []byte)
 
 		for ospfInstanceKey, ospfInstanceVal := range _tempvrfInstance {
 			switch ospfInstanceKey {
-			case "vrfName", "vrfId":
+			case "vrfName", "vrfId", "ospfInstance":
 				// Do nothing as we do not need the value of these keys.
 			case "interfaces":
 				var _tempInterfaceInstance map[string]json.RawMessage
@@ -118,10 +243,10 @@ func processOSPFInterface(ch chan<- prometheus.Metric, jsonOSPFInterface []byte)
 					if err := json.Unmarshal(interfaceValue, &newIface); err != nil {
 						return fmt.Errorf("cannot unmarshal interface json: %s", err)
 					}
-					// The labels are "vrf", "newIface", "area"
-					labels := []string{strings.ToLower(vrfName), interfaceKey, newIface.Area}
-					newGauge(ch, ospfDesc["ospfIfaceNeigh"], newIface.NbrCount, labels...)
-					newGauge(ch, ospfDesc["ospfIfaceNeighAdj"], newIface.NbrAdjacentCount, labels...)
+					// The labels are "vrf", "instance", "newIface", "area"
+					labels := []string{strings.ToLower(vrfName), instance, interfaceKey, newIface.Area}
+					seen[strings.Join(labels, "|")] = true
+					processOSPFIface(ch, newIface, labels...)
 				}
 			default:
 				// All other keys are interfaces.
@@ -129,18 +254,303 @@ func processOSPFInterface(ch chan<- prometheus.Metric, jsonOSPFInterface []byte)
 				if err := json.Unmarshal(ospfInstanceVal, &iface); err != nil {
 					return fmt.Errorf("cannot unmarshal interface json: %s", err)
 				}
-				// The labels are "vrf", "iface", "area"
-				labels := []string{strings.ToLower(vrfName), ospfInstanceKey, iface.Area}
-				newGauge(ch, ospfDesc["ospfIfaceNeigh"], iface.NbrCount, labels...)
-				newGauge(ch, ospfDesc["ospfIfaceNeighAdj"], iface.NbrAdjacentCount, labels...)
+				// The labels are "vrf", "instance", "iface", "area"
+				labels := []string{strings.ToLower(vrfName), instance, ospfInstanceKey, iface.Area}
+				seen[strings.Join(labels, "|")] = true
+				processOSPFIface(ch, iface, labels...)
 			}
 		}
 	}
+	ospfDRPrune(instance, seen)
 	return nil
 }
 
+func processOSPFIface(ch chan<- prometheus.Metric, iface ospfIface, labels ...string) {
+	newGauge(ch, ospfDesc["ospfIfaceNeigh"], iface.NbrCount, labels...)
+	newGauge(ch, ospfDesc["ospfIfaceNeighAdj"], iface.NbrAdjacentCount, labels...)
+	newGauge(ch, ospfDesc["ospfIfaceCost"], iface.Cost, labels...)
+	newGauge(ch, ospfDesc["ospfIfaceState"], 1, append(labels, iface.State)...)
+	// timerMsecs is the hello interval, whereas timerHelloInMsecs is the time until the next hello is sent.
+	newGauge(ch, ospfDesc["ospfIfaceHello"], iface.TimerMsecs*0.001, labels...)
+	// Earlier versions of FRR use the timerDeadMsecs and timerRetransmit keys, although the values are in seconds.
+	deadInterval, retransmitInterval := iface.TimerDeadMsecs, iface.TimerRetransmit
+	if iface.TimerDeadSecs != nil {
+		deadInterval = *iface.TimerDeadSecs
+	}
+	if iface.TimerRetransmitSecs != nil {
+		retransmitInterval = *iface.TimerRetransmitSecs
+	}
+	newGauge(ch, ospfDesc["ospfIfaceDead"], deadInterval, labels...)
+	newGauge(ch, ospfDesc["ospfIfaceRetransmit"], retransmitInterval, labels...)
+	passive := 0.0
+	if iface.TimerPassiveIface {
+		passive = 1
+	}
+	newGauge(ch, ospfDesc["ospfIfacePassive"], passive, labels...)
+
+	// Virtual links are exposed as interfaces named VLINKx, with the router ID of the other end of the virtual link
+	// in vlinkPeer.
+	if iface.NetworkType == "VIRTUALLINK" {
+		up := 0.0
+		if iface.NbrAdjacentCount > 0 {
+			up = 1
+		}
+		newGauge(ch, ospfDesc["ospfVirtualLinkUp"], up, append(labels, iface.VlinkPeer)...)
+	}
+
+	if iface.NetworkType == "BROADCAST" || iface.NetworkType == "NBMA" {
+		// drId and bdrId are not included until a DR and BDR have been elected.
+		newGauge(ch, ospfDesc["ospfIfaceDR"], 1, append(labels, iface.DrID, iface.BdrID)...)
+		newCounter(ch, ospfDesc["ospfIfaceDRChanges"], ospfDRChange(strings.Join(labels, "|"), iface.DrID), labels...)
+	}
+}
+
+// ospfDRChange records the DR of an interface and returns the number of times it has changed.
+func ospfDRChange(key string, dr string) float64 {
+	ospfDRMu.Lock()
+	defer ospfDRMu.Unlock()
+
+	if lastDR, exist := ospfLastDR[key]; exist && lastDR != dr {
+		ospfDRChanges[key]++
+	}
+	ospfLastDR[key] = dr
+	return ospfDRChanges[key]
+}
+
+// ospfDRPrune removes the interfaces of the OSPF instance that no longer exist. The interfaces of other instances are
+// kept, as each instance is scraped separately.
+func ospfDRPrune(instance string, seen map[string]bool) {
+	ospfDRMu.Lock()
+	defer ospfDRMu.Unlock()
+
+	for key := range ospfLastDR {
+		if strings.Split(key, "|")[1] == instance && !seen[key] {
+			delete(ospfLastDR, key)
+			delete(ospfDRChanges, key)
+		}
+	}
+}
+
 type ospfIface struct {
-	NbrCount         float64
-	NbrAdjacentCount float64
-	Area             string
+	NbrCount            float64
+	NbrAdjacentCount    float64
+	Area                string
+	Cost                float64
+	State               string
+	TimerMsecs          float64
+	TimerPassiveIface   bool
+	TimerDeadSecs       *float64
+	TimerDeadMsecs      float64
+	TimerRetransmitSecs *float64
+	TimerRetransmit     float64
+	NetworkType         string
+	VlinkPeer           string
+	DrID                string `json:"drId"`
+	BdrID               string `json:"bdrId"`
+}
+
+func processOSPFInterfaceTraffic(ch chan<- prometheus.Metric, jsonOSPFTraffic []byte, instance string) error {
+	var jsonMap map[string]map[string]json.RawMessage
+	if err := json.Unmarshal(jsonOSPFTraffic, &jsonMap); err != nil {
+		return fmt.Errorf("cannot unmarshal ospf interface traffic json: %s", err)
+	}
+
+	for vrfName, vrfData := range jsonMap {
+		// Like 'show ip ospf interface json', each interface is a key on the same level as vrfName and vrfId.
+		for ifaceName, ifaceData := range vrfData {
+			switch ifaceName {
+			case "vrfName", "vrfId", "ospfInstance":
+				continue
+			}
+			var counters map[string]float64
+			if err := json.Unmarshal(ifaceData, &counters); err != nil {
+				return fmt.Errorf("cannot unmarshal ospf interface %s traffic json: %s", ifaceName, err)
+			}
+			// The labels are "vrf", "instance", "iface"
+			labels := []string{strings.ToLower(vrfName), instance, ifaceName}
+			for key, packetType := range ospfPacketTypes {
+				// The labels are "vrf", "instance", "iface", "type", "direction"
+				newCounter(ch, ospfDesc["ospfIfacePackets"], counters[key+"In"], append(labels, packetType, "in")...)
+				newCounter(ch, ospfDesc["ospfIfacePackets"], counters[key+"Out"], append(labels, packetType, "out")...)
+			}
+		}
+	}
+	return nil
+}
+
+func processOSPF(ch chan<- prometheus.Metric, jsonOSPF []byte, instance string) error {
+	var jsonMap map[string]ospfInstance
+	if err := json.Unmarshal(jsonOSPF, &jsonMap); err != nil {
+		return fmt.Errorf("cannot unmarshal ospf json: %s", err)
+	}
+
+	for vrfName, ospf := range jsonMap {
+		// The labels are "vrf", "instance"
+		labels := []string{strings.ToLower(vrfName), instance}
+		// spfLastExecutedMsecs is the time since the last SPF calculation, and is not included if SPF has not run.
+		if ospf.SpfLastExecutedMsecs != nil {
+			newGauge(ch, ospfDesc["ospfSPFLastRun"], float64(timeNow().Unix())-*ospf.SpfLastExecutedMsecs*0.001, labels...)
+		}
+		newGauge(ch, ospfDesc["ospfSPFLastDuration"], ospf.SpfLastDurationMsecs*0.001, labels...)
+		newGauge(ch, ospfDesc["ospfSPFDelay"], ospf.SpfScheduleDelayMsecs*0.001, labels...)
+		newGauge(ch, ospfDesc["ospfSPFHoldMin"], ospf.HoldtimeMinMsecs*0.001, labels...)
+		newGauge(ch, ospfDesc["ospfSPFHoldMax"], ospf.HoldtimeMaxMsecs*0.001, labels...)
+		newGauge(ch, ospfDesc["ospfSPFHoldMultiple"], ospf.HoldtimeMultplier, labels...)
+		spfScheduled := 0.0
+		if ospf.SpfTimerDueInMsecs != nil {
+			spfScheduled = 1
+		}
+		newGauge(ch, ospfDesc["ospfSPFScheduled"], spfScheduled, labels...)
+
+		// abrType and asbrRouter are only included if the router is an ABR or ASBR respectively.
+		abr, asbr := 0.0, 0.0
+		if ospf.AbrType != "" {
+			abr = 1
+		}
+		if ospf.AsbrRouter != "" {
+			asbr = 1
+		}
+		newGauge(ch, ospfDesc["ospfABR"], abr, labels...)
+		newGauge(ch, ospfDesc["ospfASBR"], asbr, labels...)
+		newGauge(ch, ospfDesc["ospfExternalLSAs"], ospf.LsaExternalCounter, labels...)
+
+		for areaID, area := range ospf.Areas {
+			// The labels are "vrf", "instance", "area"
+			areaLabels := []string{strings.ToLower(vrfName), instance, areaID}
+			newCounter(ch, ospfDesc["ospfSPFExecutions"], area.SpfExecutedCounter, areaLabels...)
+			newGauge(ch, ospfDesc["ospfAreaInfo"], 1, append(areaLabels, area.areaType())...)
+			newGauge(ch, ospfDesc["ospfAreaIfaces"], area.AreaIfTotalCounter, areaLabels...)
+			newGauge(ch, ospfDesc["ospfAreaActiveIfaces"], area.AreaIfActiveCounter, areaLabels...)
+			newGauge(ch, ospfDesc["ospfAreaFullAdjacencies"], area.NbrFullAdjacentCounter, areaLabels...)
+		}
+	}
+	return nil
+}
+
+func processOSPFNeighbor(ch chan<- prometheus.Metric, jsonOSPFNeighbor []byte, instance string) error {
+	var jsonMap map[string]map[string]json.RawMessage
+	if err := json.Unmarshal(jsonOSPFNeighbor, &jsonMap); err != nil {
+		return fmt.Errorf("cannot unmarshal ospf neighbor json: %s", err)
+	}
+
+	for vrfName, vrfData := range jsonMap {
+		// Later versions of FRR add the neighbors under the neighbors key, whereas earlier versions add each neighbor as
+		// a key on the same level as vrfName and vrfId.
+		neighbors := map[string][]ospfNeighbor{}
+		if jsonNeighbors, exist := vrfData["neighbors"]; exist {
+			if err := json.Unmarshal(jsonNeighbors, &neighbors); err != nil {
+				return fmt.Errorf("cannot unmarshal ospf neighbors json: %s", err)
+			}
+		} else {
+			for neighborKey, neighborValue := range vrfData {
+				switch neighborKey {
+				case "vrfName", "vrfId", "ospfInstance":
+					// Do nothing as we do not need the value of these keys.
+					continue
+				}
+				var neighborList []ospfNeighbor
+				if err := json.Unmarshal(neighborValue, &neighborList); err != nil {
+					return fmt.Errorf("cannot unmarshal ospf neighbor %s json: %s", neighborKey, err)
+				}
+				neighbors[neighborKey] = neighborList
+			}
+		}
+
+		for neighborID, neighborList := range neighbors {
+			// A neighbor is listed once per interface that it is adjacent on.
+			for _, neighbor := range neighborList {
+				// The interface name is the name of the interface followed by the local address, such as swp1:192.168.0.1.
+				iface := strings.SplitN(neighbor.IfaceName, ":", 2)[0]
+				// The labels are "vrf", "instance", "iface", "area", "neighbor"
+				labels := []string{strings.ToLower(vrfName), instance, iface, neighbor.AreaID, neighborID}
+
+				// The state includes the role of the neighbor in earlier versions of FRR, such as Full/DR.
+				state := strings.SplitN(neighbor.NbrState, "/", 2)
+				role := neighbor.Role
+				if role == "" && len(state) == 2 {
+					role = state[1]
+				}
+				newGauge(ch, ospfDesc["ospfNeighState"], 1, append(labels, state[0], role)...)
+				newCounter(ch, ospfDesc["ospfNeighStateChanges"], neighbor.StateChangeCounter, labels...)
+				newGauge(ch, ospfDesc["ospfNeighDeadTimer"], neighbor.RouterDeadIntervalTimerDueMsec*0.001, labels...)
+				newGauge(ch, ospfDesc["ospfNeighRetransmit"], neighbor.LinkStateRetransmissionListCounter, labels...)
+			}
+		}
+	}
+	return nil
+}
+
+type ospfInstance struct {
+	SpfScheduleDelayMsecs float64
+	HoldtimeMinMsecs      float64
+	HoldtimeMaxMsecs      float64
+	// The typo is in the FRR JSON key.
+	HoldtimeMultplier    float64
+	SpfLastExecutedMsecs *float64
+	SpfLastDurationMsecs float64
+	SpfTimerDueInMsecs   *float64
+	AbrType              string
+	AsbrRouter           string
+	LsaExternalCounter   float64
+	Areas                map[string]ospfArea
+}
+
+type ospfArea struct {
+	SpfExecutedCounter     float64
+	AreaIfTotalCounter     float64
+	AreaIfActiveCounter    float64
+	NbrFullAdjacentCounter float64
+	Stub                   bool
+	StubNoSummary          bool
+	StubShortcut           bool
+	Nssa                   bool
+}
+
+// areaType returns the type of the area. FRR only includes the stub and nssa keys for areas of that type.
+func (a ospfArea) areaType() string {
+	switch {
+	case a.Nssa:
+		return "nssa"
+	case a.Stub, a.StubNoSummary, a.StubShortcut:
+		return "stub"
+	default:
+		return "normal"
+	}
+}
+
+func processOSPFGRHelper(ch chan<- prometheus.Metric, jsonOSPFGRHelper []byte, instance string) error {
+	var jsonMap map[string]ospfGRHelperInfo
+	if err := json.Unmarshal(jsonOSPFGRHelper, &jsonMap); err != nil {
+		return fmt.Errorf("cannot unmarshal ospf graceful restart helper json: %s", err)
+	}
+
+	for vrfName, helper := range jsonMap {
+		// The labels are "vrf", "instance"
+		labels := []string{strings.ToLower(vrfName), instance}
+		enabled := 0.0
+		if strings.ToLower(helper.HelperSupport) == "enabled" {
+			enabled = 1
+		}
+		newGauge(ch, ospfDesc["ospfGRHelperEnabled"], enabled, labels...)
+		newGauge(ch, ospfDesc["ospfGRHelperGracePeriod"], helper.SupportedGracePeriod, labels...)
+		// activeRestarterCnt is not included if no neighbors are restarting.
+		newGauge(ch, ospfDesc["ospfGRHelperRestarters"], helper.ActiveRestarterCnt, labels...)
+	}
+	return nil
+}
+
+type ospfGRHelperInfo struct {
+	HelperSupport        string
+	SupportedGracePeriod float64
+	ActiveRestarterCnt   float64
+}
+
+type ospfNeighbor struct {
+	AreaID                             string `json:"areaId"`
+	IfaceName                          string
+	NbrState                           string
+	Role                               string
+	StateChangeCounter                 float64
+	RouterDeadIntervalTimerDueMsec     float64
+	LinkStateRetransmissionListCounter float64
 }
@@ -0,0 +1,176 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	ospf6Subsystem = "ospf6"
+
+	ospf6IfaceLabels    = []string{"vrf", "iface", "area"}
+	ospf6NeighborLabels = append(ospf6IfaceLabels, "neighbor")
+	ospf6Desc           = map[string]*prometheus.Desc{
+		"ospf6IfaceNeigh":    colPromDesc(ospf6Subsystem, "neighbors", "Number of neighbors detected.", ospf6IfaceLabels),
+		"ospf6IfaceNeighAdj": colPromDesc(ospf6Subsystem, "neighbor_adjacencies", "Number of neighbor adjacencies formed.", ospf6IfaceLabels),
+		"ospf6IfaceCost":     colPromDesc(ospf6Subsystem, "interface_cost", "OSPFv3 cost of the interface.", ospf6IfaceLabels),
+		"ospf6IfaceState":    colPromDesc(ospf6Subsystem, "interface_state_info", "State of the interface, such as DR, BDR or DROther. Value is always 1.", append(ospf6IfaceLabels, "state")),
+		"ospf6IfaceHello":    colPromDesc(ospf6Subsystem, "interface_hello_interval_seconds", "Hello interval of the interface.", ospf6IfaceLabels),
+
+		"ospf6NeighState":     colPromDesc(ospf6Subsystem, "neighbor_state_info", "State of the neighbor, such as Full or TwoWay. Value is always 1.", append(ospf6NeighborLabels, "state", "role")),
+		"ospf6NeighDeadTimer": colPromDesc(ospf6Subsystem, "neighbor_dead_timer_remaining_seconds", "Time remaining until the neighbor is declared dead if no hello is received.", ospf6NeighborLabels),
+		"ospf6NeighUptime":    colPromDesc(ospf6Subsystem, "neighbor_uptime_seconds", "How long the neighbor has been in its current state.", ospf6NeighborLabels),
+	}
+	ospf6Errors      = []error{}
+	totalOSPF6Errors = 0.0
+)
+
+// OSPF6Collector collects OSPFv3 metrics, implemented as per prometheus.Collector interface.
+type OSPF6Collector struct{}
+
+// NewOSPF6Collector returns a OSPF6Collector struct.
+func NewOSPF6Collector() *OSPF6Collector {
+	return &OSPF6Collector{}
+}
+
+// Name of the collector. Used to populate flag name.
+func (*OSPF6Collector) Name() string {
+	return ospf6Subsystem
+}
+
+// Help describes the metrics this collector scrapes. Used to populate flag help.
+func (*OSPF6Collector) Help() string {
+	return "Collect OSPFv3 Metrics"
+}
+
+// EnabledByDefault describes whether this collector is enabled by default. Used to populate flag default.
+func (*OSPF6Collector) EnabledByDefault() bool {
+	return false
+}
+
+// Describe implemented as per the prometheus.Collector interface.
+func (*OSPF6Collector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range ospf6Desc {
+		ch <- desc
+	}
+}
+
+// Collect implemented as per the prometheus.Collector interface.
+func (c *OSPF6Collector) Collect(ch chan<- prometheus.Metric) {
+	ospf6Errors = []error{}
+
+	// ospf6d prints a JSON object without the name of the VRF for each VRF of 'show ipv6 ospf6 vrf all ... json', so
+	// the interfaces and neighbors are collected per VRF.
+	vrfs := []string{"default"}
+	jsonVRF, err := execVtyshCommand("-c", "show vrf json")
+	if err != nil {
+		ospf6Errors = append(ospf6Errors, fmt.Errorf("cannot get vrfs: %s", err))
+	} else {
+		if vrfNames, err := processVRFNames(jsonVRF); err != nil {
+			ospf6Errors = append(ospf6Errors, err)
+		} else {
+			vrfs = append(vrfs, vrfNames...)
+		}
+	}
+
+	for _, vrf := range vrfs {
+		jsonOSPF6Interface, err := execVtyshCommand("-c", fmt.Sprintf("show ipv6 ospf6 vrf %s interface json", vrf))
+		if err != nil {
+			ospf6Errors = append(ospf6Errors, fmt.Errorf("cannot get ospf6 interfaces of vrf %s: %s", vrf, err))
+			continue
+		}
+		jsonOSPF6Neighbor, err := execVtyshCommand("-c", fmt.Sprintf("show ipv6 ospf6 vrf %s neighbor json", vrf))
+		if err != nil {
+			ospf6Errors = append(ospf6Errors, fmt.Errorf("cannot get ospf6 neighbors of vrf %s: %s", vrf, err))
+			continue
+		}
+		if err := processOSPF6(ch, jsonOSPF6Interface, jsonOSPF6Neighbor, vrf); err != nil {
+			ospf6Errors = append(ospf6Errors, err)
+		}
+	}
+
+	totalOSPF6Errors += float64(len(ospf6Errors))
+}
+
+// CollectErrors returns what errors have been gathered.
+func (*OSPF6Collector) CollectErrors() []error {
+	return ospf6Errors
+}
+
+// CollectTotalErrors returns total errors.
+func (*OSPF6Collector) CollectTotalErrors() float64 {
+	return totalOSPF6Errors
+}
+
+func processOSPF6(ch chan<- prometheus.Metric, jsonOSPF6Interface []byte, jsonOSPF6Neighbor []byte, vrfName string) error {
+	// The interfaces are keyed by the interface name. VRFs without an OSPFv3 instance return an empty object.
+	var interfaces map[string]ospf6Iface
+	if err := json.Unmarshal(jsonOSPF6Interface, &interfaces); err != nil {
+		return fmt.Errorf("cannot unmarshal ospf6 interface json: %s", err)
+	}
+	var neighbors struct {
+		Neighbors []ospf6Neighbor
+	}
+	if err := json.Unmarshal(jsonOSPF6Neighbor, &neighbors); err != nil {
+		return fmt.Errorf("cannot unmarshal ospf6 neighbor json: %s", err)
+	}
+
+	vrfName = strings.ToLower(vrfName)
+	// Unlike OSPFv2, the neighbor counts are not included in the interface JSON, so they are derived from the
+	// neighbors on each interface.
+	ifaceNeighbors, adjacencies := map[string]float64{}, map[string]float64{}
+	for _, neighbor := range neighbors.Neighbors {
+		iface, exist := interfaces[neighbor.InterfaceName]
+		if !exist {
+			continue
+		}
+		ifaceNeighbors[neighbor.InterfaceName]++
+		if strings.ToLower(neighbor.State) == "full" {
+			adjacencies[neighbor.InterfaceName]++
+		}
+
+		// The labels are "vrf", "iface", "area", "neighbor"
+		labels := []string{vrfName, neighbor.InterfaceName, iface.AreaID, neighbor.NeighborID}
+		newGauge(ch, ospf6Desc["ospf6NeighState"], 1, append(labels, neighbor.State, neighbor.IfState)...)
+		if deadTime, err := parseDuration(neighbor.DeadTime); err == nil {
+			newGauge(ch, ospf6Desc["ospf6NeighDeadTimer"], deadTime, labels...)
+		}
+		if uptime, err := parseDuration(neighbor.Duration); err == nil {
+			newGauge(ch, ospf6Desc["ospf6NeighUptime"], uptime, labels...)
+		}
+	}
+
+	for ifaceName, iface := range interfaces {
+		if !iface.AttachedToArea {
+			continue
+		}
+		// The labels are "vrf", "iface", "area"
+		labels := []string{vrfName, ifaceName, iface.AreaID}
+		newGauge(ch, ospf6Desc["ospf6IfaceNeigh"], ifaceNeighbors[ifaceName], labels...)
+		newGauge(ch, ospf6Desc["ospf6IfaceNeighAdj"], adjacencies[ifaceName], labels...)
+		newGauge(ch, ospf6Desc["ospf6IfaceCost"], iface.Cost, labels...)
+		newGauge(ch, ospf6Desc["ospf6IfaceState"], 1, append(labels, iface.State)...)
+		newGauge(ch, ospf6Desc["ospf6IfaceHello"], iface.TimerIntervalsConfigHello, labels...)
+	}
+	return nil
+}
+
+type ospf6Iface struct {
+	AttachedToArea            bool
+	AreaID                    string `json:"areaId"`
+	Cost                      float64
+	State                     string
+	TimerIntervalsConfigHello float64
+}
+
+type ospf6Neighbor struct {
+	NeighborID    string `json:"neighborId"`
+	State         string
+	IfState       string
+	DeadTime      string
+	Duration      string
+	InterfaceName string
+}
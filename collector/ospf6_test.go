@@ -0,0 +1,118 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	ospf6InterfaceJSON = []byte(`{
+  "swp1":{
+    "status":"up",
+    "type":"BROADCAST",
+    "interfaceId":4,
+    "ipv6LinkLocalAddress":"fe80::1",
+    "attachedToArea":true,
+    "instanceId":0,
+    "interfaceMtu":1500,
+    "autoDetect":1500,
+    "mtuMismatchDetection":"enabled",
+    "areaId":"0.0.0.0",
+    "cost":10,
+    "transmitDelaySec":1,
+    "priority":1,
+    "timerIntervalsConfigHello":10,
+    "timerIntervalsConfigDead":40,
+    "timerIntervalsConfigRetransmit":5,
+    "dr":"192.168.255.1",
+    "bdr":"192.168.255.2",
+    "state":"DR"
+  },
+  "swp2":{
+    "status":"up",
+    "type":"POINTOPOINT",
+    "attachedToArea":true,
+    "areaId":"0.0.0.1",
+    "cost":100,
+    "timerIntervalsConfigHello":1,
+    "timerIntervalsConfigDead":3,
+    "state":"PointToPoint"
+  },
+  "lo":{
+    "status":"up",
+    "type":"LOOPBACK",
+    "attachedToArea":false
+  }
+}`)
+
+	ospf6NeighborJSON = []byte(`{
+  "neighbors":[
+    {"neighborId":"192.168.255.2","priority":1,"deadTime":"00:00:35","state":"Full","ifState":"BDR","duration":"1d02:03:04","interfaceName":"swp1","interfaceState":"DR"},
+    {"neighborId":"192.168.255.3","priority":1,"deadTime":"00:00:31","state":"TwoWay","ifState":"DROther","duration":"00:10:00","interfaceName":"swp1","interfaceState":"DR"},
+    {"neighborId":"192.168.255.4","priority":1,"deadTime":"00:00:02","state":"Full","ifState":"PointToPoint","duration":"00:00:10","interfaceName":"swp2","interfaceState":"PointToPoint"}
+  ]
+}`)
+
+	ospf6RedInterfaceJSON = []byte(`{
+  "swp3":{
+    "status":"up",
+    "type":"BROADCAST",
+    "attachedToArea":true,
+    "areaId":"0.0.0.0",
+    "cost":10,
+    "timerIntervalsConfigHello":10,
+    "state":"Waiting"
+  }
+}`)
+
+	ospf6RedNeighborJSON = []byte(`{
+  "neighbors":[]
+}`)
+
+	expectedOSPF6Metrics = map[string]float64{
+		"frr_ospf6_neighbors{area=0.0.0.0,iface=swp1,vrf=default}":                               2,
+		"frr_ospf6_neighbors{area=0.0.0.1,iface=swp2,vrf=default}":                               1,
+		"frr_ospf6_neighbors{area=0.0.0.0,iface=swp3,vrf=red}":                                   0,
+		"frr_ospf6_neighbor_adjacencies{area=0.0.0.0,iface=swp1,vrf=default}":                    1,
+		"frr_ospf6_neighbor_adjacencies{area=0.0.0.1,iface=swp2,vrf=default}":                    1,
+		"frr_ospf6_neighbor_adjacencies{area=0.0.0.0,iface=swp3,vrf=red}":                        0,
+		"frr_ospf6_interface_cost{area=0.0.0.0,iface=swp1,vrf=default}":                          10,
+		"frr_ospf6_interface_cost{area=0.0.0.1,iface=swp2,vrf=default}":                          100,
+		"frr_ospf6_interface_cost{area=0.0.0.0,iface=swp3,vrf=red}":                              10,
+		"frr_ospf6_interface_state_info{area=0.0.0.0,iface=swp1,state=DR,vrf=default}":           1,
+		"frr_ospf6_interface_state_info{area=0.0.0.1,iface=swp2,state=PointToPoint,vrf=default}": 1,
+		"frr_ospf6_interface_state_info{area=0.0.0.0,iface=swp3,state=Waiting,vrf=red}":          1,
+		"frr_ospf6_interface_hello_interval_seconds{area=0.0.0.0,iface=swp1,vrf=default}":        10,
+		"frr_ospf6_interface_hello_interval_seconds{area=0.0.0.1,iface=swp2,vrf=default}":        1,
+		"frr_ospf6_interface_hello_interval_seconds{area=0.0.0.0,iface=swp3,vrf=red}":            10,
+
+		"frr_ospf6_neighbor_state_info{area=0.0.0.0,iface=swp1,neighbor=192.168.255.2,role=BDR,state=Full,vrf=default}":          1,
+		"frr_ospf6_neighbor_state_info{area=0.0.0.0,iface=swp1,neighbor=192.168.255.3,role=DROther,state=TwoWay,vrf=default}":    1,
+		"frr_ospf6_neighbor_state_info{area=0.0.0.1,iface=swp2,neighbor=192.168.255.4,role=PointToPoint,state=Full,vrf=default}": 1,
+		"frr_ospf6_neighbor_dead_timer_remaining_seconds{area=0.0.0.0,iface=swp1,neighbor=192.168.255.2,vrf=default}":            35,
+		"frr_ospf6_neighbor_dead_timer_remaining_seconds{area=0.0.0.0,iface=swp1,neighbor=192.168.255.3,vrf=default}":            31,
+		"frr_ospf6_neighbor_dead_timer_remaining_seconds{area=0.0.0.1,iface=swp2,neighbor=192.168.255.4,vrf=default}":            2,
+		"frr_ospf6_neighbor_uptime_seconds{area=0.0.0.0,iface=swp1,neighbor=192.168.255.2,vrf=default}":                          93784,
+		"frr_ospf6_neighbor_uptime_seconds{area=0.0.0.0,iface=swp1,neighbor=192.168.255.3,vrf=default}":                          600,
+		"frr_ospf6_neighbor_uptime_seconds{area=0.0.0.1,iface=swp2,neighbor=192.168.255.4,vrf=default}":                          10,
+	}
+)
+
+func TestProcessOSPF6(t *testing.T) {
+	ch := make(chan prometheus.Metric, 1024)
+	if err := processOSPF6(ch, ospf6InterfaceJSON, ospf6NeighborJSON, "default"); err != nil {
+		t.Errorf("error calling processOSPF6 default: %s", err)
+	}
+	if err := processOSPF6(ch, ospf6RedInterfaceJSON, ospf6RedNeighborJSON, "Red"); err != nil {
+		t.Errorf("error calling processOSPF6 red: %s", err)
+	}
+	// VRFs without an OSPFv3 instance return empty objects.
+	if err := processOSPF6(ch, []byte(`{}`), []byte(`{}`), "blue"); err != nil {
+		t.Errorf("error calling processOSPF6 blue: %s", err)
+	}
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, expectedOSPF6Metrics)
+}
@@ -0,0 +1,206 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	ospfDatabaseMetricPrefix = "ospf_database"
+
+	ospfDatabaseLabels = []string{"vrf", "instance", "area"}
+	ospfDatabaseDesc   = map[string]*prometheus.Desc{
+		"lsas":           colPromDesc(ospfDatabaseMetricPrefix, "lsas_count_total", "Number of LSAs in the OSPF database per LSA type. AS scoped LSAs have an empty area label.", append(ospfDatabaseLabels, "type")),
+		"selfOriginated": colPromDesc(ospfDatabaseMetricPrefix, "self_originated_lsas_count_total", "Number of LSAs in the OSPF database originated by the router per LSA type. AS scoped LSAs have an empty area label.", append(ospfDatabaseLabels, "type")),
+		"maxAgeLSAs":     colPromDesc(ospfDatabaseMetricPrefix, "maxage_lsas_count_total", "Number of LSAs that have reached MaxAge and are pending to be flushed from the OSPF database.", ospfLabels),
+		"checksumSum":    colPromDesc(ospfDatabaseMetricPrefix, "checksum_sum", "Sum of the checksums of the LSAs in the OSPF database, which should be identical on all routers of the area.", ospfDatabaseLabels),
+	}
+	ospfDatabaseErrors      = []error{}
+	totalOSPFDatabaseErrors = 0.0
+
+	// ospfLSATypes maps the keys of the LSA types in 'show ip ospf database json' to the type label.
+	ospfLSATypes = map[string]string{
+		"routerLinkStates":       "router",
+		"networkLinkStates":      "network",
+		"summaryLinkStates":      "summary",
+		"asbrSummaryLinkStates":  "asbr-summary",
+		"asExternalLinkStates":   "as-external",
+		"nssaExternalLinkStates": "nssa-external",
+		"linkLocalOpaqueLsa":     "opaque-link",
+		"areaLocalOpaqueLsa":     "opaque-area",
+		"asExternalOpaqueLsa":    "opaque-as",
+	}
+)
+
+// OSPFDatabaseCollector collects OSPF LSA database metrics, implemented as per prometheus.Collector interface.
+type OSPFDatabaseCollector struct{}
+
+// NewOSPFDatabaseCollector returns a OSPFDatabaseCollector struct.
+func NewOSPFDatabaseCollector() *OSPFDatabaseCollector {
+	return &OSPFDatabaseCollector{}
+}
+
+// Name of the collector. Used to populate flag name.
+func (*OSPFDatabaseCollector) Name() string {
+	return ospfSubsystem + "database"
+}
+
+// Help describes the metrics this collector scrapes. Used to populate flag help.
+func (*OSPFDatabaseCollector) Help() string {
+	return "Collect OSPF LSA Database Metrics"
+}
+
+// EnabledByDefault describes whether this collector is enabled by default. Used to populate flag default.
+func (*OSPFDatabaseCollector) EnabledByDefault() bool {
+	return false
+}
+
+// Describe implemented as per the prometheus.Collector interface.
+func (*OSPFDatabaseCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range ospfDatabaseDesc {
+		ch <- desc
+	}
+}
+
+// Collect implemented as per the prometheus.Collector interface.
+func (c *OSPFDatabaseCollector) Collect(ch chan<- prometheus.Metric) {
+	ospfDatabaseErrors = []error{}
+
+	for _, instance := range getOSPFInstances() {
+		jsonOSPFDatabase, err := execOSPFCommand(instance, "database json")
+		if err != nil {
+			ospfDatabaseErrors = append(ospfDatabaseErrors, fmt.Errorf("cannot get ospf database: %s", err))
+		} else {
+			if err := processOSPFDatabase(ch, jsonOSPFDatabase, instance); err != nil {
+				ospfDatabaseErrors = append(ospfDatabaseErrors, err)
+			}
+		}
+
+		jsonOSPFMaxAge, err := execOSPFCommand(instance, "database max-age json")
+		if err != nil {
+			ospfDatabaseErrors = append(ospfDatabaseErrors, fmt.Errorf("cannot get ospf database max-age: %s", err))
+		} else {
+			if err := processOSPFDatabaseMaxAge(ch, jsonOSPFMaxAge, instance); err != nil {
+				ospfDatabaseErrors = append(ospfDatabaseErrors, err)
+			}
+		}
+	}
+
+	totalOSPFDatabaseErrors += float64(len(ospfDatabaseErrors))
+}
+
+// CollectErrors returns what errors have been gathered.
+func (*OSPFDatabaseCollector) CollectErrors() []error {
+	return ospfDatabaseErrors
+}
+
+// CollectTotalErrors returns total errors.
+func (*OSPFDatabaseCollector) CollectTotalErrors() float64 {
+	return totalOSPFDatabaseErrors
+}
+
+func processOSPFDatabase(ch chan<- prometheus.Metric, jsonOSPFDatabase []byte, instance string) error {
+	var jsonMap map[string]map[string]json.RawMessage
+	if err := json.Unmarshal(jsonOSPFDatabase, &jsonMap); err != nil {
+		return fmt.Errorf("cannot unmarshal ospf database json: %s", err)
+	}
+
+	// LSAs that cannot be processed are skipped, so the rest of the database is still exported.
+	lsaErrors := []string{}
+	for vrfName, vrfData := range jsonMap {
+		vrfName = strings.ToLower(vrfName)
+		var routerID string
+		if jsonRouterID, exist := vrfData["routerId"]; exist {
+			if err := json.Unmarshal(jsonRouterID, &routerID); err != nil {
+				return fmt.Errorf("cannot unmarshal ospf database router id json: %s", err)
+			}
+		}
+		if jsonAreas, exist := vrfData["areas"]; exist {
+			var areas map[string]map[string][]ospfLSA
+			if err := json.Unmarshal(jsonAreas, &areas); err != nil {
+				return fmt.Errorf("cannot unmarshal ospf database areas json: %s", err)
+			}
+			for areaID, lsaTypes := range areas {
+				lsaErrors = append(lsaErrors, processOSPFDatabaseLSAs(ch, lsaTypes, routerID, vrfName, instance, areaID)...)
+			}
+		}
+
+		// AS scoped LSAs, such as type 5 LSAs, are on the same level as the areas.
+		asLSATypes := map[string][]ospfLSA{}
+		for key, value := range vrfData {
+			if _, exist := ospfLSATypes[key]; !exist {
+				continue
+			}
+			var lsas []ospfLSA
+			if err := json.Unmarshal(value, &lsas); err != nil {
+				return fmt.Errorf("cannot unmarshal ospf database %s json: %s", key, err)
+			}
+			asLSATypes[key] = lsas
+		}
+		if len(asLSATypes) > 0 {
+			lsaErrors = append(lsaErrors, processOSPFDatabaseLSAs(ch, asLSATypes, routerID, vrfName, instance, "")...)
+		}
+	}
+	if len(lsaErrors) > 0 {
+		return fmt.Errorf("cannot process %d ospf lsas: %s", len(lsaErrors), strings.Join(lsaErrors, "; "))
+	}
+	return nil
+}
+
+// processOSPFDatabaseLSAs exports the LSAs of an area, or the AS scoped LSAs if the area is empty, and returns the
+// LSAs that were skipped as their checksum could not be parsed.
+func processOSPFDatabaseLSAs(ch chan<- prometheus.Metric, lsaTypes map[string][]ospfLSA, routerID string, vrfName string, instance string, areaID string) []string {
+	lsaErrors := []string{}
+	checksumSum := 0.0
+	for lsaType, lsas := range lsaTypes {
+		if label, exist := ospfLSATypes[lsaType]; exist {
+			lsaType = label
+		}
+		// The labels are "vrf", "instance", "area", "type"
+		newGauge(ch, ospfDatabaseDesc["lsas"], float64(len(lsas)), vrfName, instance, areaID, lsaType)
+		selfOriginated := 0.0
+		for _, lsa := range lsas {
+			if lsa.AdvertisedRouter == routerID {
+				selfOriginated++
+			}
+			checksum, err := strconv.ParseUint(strings.TrimPrefix(lsa.Checksum, "0x"), 16, 16)
+			if err != nil {
+				lsaErrors = append(lsaErrors, fmt.Sprintf("cannot parse checksum of %s lsa %s advertised by %s in vrf %s: %s", lsaType, lsa.LsID, lsa.AdvertisedRouter, vrfName, err))
+				continue
+			}
+			checksumSum += float64(checksum)
+		}
+		// The labels are "vrf", "instance", "area", "type"
+		newGauge(ch, ospfDatabaseDesc["selfOriginated"], selfOriginated, vrfName, instance, areaID, lsaType)
+	}
+	// The labels are "vrf", "instance", "area"
+	newGauge(ch, ospfDatabaseDesc["checksumSum"], checksumSum, vrfName, instance, areaID)
+	return lsaErrors
+}
+
+func processOSPFDatabaseMaxAge(ch chan<- prometheus.Metric, jsonOSPFMaxAge []byte, instance string) error {
+	var jsonMap map[string]struct {
+		MaxAgeLinkStates map[string]json.RawMessage
+	}
+	if err := json.Unmarshal(jsonOSPFMaxAge, &jsonMap); err != nil {
+		return fmt.Errorf("cannot unmarshal ospf database max-age json: %s", err)
+	}
+
+	for vrfName, vrfData := range jsonMap {
+		// The labels are "vrf", "instance"
+		newGauge(ch, ospfDatabaseDesc["maxAgeLSAs"], float64(len(vrfData.MaxAgeLinkStates)), strings.ToLower(vrfName), instance)
+	}
+	return nil
+}
+
+type ospfLSA struct {
+	LsID             string `json:"lsId"`
+	AdvertisedRouter string
+	LsaAge           float64
+	SequenceNumber   string
+	Checksum         string
+}
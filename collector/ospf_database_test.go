@@ -0,0 +1,153 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	ospfDatabaseJSON = []byte(`{
+"default":{
+  "vrfName":"default",
+  "vrfId":0,
+  "routerId":"192.168.255.1",
+  "areas":{
+    "0.0.0.0":{
+      "routerLinkStates":[
+        {"lsId":"192.168.255.1","advertisedRouter":"192.168.255.1","lsaAge":120,"sequenceNumber":"80000004","checksum":"0x3f2a","numOfRouterLinks":2},
+        {"lsId":"192.168.255.2","advertisedRouter":"192.168.255.2","lsaAge":118,"sequenceNumber":"80000003","checksum":"0x0a01","numOfRouterLinks":2}
+      ],
+      "networkLinkStates":[
+        {"lsId":"192.168.2.1","advertisedRouter":"192.168.255.1","lsaAge":120,"sequenceNumber":"80000001","checksum":"0x0001"}
+      ]
+    },
+    "0.0.0.1":{
+      "routerLinkStates":[
+        {"lsId":"192.168.255.1","advertisedRouter":"192.168.255.1","lsaAge":120,"sequenceNumber":"80000002","checksum":"0x0100","numOfRouterLinks":1}
+      ],
+      "summaryLinkStates":[
+        {"lsId":"192.168.2.0","advertisedRouter":"192.168.255.1","lsaAge":120,"sequenceNumber":"80000001","checksum":"0x0010","summaryAddress":"192.168.2.0/24"}
+      ]
+    }
+  },
+  "asExternalLinkStates":[
+    {"lsId":"10.0.0.0","advertisedRouter":"192.168.255.2","lsaAge":57,"sequenceNumber":"80000001","checksum":"0x00ff","metricType":"E2","route":"10.0.0.0/24","tag":0}
+  ]
+}
+,
+"red":{
+  "vrfName":"red",
+  "vrfId":39,
+  "routerId":"192.168.255.1",
+  "areas":{
+    "0.0.0.0":{
+      "routerLinkStates":[
+        {"lsId":"192.168.255.1","advertisedRouter":"192.168.255.1","lsaAge":10,"sequenceNumber":"80000001","checksum":"0x1000","numOfRouterLinks":1}
+      ]
+    }
+  }
+}
+}`)
+
+	expectedOSPFDatabaseMetrics = map[string]float64{
+		"frr_ospf_database_lsas_count_total{area=0.0.0.0,instance=,type=router,vrf=default}":                  2,
+		"frr_ospf_database_lsas_count_total{area=0.0.0.0,instance=,type=network,vrf=default}":                 1,
+		"frr_ospf_database_lsas_count_total{area=0.0.0.1,instance=,type=router,vrf=default}":                  1,
+		"frr_ospf_database_lsas_count_total{area=0.0.0.1,instance=,type=summary,vrf=default}":                 1,
+		"frr_ospf_database_lsas_count_total{area=,instance=,type=as-external,vrf=default}":                    1,
+		"frr_ospf_database_lsas_count_total{area=0.0.0.0,instance=,type=router,vrf=red}":                      1,
+		"frr_ospf_database_self_originated_lsas_count_total{area=0.0.0.0,instance=,type=router,vrf=default}":  1,
+		"frr_ospf_database_self_originated_lsas_count_total{area=0.0.0.0,instance=,type=network,vrf=default}": 1,
+		"frr_ospf_database_self_originated_lsas_count_total{area=0.0.0.1,instance=,type=router,vrf=default}":  1,
+		"frr_ospf_database_self_originated_lsas_count_total{area=0.0.0.1,instance=,type=summary,vrf=default}": 1,
+		"frr_ospf_database_self_originated_lsas_count_total{area=,instance=,type=as-external,vrf=default}":    0,
+		"frr_ospf_database_self_originated_lsas_count_total{area=0.0.0.0,instance=,type=router,vrf=red}":      1,
+		"frr_ospf_database_checksum_sum{area=0.0.0.0,instance=,vrf=default}":                                  0x3f2a + 0x0a01 + 0x0001,
+		"frr_ospf_database_checksum_sum{area=0.0.0.1,instance=,vrf=default}":                                  0x0100 + 0x0010,
+		"frr_ospf_database_checksum_sum{area=,instance=,vrf=default}":                                         0x00ff,
+		"frr_ospf_database_checksum_sum{area=0.0.0.0,instance=,vrf=red}":                                      0x1000,
+	}
+)
+
+func TestProcessOSPFDatabase(t *testing.T) {
+	ch := make(chan prometheus.Metric, 1024)
+	if err := processOSPFDatabase(ch, ospfDatabaseJSON, ""); err != nil {
+		t.Errorf("error calling processOSPFDatabase: %s", err)
+	}
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, expectedOSPFDatabaseMetrics)
+}
+
+func TestProcessOSPFDatabaseInvalidChecksum(t *testing.T) {
+	ospfDatabaseInvalidChecksum := []byte(`{
+"default":{
+  "vrfName":"default",
+  "vrfId":0,
+  "routerId":"192.168.255.1",
+  "areas":{
+    "0.0.0.0":{
+      "routerLinkStates":[
+        {"lsId":"192.168.255.1","advertisedRouter":"192.168.255.1","lsaAge":120,"sequenceNumber":"80000004","checksum":"0x3f2a","numOfRouterLinks":2},
+        {"lsId":"192.168.255.2","advertisedRouter":"192.168.255.2","lsaAge":118,"sequenceNumber":"80000003","checksum":"invalid","numOfRouterLinks":2}
+      ]
+    },
+    "0.0.0.1":{
+      "routerLinkStates":[
+        {"lsId":"192.168.255.1","advertisedRouter":"192.168.255.1","lsaAge":120,"sequenceNumber":"80000002","checksum":"0x0100","numOfRouterLinks":1}
+      ]
+    }
+  }
+}
+}`)
+
+	ch := make(chan prometheus.Metric, 1024)
+	if err := processOSPFDatabase(ch, ospfDatabaseInvalidChecksum, ""); err == nil {
+		t.Errorf("expected error calling processOSPFDatabase with an invalid checksum")
+	}
+	close(ch)
+
+	// The LSA with the invalid checksum is skipped, but the rest of the database is still exported.
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, map[string]float64{
+		"frr_ospf_database_lsas_count_total{area=0.0.0.0,instance=,type=router,vrf=default}":                 2,
+		"frr_ospf_database_lsas_count_total{area=0.0.0.1,instance=,type=router,vrf=default}":                 1,
+		"frr_ospf_database_self_originated_lsas_count_total{area=0.0.0.0,instance=,type=router,vrf=default}": 1,
+		"frr_ospf_database_self_originated_lsas_count_total{area=0.0.0.1,instance=,type=router,vrf=default}": 1,
+		"frr_ospf_database_checksum_sum{area=0.0.0.0,instance=,vrf=default}":                                 0x3f2a,
+		"frr_ospf_database_checksum_sum{area=0.0.0.1,instance=,vrf=default}":                                 0x0100,
+	})
+}
+
+func TestProcessOSPFDatabaseMaxAge(t *testing.T) {
+	ospfDatabaseMaxAge := []byte(`{
+"default":{
+  "maxAgeLinkStates":{
+    "10.0.0.0":{
+      "lsaType":5,
+      "linkStateId":"10.0.0.0",
+      "advertisingRouter":"192.168.255.2",
+      "lsaPtr":"0x55d4a5f1c0b0",
+      "lsaLock":2
+    }
+  }
+},
+"red":{
+  "maxAgeLinkStates":{}
+}
+}`)
+
+	ch := make(chan prometheus.Metric, 1024)
+	if err := processOSPFDatabaseMaxAge(ch, ospfDatabaseMaxAge, ""); err != nil {
+		t.Errorf("error calling processOSPFDatabaseMaxAge: %s", err)
+	}
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, map[string]float64{
+		"frr_ospf_database_maxage_lsas_count_total{instance=,vrf=default}": 1,
+		"frr_ospf_database_maxage_lsas_count_total{instance=,vrf=red}":     0,
+	})
+}
@@ -0,0 +1,119 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	ospfSRMetricPrefix = "ospf_sr"
+
+	ospfSRNodeLabels = []string{"vrf", "instance", "router_id"}
+	ospfSRDesc       = map[string]*prometheus.Desc{
+		"srgbStart":     colPromDesc(ospfSRMetricPrefix, "srgb_start_label", "First label of the segment routing global block (SRGB) advertised by the node.", ospfSRNodeLabels),
+		"srgbSize":      colPromDesc(ospfSRMetricPrefix, "srgb_size", "Number of labels in the segment routing global block (SRGB) advertised by the node.", ospfSRNodeLabels),
+		"srlbStart":     colPromDesc(ospfSRMetricPrefix, "srlb_start_label", "First label of the segment routing local block (SRLB) advertised by the node.", ospfSRNodeLabels),
+		"srlbSize":      colPromDesc(ospfSRMetricPrefix, "srlb_size", "Number of labels in the segment routing local block (SRLB) advertised by the node.", ospfSRNodeLabels),
+		"prefixSIDs":    colPromDesc(ospfSRMetricPrefix, "prefix_sids_count_total", "Number of prefix SIDs advertised by the node.", ospfSRNodeLabels),
+		"adjacencySIDs": colPromDesc(ospfSRMetricPrefix, "adjacency_sids_count_total", "Number of adjacency SIDs advertised by the node.", ospfSRNodeLabels),
+	}
+	ospfSRErrors      = []error{}
+	totalOSPFSRErrors = 0.0
+)
+
+// OSPFSRCollector collects OSPF segment routing metrics, implemented as per prometheus.Collector interface.
+type OSPFSRCollector struct{}
+
+// NewOSPFSRCollector returns a OSPFSRCollector struct.
+func NewOSPFSRCollector() *OSPFSRCollector {
+	return &OSPFSRCollector{}
+}
+
+// Name of the collector. Used to populate flag name.
+func (*OSPFSRCollector) Name() string {
+	return ospfSubsystem + "sr"
+}
+
+// Help describes the metrics this collector scrapes. Used to populate flag help.
+func (*OSPFSRCollector) Help() string {
+	return "Collect OSPF Segment Routing Metrics"
+}
+
+// EnabledByDefault describes whether this collector is enabled by default. Used to populate flag default.
+func (*OSPFSRCollector) EnabledByDefault() bool {
+	return false
+}
+
+// Describe implemented as per the prometheus.Collector interface.
+func (*OSPFSRCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range ospfSRDesc {
+		ch <- desc
+	}
+}
+
+// Collect implemented as per the prometheus.Collector interface.
+func (c *OSPFSRCollector) Collect(ch chan<- prometheus.Metric) {
+	ospfSRErrors = []error{}
+
+	for _, instance := range getOSPFInstances() {
+		// Segment routing is only supported in the default VRF, so 'vrf all' is not used.
+		command := "show ip ospf database segment-routing json"
+		if instance != "" {
+			command = fmt.Sprintf("show ip ospf %s database segment-routing json", instance)
+		}
+		jsonOSPFSR, err := execVtyshCommand("-c", command)
+		if err != nil {
+			ospfSRErrors = append(ospfSRErrors, fmt.Errorf("cannot get ospf segment routing database: %s", err))
+			continue
+		}
+		if err := processOSPFSR(ch, jsonOSPFSR, instance); err != nil {
+			ospfSRErrors = append(ospfSRErrors, err)
+		}
+	}
+
+	totalOSPFSRErrors += float64(len(ospfSRErrors))
+}
+
+// CollectErrors returns what errors have been gathered.
+func (*OSPFSRCollector) CollectErrors() []error {
+	return ospfSRErrors
+}
+
+// CollectTotalErrors returns total errors.
+func (*OSPFSRCollector) CollectTotalErrors() float64 {
+	return totalOSPFSRErrors
+}
+
+func processOSPFSR(ch chan<- prometheus.Metric, jsonOSPFSR []byte, instance string) error {
+	var srDatabase struct {
+		SrNodes []ospfSRNode
+	}
+	if err := json.Unmarshal(jsonOSPFSR, &srDatabase); err != nil {
+		return fmt.Errorf("cannot unmarshal ospf segment routing database json: %s", err)
+	}
+
+	for _, node := range srDatabase.SrNodes {
+		// Segment routing is only supported in the default VRF.
+		// The labels are "vrf", "instance", "router_id"
+		labels := []string{"default", instance, node.RouterID}
+		newGauge(ch, ospfSRDesc["srgbStart"], node.SrgbLabel, labels...)
+		newGauge(ch, ospfSRDesc["srgbSize"], node.SrgbSize, labels...)
+		newGauge(ch, ospfSRDesc["srlbStart"], node.SrlbLabel, labels...)
+		newGauge(ch, ospfSRDesc["srlbSize"], node.SrlbSize, labels...)
+		newGauge(ch, ospfSRDesc["prefixSIDs"], float64(len(node.ExtendedPrefix)), labels...)
+		newGauge(ch, ospfSRDesc["adjacencySIDs"], float64(len(node.ExtendedLink)), labels...)
+	}
+	return nil
+}
+
+type ospfSRNode struct {
+	RouterID       string `json:"routerID"`
+	SrgbSize       float64
+	SrgbLabel      float64
+	SrlbSize       float64
+	SrlbLabel      float64
+	ExtendedPrefix []json.RawMessage
+	ExtendedLink   []json.RawMessage
+}
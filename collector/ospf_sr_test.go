@@ -0,0 +1,63 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var ospfSRDatabase = []byte(`{
+  "srdbID":"192.168.255.1",
+  "srNodes":[
+    {
+      "routerID":"192.168.255.1",
+      "srgbSize":8000,
+      "srgbLabel":16000,
+      "srlbSize":1000,
+      "srlbLabel":15000,
+      "algorithms":[{"0":"SPF"}],
+      "extendedPrefix":[
+        {"prefix":"10.0.255.1/32","sid":1,"inputLabel":3}
+      ],
+      "extendedLink":[
+        {"prefix":"192.168.2.2/32","sid":15000,"inputLabel":15000,"outputLabel":3,"interface":"swp2","nexthop":"192.168.2.2"},
+        {"prefix":"192.168.2.2/32","sid":15001,"inputLabel":15001,"outputLabel":3,"interface":"swp2","nexthop":"192.168.2.2"}
+      ]
+    },
+    {
+      "routerID":"192.168.255.2",
+      "srgbSize":8000,
+      "srgbLabel":16000,
+      "srlbSize":1000,
+      "srlbLabel":15000,
+      "algorithms":[{"0":"SPF"}],
+      "extendedPrefix":[
+        {"prefix":"10.0.255.2/32","sid":2,"inputLabel":16002,"prefixRoute":[{"outputLabel":"3","interface":"swp2","nexthop":"192.168.2.2"}]}
+      ]
+    }
+  ]
+}`)
+
+func TestProcessOSPFSR(t *testing.T) {
+	ch := make(chan prometheus.Metric, 1024)
+	if err := processOSPFSR(ch, ospfSRDatabase, ""); err != nil {
+		t.Errorf("error calling processOSPFSR: %s", err)
+	}
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, map[string]float64{
+		"frr_ospf_sr_srgb_start_label{instance=,router_id=192.168.255.1,vrf=default}":           16000,
+		"frr_ospf_sr_srgb_size{instance=,router_id=192.168.255.1,vrf=default}":                  8000,
+		"frr_ospf_sr_srlb_start_label{instance=,router_id=192.168.255.1,vrf=default}":           15000,
+		"frr_ospf_sr_srlb_size{instance=,router_id=192.168.255.1,vrf=default}":                  1000,
+		"frr_ospf_sr_prefix_sids_count_total{instance=,router_id=192.168.255.1,vrf=default}":    1,
+		"frr_ospf_sr_adjacency_sids_count_total{instance=,router_id=192.168.255.1,vrf=default}": 2,
+		"frr_ospf_sr_srgb_start_label{instance=,router_id=192.168.255.2,vrf=default}":           16000,
+		"frr_ospf_sr_srgb_size{instance=,router_id=192.168.255.2,vrf=default}":                  8000,
+		"frr_ospf_sr_srlb_start_label{instance=,router_id=192.168.255.2,vrf=default}":           15000,
+		"frr_ospf_sr_srlb_size{instance=,router_id=192.168.255.2,vrf=default}":                  1000,
+		"frr_ospf_sr_prefix_sids_count_total{instance=,router_id=192.168.255.2,vrf=default}":    1,
+		"frr_ospf_sr_adjacency_sids_count_total{instance=,router_id=192.168.255.2,vrf=default}": 0,
+	})
+}
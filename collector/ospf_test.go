@@ -1,10 +1,14 @@
 package collector
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	dto "github.com/prometheus/client_model/go"
@@ -30,6 +34,7 @@ var (
 	      "cost":1,
 	      "transmitDelayMsecs":1000,
 	      "state":"DR",
+	      "drId":"192.168.255.1",
 	      "priority":1,
 	      "mcastMemberOspfAllRouters":true,
 	      "mcastMemberOspfDesignatedRouters":true,
@@ -55,7 +60,8 @@ var (
 	      "networkType":"BROADCAST",
 	      "cost":1,
 	      "transmitDelayMsecs":1000,
-	      "state":"DR",
+	      "state":"Backup",
+	      "drId":"192.168.255.2",
 	      "priority":1,
 	      "bdrId":"1.1.1.1",
 	      "bdrAddress":"192.168.1.2",
@@ -69,6 +75,28 @@ var (
 	      "timerHelloInMsecs":7769,
 	      "nbrCount":1,
 	      "nbrAdjacentCount":1
+	    },
+	    "VLINK0":{
+	      "ifUp":true,
+	      "ifIndex":0,
+	      "mtuBytes":0,
+	      "ospfEnabled":true,
+	      "ospfIfType":"Peer",
+	      "vlinkPeer":"192.168.255.9",
+	      "area":"0.0.0.0",
+	      "routerId":"192.168.255.1",
+	      "networkType":"VIRTUALLINK",
+	      "cost":20,
+	      "transmitDelayMsecs":1000,
+	      "state":"Point-To-Point",
+	      "priority":1,
+	      "timerMsecs":10000,
+	      "timerDeadMsecs":40,
+	      "timerWaitMsecs":40,
+	      "timerRetransmit":5,
+	      "timerHelloInMsecs":3120,
+	      "nbrCount":1,
+	      "nbrAdjacentCount":1
 	    }
 	  },
 	  "red":{
@@ -86,9 +114,11 @@ var (
 	      "area":"0.0.0.0",
 	      "routerId":"192.168.255.1",
 	      "networkType":"BROADCAST",
-	      "cost":1,
+	      "cost":10,
 	      "transmitDelayMsecs":1000,
 	      "state":"DR",
+	      "drId":"192.168.255.1",
+	      "timerPassiveIface":true,
 	      "priority":1,
 	      "mcastMemberOspfAllRouters":true,
 	      "mcastMemberOspfDesignatedRouters":true,
@@ -115,6 +145,7 @@ var (
 	      "cost":1,
 	      "transmitDelayMsecs":1000,
 	      "state":"DR",
+	      "drId":"192.168.255.1",
 	      "priority":1,
 	      "bdrId":"1.1.1.1",
 	      "bdrAddress":"192.168.1.2",
@@ -133,20 +164,61 @@ var (
 	}
 `)
 	expectedMetrics = map[string]float64{
-		"frr_ospf_neighbors{area=0.0.0.0,iface=swp1,vrf=default}":            0,
-		"frr_ospf_neighbors{area=0.0.0.0,iface=swp2,vrf=default}":            1,
-		"frr_ospf_neighbors{area=0.0.0.0,iface=swp3,vrf=red}":                0,
-		"frr_ospf_neighbors{area=0.0.0.0,iface=swp4,vrf=red}":                1,
-		"frr_ospf_neighbor_adjacencies{area=0.0.0.0,iface=swp1,vrf=default}": 0,
-		"frr_ospf_neighbor_adjacencies{area=0.0.0.0,iface=swp2,vrf=default}": 1,
-		"frr_ospf_neighbor_adjacencies{area=0.0.0.0,iface=swp3,vrf=red}":     0,
-		"frr_ospf_neighbor_adjacencies{area=0.0.0.0,iface=swp4,vrf=red}":     1,
+		"frr_ospf_neighbors{area=0.0.0.0,iface=swp1,instance=,vrf=default}":                                      0,
+		"frr_ospf_neighbors{area=0.0.0.0,iface=swp2,instance=,vrf=default}":                                      1,
+		"frr_ospf_neighbors{area=0.0.0.0,iface=swp3,instance=,vrf=red}":                                          0,
+		"frr_ospf_neighbors{area=0.0.0.0,iface=swp4,instance=,vrf=red}":                                          1,
+		"frr_ospf_neighbor_adjacencies{area=0.0.0.0,iface=swp1,instance=,vrf=default}":                           0,
+		"frr_ospf_neighbor_adjacencies{area=0.0.0.0,iface=swp2,instance=,vrf=default}":                           1,
+		"frr_ospf_neighbor_adjacencies{area=0.0.0.0,iface=swp3,instance=,vrf=red}":                               0,
+		"frr_ospf_neighbor_adjacencies{area=0.0.0.0,iface=swp4,instance=,vrf=red}":                               1,
+		"frr_ospf_interface_cost{area=0.0.0.0,iface=swp1,instance=,vrf=default}":                                 1,
+		"frr_ospf_interface_state_info{area=0.0.0.0,iface=swp1,instance=,state=DR,vrf=default}":                  1,
+		"frr_ospf_interface_hello_interval_seconds{area=0.0.0.0,iface=swp1,instance=,vrf=default}":               0.1,
+		"frr_ospf_interface_passive{area=0.0.0.0,iface=swp1,instance=,vrf=default}":                              0,
+		"frr_ospf_neighbors{area=0.0.0.0,iface=VLINK0,instance=,vrf=default}":                                    1,
+		"frr_ospf_neighbor_adjacencies{area=0.0.0.0,iface=VLINK0,instance=,vrf=default}":                         1,
+		"frr_ospf_interface_cost{area=0.0.0.0,iface=VLINK0,instance=,vrf=default}":                               20,
+		"frr_ospf_interface_state_info{area=0.0.0.0,iface=VLINK0,instance=,state=Point-To-Point,vrf=default}":    1,
+		"frr_ospf_interface_hello_interval_seconds{area=0.0.0.0,iface=VLINK0,instance=,vrf=default}":             10,
+		"frr_ospf_interface_passive{area=0.0.0.0,iface=VLINK0,instance=,vrf=default}":                            0,
+		"frr_ospf_interface_dr_info{area=0.0.0.0,bdr=,dr=192.168.255.1,iface=swp1,instance=,vrf=default}":        1,
+		"frr_ospf_interface_dr_info{area=0.0.0.0,bdr=1.1.1.1,dr=192.168.255.2,iface=swp2,instance=,vrf=default}": 1,
+		"frr_ospf_interface_dr_info{area=0.0.0.0,bdr=,dr=192.168.255.1,iface=swp3,instance=,vrf=red}":            1,
+		"frr_ospf_interface_dr_info{area=0.0.0.0,bdr=1.1.1.1,dr=192.168.255.1,iface=swp4,instance=,vrf=red}":     1,
+		"frr_ospf_interface_dr_changes_total{area=0.0.0.0,iface=swp1,instance=,vrf=default}":                     0,
+		"frr_ospf_interface_dr_changes_total{area=0.0.0.0,iface=swp2,instance=,vrf=default}":                     0,
+		"frr_ospf_interface_dr_changes_total{area=0.0.0.0,iface=swp3,instance=,vrf=red}":                         0,
+		"frr_ospf_interface_dr_changes_total{area=0.0.0.0,iface=swp4,instance=,vrf=red}":                         0,
+		"frr_ospf_interface_dead_interval_seconds{area=0.0.0.0,iface=swp1,instance=,vrf=default}":                25,
+		"frr_ospf_interface_retransmit_interval_seconds{area=0.0.0.0,iface=swp1,instance=,vrf=default}":          200,
+		"frr_ospf_interface_dead_interval_seconds{area=0.0.0.0,iface=swp2,instance=,vrf=default}":                25,
+		"frr_ospf_interface_retransmit_interval_seconds{area=0.0.0.0,iface=swp2,instance=,vrf=default}":          200,
+		"frr_ospf_interface_dead_interval_seconds{area=0.0.0.0,iface=swp3,instance=,vrf=red}":                    25,
+		"frr_ospf_interface_retransmit_interval_seconds{area=0.0.0.0,iface=swp3,instance=,vrf=red}":              200,
+		"frr_ospf_interface_dead_interval_seconds{area=0.0.0.0,iface=swp4,instance=,vrf=red}":                    25,
+		"frr_ospf_interface_retransmit_interval_seconds{area=0.0.0.0,iface=swp4,instance=,vrf=red}":              200,
+		"frr_ospf_interface_dead_interval_seconds{area=0.0.0.0,iface=VLINK0,instance=,vrf=default}":              40,
+		"frr_ospf_interface_retransmit_interval_seconds{area=0.0.0.0,iface=VLINK0,instance=,vrf=default}":        5,
+		"frr_ospf_virtual_link_up{area=0.0.0.0,iface=VLINK0,instance=,peer=192.168.255.9,vrf=default}":           1,
+		"frr_ospf_interface_cost{area=0.0.0.0,iface=swp2,instance=,vrf=default}":                                 1,
+		"frr_ospf_interface_state_info{area=0.0.0.0,iface=swp2,instance=,state=Backup,vrf=default}":              1,
+		"frr_ospf_interface_hello_interval_seconds{area=0.0.0.0,iface=swp2,instance=,vrf=default}":               0.1,
+		"frr_ospf_interface_passive{area=0.0.0.0,iface=swp2,instance=,vrf=default}":                              0,
+		"frr_ospf_interface_cost{area=0.0.0.0,iface=swp3,instance=,vrf=red}":                                     10,
+		"frr_ospf_interface_state_info{area=0.0.0.0,iface=swp3,instance=,state=DR,vrf=red}":                      1,
+		"frr_ospf_interface_hello_interval_seconds{area=0.0.0.0,iface=swp3,instance=,vrf=red}":                   0.1,
+		"frr_ospf_interface_passive{area=0.0.0.0,iface=swp3,instance=,vrf=red}":                                  1,
+		"frr_ospf_interface_cost{area=0.0.0.0,iface=swp4,instance=,vrf=red}":                                     1,
+		"frr_ospf_interface_state_info{area=0.0.0.0,iface=swp4,instance=,state=DR,vrf=red}":                      1,
+		"frr_ospf_interface_hello_interval_seconds{area=0.0.0.0,iface=swp4,instance=,vrf=red}":                   0.1,
+		"frr_ospf_interface_passive{area=0.0.0.0,iface=swp4,instance=,vrf=red}":                                  0,
 	}
 )
 
 func TestProcessOSPFInterface(t *testing.T) {
 	ch := make(chan prometheus.Metric, 1024)
-	if err := processOSPFInterface(ch, ospfInterfaceSum); err != nil {
+	if err := processOSPFInterface(ch, ospfInterfaceSum, ""); err != nil {
 		t.Errorf("error calling processOSPFInterface ipv4unicast: %s", err)
 	}
 	close(ch)
@@ -204,3 +276,357 @@ func TestProcessOSPFInterface(t *testing.T) {
 		}
 	}
 }
+
+func TestOSPFDRChange(t *testing.T) {
+	defer func() { ospfLastDR, ospfDRChanges = map[string]string{}, map[string]float64{} }()
+
+	for i, dr := range []string{"192.168.255.1", "192.168.255.1", "192.168.255.2", "", "192.168.255.1"} {
+		changes := ospfDRChange("default||swp1|0.0.0.0", dr)
+		if expected := []float64{0, 0, 1, 2, 3}[i]; changes != expected {
+			t.Errorf("DR change %d to %q expected %v changes got %v", i, dr, expected, changes)
+		}
+	}
+
+	// Only the interfaces of the scraped instance that no longer exist are removed.
+	ospfDRChange("default|1|swp1|0.0.0.0", "192.168.255.1")
+	ospfDRChange("default|1|swp2|0.0.0.0", "192.168.255.1")
+	ospfDRChange("default|2|swp1|0.0.0.0", "192.168.255.1")
+	ospfDRPrune("1", map[string]bool{"default|1|swp1|0.0.0.0": true})
+	for key, expected := range map[string]bool{"default|1|swp1|0.0.0.0": true, "default|1|swp2|0.0.0.0": false, "default|2|swp1|0.0.0.0": true} {
+		if _, exist := ospfLastDR[key]; exist != expected {
+			t.Errorf("DR of %q expected to exist %v", key, expected)
+		}
+	}
+}
+
+var ospfSum = []byte(`{
+  "default":{
+    "vrfName":"default",
+    "vrfId":0,
+    "routerId":"192.168.255.1",
+    "tosRoutesOnly":true,
+    "rfc2328Conform":true,
+    "spfScheduleDelayMsecs":0,
+    "holdtimeMinMsecs":50,
+    "holdtimeMaxMsecs":5000,
+    "holdtimeMultplier":1,
+    "spfLastExecutedMsecs":12000,
+    "spfLastDurationMsecs":2,
+    "abrType":"Alternative Cisco",
+    "asbrRouter":"injectingExternalRoutingInformation",
+    "lsaMinIntervalMsecs":5000,
+    "lsaMinArrivalMsecs":1000,
+    "writeMultiplier":20,
+    "refreshTimerMsecs":10000,
+    "lsaExternalCounter":1,
+    "lsaExternalChecksum":255,
+    "lsaAsopaqueCounter":0,
+    "lsaAsOpaqueChecksum":0,
+    "attachedAreaCounter":2,
+    "areas":{
+      "0.0.0.0":{
+        "backbone":true,
+        "areaIfTotalCounter":2,
+        "areaIfActiveCounter":2,
+        "nbrFullAdjacentCounter":1,
+        "authentication":"authenticationNone",
+        "spfExecutedCounter":12,
+        "lsaNumber":4,
+        "lsaRouterNumber":2,
+        "lsaNetworkNumber":1,
+        "lsaSummaryNumber":1,
+        "lsaAsbrNumber":0
+      },
+      "0.0.0.1":{
+        "stubNoSummary":true,
+        "areaIfTotalCounter":1,
+        "areaIfActiveCounter":1,
+        "nbrFullAdjacentCounter":0,
+        "authentication":"authenticationNone",
+        "spfExecutedCounter":3,
+        "lsaNumber":2,
+        "lsaRouterNumber":1,
+        "lsaSummaryNumber":1
+      }
+    }
+  },
+  "red":{
+    "vrfName":"red",
+    "vrfId":39,
+    "routerId":"192.168.255.1",
+    "spfScheduleDelayMsecs":200,
+    "holdtimeMinMsecs":1000,
+    "holdtimeMaxMsecs":10000,
+    "holdtimeMultplier":4,
+    "spfHasNotRun":true,
+    "spfTimerDueInMsecs":150,
+    "areas":{
+      "0.0.0.0":{
+        "backbone":true,
+        "areaIfTotalCounter":1,
+        "areaIfActiveCounter":1,
+        "nbrFullAdjacentCounter":0,
+        "spfExecutedCounter":0
+      }
+    }
+  }
+}`)
+
+func TestProcessOSPF(t *testing.T) {
+	timeNow = func() time.Time { return time.Unix(1600003600, 0) }
+	defer func() { timeNow = time.Now }()
+
+	ch := make(chan prometheus.Metric, 1024)
+	if err := processOSPF(ch, ospfSum, ""); err != nil {
+		t.Errorf("error calling processOSPF: %s", err)
+	}
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, map[string]float64{
+		"frr_ospf_spf_executions_total{area=0.0.0.0,instance=,vrf=default}":               12,
+		"frr_ospf_spf_executions_total{area=0.0.0.1,instance=,vrf=default}":               3,
+		"frr_ospf_spf_executions_total{area=0.0.0.0,instance=,vrf=red}":                   0,
+		"frr_ospf_area_info{area=0.0.0.0,instance=,type=normal,vrf=default}":              1,
+		"frr_ospf_area_info{area=0.0.0.1,instance=,type=stub,vrf=default}":                1,
+		"frr_ospf_area_info{area=0.0.0.0,instance=,type=normal,vrf=red}":                  1,
+		"frr_ospf_area_interfaces_count_total{area=0.0.0.0,instance=,vrf=default}":        2,
+		"frr_ospf_area_interfaces_count_total{area=0.0.0.1,instance=,vrf=default}":        1,
+		"frr_ospf_area_interfaces_count_total{area=0.0.0.0,instance=,vrf=red}":            1,
+		"frr_ospf_area_active_interfaces_count_total{area=0.0.0.0,instance=,vrf=default}": 2,
+		"frr_ospf_area_active_interfaces_count_total{area=0.0.0.1,instance=,vrf=default}": 1,
+		"frr_ospf_area_active_interfaces_count_total{area=0.0.0.0,instance=,vrf=red}":     1,
+		"frr_ospf_area_full_adjacencies_count_total{area=0.0.0.0,instance=,vrf=default}":  1,
+		"frr_ospf_area_full_adjacencies_count_total{area=0.0.0.1,instance=,vrf=default}":  0,
+		"frr_ospf_area_full_adjacencies_count_total{area=0.0.0.0,instance=,vrf=red}":      0,
+		"frr_ospf_spf_last_run_timestamp_seconds{instance=,vrf=default}":                  1600003588,
+		"frr_ospf_spf_last_duration_seconds{instance=,vrf=default}":                       0.002,
+		"frr_ospf_spf_last_duration_seconds{instance=,vrf=red}":                           0,
+		"frr_ospf_spf_delay_seconds{instance=,vrf=default}":                               0,
+		"frr_ospf_spf_delay_seconds{instance=,vrf=red}":                                   0.2,
+		"frr_ospf_spf_holdtime_min_seconds{instance=,vrf=default}":                        0.05,
+		"frr_ospf_spf_holdtime_min_seconds{instance=,vrf=red}":                            1,
+		"frr_ospf_spf_holdtime_max_seconds{instance=,vrf=default}":                        5,
+		"frr_ospf_spf_holdtime_max_seconds{instance=,vrf=red}":                            10,
+		"frr_ospf_spf_holdtime_multiplier{instance=,vrf=default}":                         1,
+		"frr_ospf_spf_holdtime_multiplier{instance=,vrf=red}":                             4,
+		"frr_ospf_spf_scheduled{instance=,vrf=default}":                                   0,
+		"frr_ospf_spf_scheduled{instance=,vrf=red}":                                       1,
+		"frr_ospf_abr{instance=,vrf=default}":                                             1,
+		"frr_ospf_abr{instance=,vrf=red}":                                                 0,
+		"frr_ospf_asbr{instance=,vrf=default}":                                            1,
+		"frr_ospf_asbr{instance=,vrf=red}":                                                0,
+		"frr_ospf_external_lsas_count_total{instance=,vrf=default}":                       1,
+		"frr_ospf_external_lsas_count_total{instance=,vrf=red}":                           0,
+	})
+}
+
+func TestProcessOSPFInstance(t *testing.T) {
+	timeNow = func() time.Time { return time.Unix(1600003600, 0) }
+	defer func() { timeNow = time.Now }()
+
+	// The output of 'show ip ospf <instance> json' is the summary of the default VRF, without the VRF key.
+	var jsonMap map[string]json.RawMessage
+	if err := json.Unmarshal(ospfSum, &jsonMap); err != nil {
+		t.Fatalf("cannot unmarshal ospf summary json: %s", err)
+	}
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "summary.json"), jsonMap["default"], 0o644); err != nil {
+		t.Fatal(err)
+	}
+	// The fake vtysh records the command it was called with and prints the summary.
+	script := fmt.Sprintf("#!/bin/sh\necho \"$2\" > %s\ncat %s\n", filepath.Join(dir, "command"), filepath.Join(dir, "summary.json"))
+	if err := os.WriteFile(filepath.Join(dir, "vtysh"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	origPath, origTimeout, origInstances := vtyshPath, vtyshTimeout, *ospfInstances
+	vtyshPath, vtyshTimeout, *ospfInstances = filepath.Join(dir, "vtysh"), 5*time.Second, []string{"2"}
+	defer func() { vtyshPath, vtyshTimeout, *ospfInstances = origPath, origTimeout, origInstances }()
+
+	ch := make(chan prometheus.Metric, 1024)
+	for _, instance := range getOSPFInstances() {
+		jsonOSPF, err := execOSPFCommand(instance, "json")
+		if err != nil {
+			t.Fatalf("error calling execOSPFCommand: %s", err)
+		}
+		if err := processOSPF(ch, jsonOSPF, instance); err != nil {
+			t.Errorf("error calling processOSPF: %s", err)
+		}
+	}
+	close(ch)
+
+	command, err := os.ReadFile(filepath.Join(dir, "command"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.TrimSpace(string(command)); got != "show ip ospf 2 json" {
+		t.Errorf("unexpected vtysh command: %q", got)
+	}
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, map[string]float64{
+		"frr_ospf_spf_executions_total{area=0.0.0.0,instance=2,vrf=default}":               12,
+		"frr_ospf_spf_executions_total{area=0.0.0.1,instance=2,vrf=default}":               3,
+		"frr_ospf_area_info{area=0.0.0.0,instance=2,type=normal,vrf=default}":              1,
+		"frr_ospf_area_info{area=0.0.0.1,instance=2,type=stub,vrf=default}":                1,
+		"frr_ospf_area_interfaces_count_total{area=0.0.0.0,instance=2,vrf=default}":        2,
+		"frr_ospf_area_interfaces_count_total{area=0.0.0.1,instance=2,vrf=default}":        1,
+		"frr_ospf_area_active_interfaces_count_total{area=0.0.0.0,instance=2,vrf=default}": 2,
+		"frr_ospf_area_active_interfaces_count_total{area=0.0.0.1,instance=2,vrf=default}": 1,
+		"frr_ospf_area_full_adjacencies_count_total{area=0.0.0.0,instance=2,vrf=default}":  1,
+		"frr_ospf_area_full_adjacencies_count_total{area=0.0.0.1,instance=2,vrf=default}":  0,
+		"frr_ospf_spf_last_run_timestamp_seconds{instance=2,vrf=default}":                  1600003588,
+		"frr_ospf_spf_last_duration_seconds{instance=2,vrf=default}":                       0.002,
+		"frr_ospf_spf_delay_seconds{instance=2,vrf=default}":                               0,
+		"frr_ospf_spf_holdtime_min_seconds{instance=2,vrf=default}":                        0.05,
+		"frr_ospf_spf_holdtime_max_seconds{instance=2,vrf=default}":                        5,
+		"frr_ospf_spf_holdtime_multiplier{instance=2,vrf=default}":                         1,
+		"frr_ospf_spf_scheduled{instance=2,vrf=default}":                                   0,
+		"frr_ospf_abr{instance=2,vrf=default}":                                             1,
+		"frr_ospf_asbr{instance=2,vrf=default}":                                            1,
+		"frr_ospf_external_lsas_count_total{instance=2,vrf=default}":                       1,
+	})
+}
+
+func TestProcessOSPFNeighbor(t *testing.T) {
+	ospfNeighborDetail := []byte(`{
+  "default":{
+    "vrfName":"default",
+    "vrfId":0,
+    "neighbors":{
+      "192.168.255.2":[
+        {
+          "ifaceAddress":"192.168.2.2",
+          "areaId":"0.0.0.0",
+          "ifaceName":"swp2:192.168.2.1",
+          "nbrPriority":1,
+          "nbrState":"Full/Backup",
+          "role":"Backup",
+          "stateChangeCounter":6,
+          "lastPrgrsvChangeMsec":3601000,
+          "routerDesignatedId":"192.168.2.1",
+          "routerDesignatedBackupId":"192.168.2.2",
+          "optionsCounter":2,
+          "optionsList":"*|-|-|-|-|-|E|-",
+          "routerDeadIntervalTimerDueMsec":32500,
+          "databaseSummaryListCounter":0,
+          "linkStateRequestListCounter":0,
+          "linkStateRetransmissionListCounter":3
+        }
+      ]
+    }
+  },
+  "red":{
+    "vrfName":"red",
+    "vrfId":39,
+    "192.168.255.3":[
+      {
+        "ifaceAddress":"192.168.12.2",
+        "areaId":"0.0.0.1",
+        "ifaceName":"swp4:192.168.12.1",
+        "nbrPriority":0,
+        "nbrState":"ExStart/DROther",
+        "stateChangeCounter":3,
+        "routerDeadIntervalTimerDueMsec":38000
+      }
+    ]
+  }
+}`)
+
+	ch := make(chan prometheus.Metric, 1024)
+	if err := processOSPFNeighbor(ch, ospfNeighborDetail, ""); err != nil {
+		t.Errorf("error calling processOSPFNeighbor: %s", err)
+	}
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, map[string]float64{
+		"frr_ospf_neighbor_state_info{area=0.0.0.0,iface=swp2,instance=,neighbor=192.168.255.2,role=Backup,state=Full,vrf=default}": 1,
+		"frr_ospf_neighbor_state_info{area=0.0.0.1,iface=swp4,instance=,neighbor=192.168.255.3,role=DROther,state=ExStart,vrf=red}": 1,
+		"frr_ospf_neighbor_state_changes_total{area=0.0.0.0,iface=swp2,instance=,neighbor=192.168.255.2,vrf=default}":               6,
+		"frr_ospf_neighbor_state_changes_total{area=0.0.0.1,iface=swp4,instance=,neighbor=192.168.255.3,vrf=red}":                   3,
+		"frr_ospf_neighbor_dead_timer_remaining_seconds{area=0.0.0.0,iface=swp2,instance=,neighbor=192.168.255.2,vrf=default}":      32.5,
+		"frr_ospf_neighbor_dead_timer_remaining_seconds{area=0.0.0.1,iface=swp4,instance=,neighbor=192.168.255.3,vrf=red}":          38,
+		"frr_ospf_neighbor_retransmission_queue_length{area=0.0.0.0,iface=swp2,instance=,neighbor=192.168.255.2,vrf=default}":       3,
+		"frr_ospf_neighbor_retransmission_queue_length{area=0.0.0.1,iface=swp4,instance=,neighbor=192.168.255.3,vrf=red}":           0,
+	})
+}
+
+func TestProcessOSPFGRHelper(t *testing.T) {
+	ospfGRHelper := []byte(`{
+  "default":{
+    "routerId":"192.168.255.1",
+    "helperSupport":"Enabled",
+    "strictLsaCheck":"Enabled",
+    "restartSupport":"Planned and Unplanned Restarts",
+    "supportedGracePeriod":1800,
+    "lastExitReason":"Successful graceful restart",
+    "activeRestarterCnt":1
+  },
+  "red":{
+    "routerId":"192.168.255.1",
+    "helperSupport":"Disabled",
+    "strictLsaCheck":"Enabled",
+    "restartSupport":"Planned and Unplanned Restarts",
+    "supportedGracePeriod":300
+  }
+}`)
+
+	ch := make(chan prometheus.Metric, 1024)
+	if err := processOSPFGRHelper(ch, ospfGRHelper, ""); err != nil {
+		t.Errorf("error calling processOSPFGRHelper: %s", err)
+	}
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, map[string]float64{
+		"frr_ospf_gr_helper_enabled{instance=,vrf=default}":                        1,
+		"frr_ospf_gr_helper_enabled{instance=,vrf=red}":                            0,
+		"frr_ospf_gr_helper_supported_grace_period_seconds{instance=,vrf=default}": 1800,
+		"frr_ospf_gr_helper_supported_grace_period_seconds{instance=,vrf=red}":     300,
+		"frr_ospf_gr_helper_active_restarters_count_total{instance=,vrf=default}":  1,
+		"frr_ospf_gr_helper_active_restarters_count_total{instance=,vrf=red}":      0,
+	})
+}
+
+func TestProcessOSPFInterfaceTraffic(t *testing.T) {
+	ospfInterfaceTraffic := []byte(`{
+  "default":{
+    "vrfName":"default",
+    "vrfId":0,
+    "swp1":{
+      "helloIn":1200,
+      "helloOut":1201,
+      "dbDescIn":3,
+      "dbDescOut":4,
+      "lsReqIn":1,
+      "lsReqOut":1,
+      "lsUpdIn":25,
+      "lsUpdOut":31,
+      "lsAckIn":30,
+      "lsAckOut":22
+    }
+  }
+}`)
+
+	ch := make(chan prometheus.Metric, 1024)
+	if err := processOSPFInterfaceTraffic(ch, ospfInterfaceTraffic, ""); err != nil {
+		t.Errorf("error calling processOSPFInterfaceTraffic: %s", err)
+	}
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, map[string]float64{
+		"frr_ospf_interface_packets_total{direction=in,iface=swp1,instance=,type=hello,vrf=default}":       1200,
+		"frr_ospf_interface_packets_total{direction=out,iface=swp1,instance=,type=hello,vrf=default}":      1201,
+		"frr_ospf_interface_packets_total{direction=in,iface=swp1,instance=,type=db_desc,vrf=default}":     3,
+		"frr_ospf_interface_packets_total{direction=out,iface=swp1,instance=,type=db_desc,vrf=default}":    4,
+		"frr_ospf_interface_packets_total{direction=in,iface=swp1,instance=,type=ls_request,vrf=default}":  1,
+		"frr_ospf_interface_packets_total{direction=out,iface=swp1,instance=,type=ls_request,vrf=default}": 1,
+		"frr_ospf_interface_packets_total{direction=in,iface=swp1,instance=,type=ls_update,vrf=default}":   25,
+		"frr_ospf_interface_packets_total{direction=out,iface=swp1,instance=,type=ls_update,vrf=default}":  31,
+		"frr_ospf_interface_packets_total{direction=in,iface=swp1,instance=,type=ls_ack,vrf=default}":      30,
+		"frr_ospf_interface_packets_total{direction=out,iface=swp1,instance=,type=ls_ack,vrf=default}":     22,
+	})
+}
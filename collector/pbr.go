@@ -0,0 +1,169 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	pbrSubsystem = "pbr"
+
+	pbrMapLabels      = []string{"map"}
+	pbrSequenceLabels = []string{"map", "sequence"}
+	pbrNHGLabels      = []string{"nexthop_group"}
+	pbrDesc           = map[string]*prometheus.Desc{
+		"mapValid": colPromDesc(pbrSubsystem, "map_valid", "Whether the PBR map is valid (1 = Valid, 0 = Invalid).", pbrMapLabels),
+		"mapRules": colPromDesc(pbrSubsystem, "map_rules_count_total", "Number of sequences (rules) of the PBR map.", pbrMapLabels),
+
+		"sequenceInstalled":     colPromDesc(pbrSubsystem, "map_sequence_installed", "Whether the rule of the PBR map sequence is installed in the kernel (1 = Installed, 0 = Not installed).", pbrSequenceLabels),
+		"sequenceInstalledInfo": colPromDesc(pbrSubsystem, "map_sequence_installed_info", "Reason the rule of the PBR map sequence is or is not installed, such as Valid or Invalid NH-group. Value is always 1.", append(pbrSequenceLabels, "reason")),
+
+		"nhgValid":     colPromDesc(pbrSubsystem, "nexthop_group_valid", "Whether the PBR nexthop group is valid (1 = Valid, 0 = Invalid).", pbrNHGLabels),
+		"nhgInstalled": colPromDesc(pbrSubsystem, "nexthop_group_installed", "Whether the PBR nexthop group is installed as a routing table in zebra (1 = Installed, 0 = Not installed).", pbrNHGLabels),
+		"nexthopValid": colPromDesc(pbrSubsystem, "nexthop_valid", "Whether the nexthop of the PBR nexthop group is reachable (1 = Valid, 0 = Invalid).", append(pbrNHGLabels, "nexthop", "iface")),
+	}
+	pbrErrors      = []error{}
+	totalPBRErrors = 0.0
+)
+
+// PBRCollector collects PBR metrics, implemented as per prometheus.Collector interface.
+type PBRCollector struct{}
+
+// NewPBRCollector returns a PBRCollector struct.
+func NewPBRCollector() *PBRCollector {
+	return &PBRCollector{}
+}
+
+// Name of the collector. Used to populate flag name.
+func (*PBRCollector) Name() string {
+	return pbrSubsystem
+}
+
+// Help describes the metrics this collector scrapes. Used to populate flag help.
+func (*PBRCollector) Help() string {
+	return "Collect PBR Metrics"
+}
+
+// EnabledByDefault describes whether this collector is enabled by default. Used to populate flag default.
+func (*PBRCollector) EnabledByDefault() bool {
+	return false
+}
+
+// Describe implemented as per the prometheus.Collector interface.
+func (*PBRCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range pbrDesc {
+		ch <- desc
+	}
+}
+
+// Collect implemented as per the prometheus.Collector interface.
+func (c *PBRCollector) Collect(ch chan<- prometheus.Metric) {
+	pbrErrors = []error{}
+
+	jsonPBRMap, err := execVtyshCommand("-c", "show pbr map json")
+	if err != nil {
+		pbrErrors = append(pbrErrors, fmt.Errorf("cannot get pbr maps: %s", err))
+	} else {
+		if err := processPBRMap(ch, jsonPBRMap); err != nil {
+			pbrErrors = append(pbrErrors, err)
+		}
+	}
+
+	jsonPBRNHG, err := execVtyshCommand("-c", "show pbr nexthop-groups json")
+	if err != nil {
+		pbrErrors = append(pbrErrors, fmt.Errorf("cannot get pbr nexthop groups: %s", err))
+	} else {
+		if err := processPBRNexthopGroups(ch, jsonPBRNHG); err != nil {
+			pbrErrors = append(pbrErrors, err)
+		}
+	}
+
+	totalPBRErrors += float64(len(pbrErrors))
+}
+
+// CollectErrors returns what errors have been gathered.
+func (*PBRCollector) CollectErrors() []error {
+	return pbrErrors
+}
+
+// CollectTotalErrors returns total errors.
+func (*PBRCollector) CollectTotalErrors() float64 {
+	return totalPBRErrors
+}
+
+func processPBRMap(ch chan<- prometheus.Metric, jsonPBRMap []byte) error {
+	var pbrMaps []struct {
+		Name     string
+		Valid    bool
+		Policies []struct {
+			SequenceNumber  int
+			Installed       bool
+			InstalledReason string
+		}
+	}
+	if err := json.Unmarshal(jsonPBRMap, &pbrMaps); err != nil {
+		return fmt.Errorf("cannot unmarshal pbr map json: %s", err)
+	}
+
+	for _, pbrMap := range pbrMaps {
+		valid := 0.0
+		if pbrMap.Valid {
+			valid = 1
+		}
+		// The labels are "map"
+		newGauge(ch, pbrDesc["mapValid"], valid, pbrMap.Name)
+		newGauge(ch, pbrDesc["mapRules"], float64(len(pbrMap.Policies)), pbrMap.Name)
+		for _, policy := range pbrMap.Policies {
+			// The labels are "map", "sequence"
+			labels := []string{pbrMap.Name, strconv.Itoa(policy.SequenceNumber)}
+			installed := 0.0
+			if policy.Installed {
+				installed = 1
+			}
+			newGauge(ch, pbrDesc["sequenceInstalled"], installed, labels...)
+			newGauge(ch, pbrDesc["sequenceInstalledInfo"], 1, append(labels, policy.InstalledReason)...)
+		}
+	}
+	return nil
+}
+
+func processPBRNexthopGroups(ch chan<- prometheus.Metric, jsonPBRNHG []byte) error {
+	var pbrNHGs []struct {
+		Name      string
+		Valid     bool
+		Installed bool
+		Nexthops  []struct {
+			Nexthop   string
+			Interface string
+			Valid     bool
+		}
+	}
+	if err := json.Unmarshal(jsonPBRNHG, &pbrNHGs); err != nil {
+		return fmt.Errorf("cannot unmarshal pbr nexthop group json: %s", err)
+	}
+
+	for _, nhg := range pbrNHGs {
+		valid, installed := 0.0, 0.0
+		if nhg.Valid {
+			valid = 1
+		}
+		if nhg.Installed {
+			installed = 1
+		}
+		// The labels are "nexthop_group"
+		newGauge(ch, pbrDesc["nhgValid"], valid, nhg.Name)
+		newGauge(ch, pbrDesc["nhgInstalled"], installed, nhg.Name)
+		for _, nexthop := range nhg.Nexthops {
+			nexthopValid := 0.0
+			if nexthop.Valid {
+				nexthopValid = 1
+			}
+			// The labels are "nexthop_group", "nexthop", "iface"
+			newGauge(ch, pbrDesc["nexthopValid"], nexthopValid, nhg.Name, nexthop.Nexthop, nexthop.Interface)
+		}
+	}
+	return nil
+}
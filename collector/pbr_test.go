@@ -0,0 +1,80 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestProcessPBRMap(t *testing.T) {
+	pbrMap := []byte(`[
+  {
+    "name":"EGRESS",
+    "valid":true,
+    "policies":[
+      {"id":1,"sequenceNumber":10,"ruleNumber":309,"vrfUnchanged":false,"installed":true,"installedReason":"Valid","nexthopGroup":{"name":"EGRESS10","installed":true,"installedInternally":1},"matchSrc":"10.0.0.0/24"},
+      {"id":2,"sequenceNumber":20,"ruleNumber":310,"vrfUnchanged":false,"installed":false,"installedReason":"Invalid NH-group","nexthopGroup":{"name":"BACKUP","installed":false,"installedInternally":0},"matchDst":"10.1.0.0/24"}
+    ]
+  },
+  {"name":"EMPTY","valid":false,"policies":[]}
+]`)
+
+	ch := make(chan prometheus.Metric, 1024)
+	if err := processPBRMap(ch, pbrMap); err != nil {
+		t.Errorf("error calling processPBRMap: %s", err)
+	}
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, map[string]float64{
+		"frr_pbr_map_valid{map=EGRESS}":                                                       1,
+		"frr_pbr_map_valid{map=EMPTY}":                                                        0,
+		"frr_pbr_map_rules_count_total{map=EGRESS}":                                           2,
+		"frr_pbr_map_rules_count_total{map=EMPTY}":                                            0,
+		"frr_pbr_map_sequence_installed{map=EGRESS,sequence=10}":                              1,
+		"frr_pbr_map_sequence_installed{map=EGRESS,sequence=20}":                              0,
+		"frr_pbr_map_sequence_installed_info{map=EGRESS,reason=Valid,sequence=10}":            1,
+		"frr_pbr_map_sequence_installed_info{map=EGRESS,reason=Invalid NH-group,sequence=20}": 1,
+	})
+}
+
+func TestProcessPBRNexthopGroups(t *testing.T) {
+	pbrNHG := []byte(`[
+  {
+    "id":10000,
+    "name":"BACKUP",
+    "valid":false,
+    "installed":false,
+    "nexthops":[
+      {"nexthop":"192.168.1.1","vrfId":0,"valid":false,"installed":false},
+      {"nexthop":"192.168.2.1","interface":"eth1","vrfId":0,"valid":true,"installed":false}
+    ]
+  },
+  {
+    "id":10001,
+    "name":"EGRESS10",
+    "valid":true,
+    "installed":true,
+    "nexthops":[
+      {"nexthop":"10.0.0.1","vrfId":0,"valid":true,"installed":true}
+    ]
+  }
+]`)
+
+	ch := make(chan prometheus.Metric, 1024)
+	if err := processPBRNexthopGroups(ch, pbrNHG); err != nil {
+		t.Errorf("error calling processPBRNexthopGroups: %s", err)
+	}
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, map[string]float64{
+		"frr_pbr_nexthop_group_valid{nexthop_group=BACKUP}":                          0,
+		"frr_pbr_nexthop_group_valid{nexthop_group=EGRESS10}":                        1,
+		"frr_pbr_nexthop_group_installed{nexthop_group=BACKUP}":                      0,
+		"frr_pbr_nexthop_group_installed{nexthop_group=EGRESS10}":                    1,
+		"frr_pbr_nexthop_valid{iface=,nexthop=192.168.1.1,nexthop_group=BACKUP}":     0,
+		"frr_pbr_nexthop_valid{iface=eth1,nexthop=192.168.2.1,nexthop_group=BACKUP}": 1,
+		"frr_pbr_nexthop_valid{iface=,nexthop=10.0.0.1,nexthop_group=EGRESS10}":      1,
+	})
+}
@@ -0,0 +1,590 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+var (
+	pimSubsystem       = "pim"
+	igmpMetricPrefix   = "igmp"
+	mrouteMetricPrefix = "mroute"
+	msdpMetricPrefix   = "msdp"
+
+	pimMrouteFlows = kingpin.Flag("collector.pim.mroute-flows", "Collect the packet and byte counters of each multicast route (S,G or *,G flow) with the pim collector, in addition to the VRF totals (default: disabled).").Default("False").Bool()
+	pimIPv6        = kingpin.Flag("collector.pim.ipv6", "Collect IPv6 PIM neighbor, MLD group and multicast route metrics from pim6d with the pim collector (default: disabled).").Default("False").Bool()
+
+	pimNeighborLabels   = []string{"vrf", "ip_version", "iface", "neighbor"}
+	pimGroupLabels      = []string{"vrf", "ip_version"}
+	pimMrouteLabels     = []string{"vrf", "ip_version", "type"}
+	pimMrouteFlowLabels = []string{"vrf", "ip_version", "source", "group"}
+	pimMSDPPeerLabels   = []string{"vrf", "peer", "local"}
+	pimRPGroupLabels    = []string{"vrf", "group", "prefix_list"}
+	pimIfaceLabels      = []string{"vrf", "iface"}
+	pimDesc             = map[string]*prometheus.Desc{
+		"neighborUptime":     colPromDesc(pimSubsystem, "neighbor_uptime_seconds", "How long has the PIM neighbor been up.", pimNeighborLabels),
+		"neighborHoldTime":   colPromDesc(pimSubsystem, "neighbor_hold_time_remaining_seconds", "Time remaining until the PIM neighbor expires if no hello is received.", pimNeighborLabels),
+		"neighborDRPriority": colPromDesc(pimSubsystem, "neighbor_dr_priority", "DR priority advertised by the PIM neighbor.", pimNeighborLabels),
+
+		"ifaceUp":                 colPromDesc(pimSubsystem, "interface_up", "Whether PIM is active on the interface (1 = up, 0 = down).", pimIfaceLabels),
+		"ifaceDR":                 colPromDesc(pimSubsystem, "interface_dr_info", "Address of the DR of the interface. Value is always 1.", append(pimIfaceLabels, "dr")),
+		"ifaceDRChanges":          colPromDesc(pimSubsystem, "interface_dr_changes_total", "Number of times the DR of the interface has changed.", pimIfaceLabels),
+		"ifaceHellosSent":         colPromDesc(pimSubsystem, "interface_hellos_sent_total", "Number of PIM hellos sent on the interface.", pimIfaceLabels),
+		"ifaceHellosReceived":     colPromDesc(pimSubsystem, "interface_hellos_received_total", "Number of PIM hellos received on the interface.", pimIfaceLabels),
+		"ifaceHelloSendFailures":  colPromDesc(pimSubsystem, "interface_hello_send_failures_total", "Number of PIM hellos that failed to be sent on the interface.", pimIfaceLabels),
+		"ifaceHelloReceiveErrors": colPromDesc(pimSubsystem, "interface_hello_receive_failures_total", "Number of PIM hellos received on the interface that failed to be processed.", pimIfaceLabels),
+
+		"igmpGroups":      colPromDesc(igmpMetricPrefix, "groups_count_total", "Number of IGMP (IPv4) or MLD (IPv6) groups joined on the interface.", append(pimGroupLabels, "iface")),
+		"igmpTotalGroups": colPromDesc(igmpMetricPrefix, "total_groups_count_total", "Number of IGMP (IPv4) or MLD (IPv6) group memberships across all interfaces of the VRF.", pimGroupLabels),
+
+		"mroutes":           colPromDesc(mrouteMetricPrefix, "entries_count_total", "Number of multicast routes per entry type, such as (S,G) or (*,G).", pimMrouteLabels),
+		"mroutePackets":     colPromDesc(mrouteMetricPrefix, "packets", "Number of packets forwarded by the multicast routes that currently exist per entry type, which decreases when multicast routes expire.", pimMrouteLabels),
+		"mrouteBytes":       colPromDesc(mrouteMetricPrefix, "bytes", "Number of bytes forwarded by the multicast routes that currently exist per entry type, which decreases when multicast routes expire.", pimMrouteLabels),
+		"mrouteWrongIf":     colPromDesc(mrouteMetricPrefix, "wrong_iif_packets", "Number of packets received by the multicast routes that currently exist on an interface other than the incoming interface per entry type, which decreases when multicast routes expire.", pimMrouteLabels),
+		"mrouteFlowPackets": colPromDesc(mrouteMetricPrefix, "flow_packets_total", "Number of packets forwarded by the multicast route.", pimMrouteFlowLabels),
+		"mrouteFlowBytes":   colPromDesc(mrouteMetricPrefix, "flow_bytes_total", "Number of bytes forwarded by the multicast route.", pimMrouteFlowLabels),
+
+		"msdpPeerState":     colPromDesc(msdpMetricPrefix, "peer_state", "State of the MSDP peer (1 = Established, 0 = Down).", pimMSDPPeerLabels),
+		"msdpPeerStateInfo": colPromDesc(msdpMetricPrefix, "peer_state_info", "State of the MSDP peer, such as established, connecting or listening. Value is always 1.", append(pimMSDPPeerLabels, "state")),
+		"msdpPeerUptime":    colPromDesc(msdpMetricPrefix, "peer_uptime_seconds", "How long has the MSDP peer been established.", pimMSDPPeerLabels),
+		"msdpPeerSAs":       colPromDesc(msdpMetricPrefix, "peer_sa_count_total", "Number of source-active (SA) entries learnt from the MSDP peer.", pimMSDPPeerLabels),
+		"msdpSAs":           colPromDesc(msdpMetricPrefix, "sa_count_total", "Number of source-active (SA) entries in the MSDP SA cache, including the locally originated entries.", []string{"vrf"}),
+
+		"rpInfo":    colPromDesc(pimSubsystem, "rp_info", "RP of the multicast group range or prefix list, and the source of the mapping, such as Static or BSR. Value is always 1.", append(pimRPGroupLabels, "rp", "source")),
+		"rpChanges": colPromDesc(pimSubsystem, "rp_changes_total", "Number of times the RP of the multicast group range or prefix list has changed since the exporter started, per source of the mapping, such as Static or BSR.", append(pimRPGroupLabels, "source")),
+
+		"upstreamJoinStates":     colPromDesc(pimSubsystem, "upstream_join_state_count_total", "Number of upstream (S,G) and (*,G) entries per join state, such as Joined or NotJoined.", []string{"vrf", "state"}),
+		"upstreamRegisterStates": colPromDesc(pimSubsystem, "upstream_register_state_count_total", "Number of upstream entries per register state, such as RegJoined or RegPrune for entries with register suppression after a register-stop from the RP.", []string{"vrf", "state"}),
+
+		"bsrInfo":         colPromDesc(pimSubsystem, "bsr_info", "Address of the elected bootstrap router (BSR) and the BSR election state, such as ACCEPT_PREFERRED. Value is always 1.", []string{"bsr", "state"}),
+		"bsrPriority":     colPromDesc(pimSubsystem, "bsr_priority", "Priority of the elected bootstrap router (BSR).", []string{"bsr"}),
+		"bsrUptime":       colPromDesc(pimSubsystem, "bsr_uptime_seconds", "How long has the bootstrap router (BSR) been elected.", []string{"bsr"}),
+		"bsrCandidateRPs": colPromDesc(pimSubsystem, "bsr_candidate_rps_count_total", "Number of candidate RPs advertised by the bootstrap router (BSR) for the multicast group range.", []string{"group"}),
+		"bsrPendingRPs":   colPromDesc(pimSubsystem, "bsr_pending_rps_count_total", "Number of pending candidate RPs of the multicast group range, which are installed once the bootstrap message fragments are complete.", []string{"group"}),
+	}
+	pimErrors      = []error{}
+	totalPIMErrors = 0.0
+
+	// FRR does not count RP changes, so the RP of each group range and source is tracked between scrapes. A group range
+	// can have an RP per source, such as a static RP and an RP learnt from the BSR.
+	pimLastRP    = map[string]string{}
+	pimRPChanges = map[string]float64{}
+	pimRPMu      sync.Mutex
+)
+
+// pimAddressFamily describes the commands of pimd (IPv4) and pim6d (IPv6).
+type pimAddressFamily struct {
+	ipVersion string
+	// ip is the keyword of the show commands, such as ip or ipv6.
+	ip string
+	// groups is the group membership protocol, such as igmp or mld.
+	groups string
+}
+
+// PIMCollector collects PIM metrics, implemented as per prometheus.Collector interface.
+type PIMCollector struct{}
+
+// NewPIMCollector returns a PIMCollector struct.
+func NewPIMCollector() *PIMCollector {
+	return &PIMCollector{}
+}
+
+// Name of the collector. Used to populate flag name.
+func (*PIMCollector) Name() string {
+	return pimSubsystem
+}
+
+// Help describes the metrics this collector scrapes. Used to populate flag help.
+func (*PIMCollector) Help() string {
+	return "Collect PIM Metrics"
+}
+
+// EnabledByDefault describes whether this collector is enabled by default. Used to populate flag default.
+func (*PIMCollector) EnabledByDefault() bool {
+	return false
+}
+
+// Describe implemented as per the prometheus.Collector interface.
+func (*PIMCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range pimDesc {
+		ch <- desc
+	}
+}
+
+// Collect implemented as per the prometheus.Collector interface.
+func (c *PIMCollector) Collect(ch chan<- prometheus.Metric) {
+	pimErrors = []error{}
+
+	addressFamilies := []pimAddressFamily{{ipVersion: "4", ip: "ip", groups: "igmp"}}
+	if *pimIPv6 {
+		addressFamilies = append(addressFamilies, pimAddressFamily{ipVersion: "6", ip: "ipv6", groups: "mld"})
+	}
+	for _, af := range addressFamilies {
+		jsonPIMNeighbor, err := execVtyshCommand("-c", fmt.Sprintf("show %s pim vrf all neighbor json", af.ip))
+		if err != nil {
+			pimErrors = append(pimErrors, fmt.Errorf("cannot get %s pim neighbors: %s", af.ip, err))
+		} else {
+			if err := processPIMNeighbor(ch, jsonPIMNeighbor, af.ipVersion); err != nil {
+				pimErrors = append(pimErrors, err)
+			}
+		}
+
+		jsonGroups, err := execVtyshCommand("-c", fmt.Sprintf("show %s %s vrf all groups json", af.ip, af.groups))
+		if err != nil {
+			pimErrors = append(pimErrors, fmt.Errorf("cannot get %s groups: %s", af.groups, err))
+		} else {
+			if err := processIGMPGroups(ch, jsonGroups, af.ipVersion); err != nil {
+				pimErrors = append(pimErrors, err)
+			}
+		}
+
+		jsonMrouteCount, err := execVtyshCommand("-c", fmt.Sprintf("show %s mroute vrf all count json", af.ip))
+		if err != nil {
+			pimErrors = append(pimErrors, fmt.Errorf("cannot get %s mroute count: %s", af.ip, err))
+		} else {
+			if err := processMrouteCount(ch, jsonMrouteCount, af.ipVersion, *pimMrouteFlows); err != nil {
+				pimErrors = append(pimErrors, err)
+			}
+		}
+	}
+
+	jsonPIMInterface, err := execVtyshCommand("-c", "show ip pim vrf all interface detail json")
+	if err != nil {
+		pimErrors = append(pimErrors, fmt.Errorf("cannot get pim interfaces: %s", err))
+	} else {
+		if err := processPIMInterface(ch, jsonPIMInterface); err != nil {
+			pimErrors = append(pimErrors, err)
+		}
+	}
+
+	jsonMSDPPeer, err := execVtyshCommand("-c", "show ip msdp vrf all peer json")
+	if err != nil {
+		pimErrors = append(pimErrors, fmt.Errorf("cannot get msdp peers: %s", err))
+	} else {
+		if err := processMSDPPeer(ch, jsonMSDPPeer); err != nil {
+			pimErrors = append(pimErrors, err)
+		}
+	}
+
+	jsonMSDPSA, err := execVtyshCommand("-c", "show ip msdp vrf all sa json")
+	if err != nil {
+		pimErrors = append(pimErrors, fmt.Errorf("cannot get msdp sa cache: %s", err))
+	} else {
+		if err := processMSDPSA(ch, jsonMSDPSA); err != nil {
+			pimErrors = append(pimErrors, err)
+		}
+	}
+
+	jsonPIMRPInfo, err := execVtyshCommand("-c", "show ip pim vrf all rp-info json")
+	if err != nil {
+		pimErrors = append(pimErrors, fmt.Errorf("cannot get pim rp info: %s", err))
+	} else {
+		if err := processPIMRPInfo(ch, jsonPIMRPInfo); err != nil {
+			pimErrors = append(pimErrors, err)
+		}
+	}
+
+	jsonPIMUpstream, err := execVtyshCommand("-c", "show ip pim vrf all upstream json")
+	if err != nil {
+		pimErrors = append(pimErrors, fmt.Errorf("cannot get pim upstream: %s", err))
+	} else {
+		if err := processPIMUpstream(ch, jsonPIMUpstream); err != nil {
+			pimErrors = append(pimErrors, err)
+		}
+	}
+
+	// 'show ip pim bsr' does not support vrf all, so the BSR metrics are collected from the default VRF.
+	jsonPIMBSR, err := execVtyshCommand("-c", "show ip pim bsr json")
+	if err != nil {
+		pimErrors = append(pimErrors, fmt.Errorf("cannot get pim bsr: %s", err))
+	} else {
+		if err := processPIMBSR(ch, jsonPIMBSR); err != nil {
+			pimErrors = append(pimErrors, err)
+		}
+	}
+
+	jsonPIMBSRPInfo, err := execVtyshCommand("-c", "show ip pim bsrp-info json")
+	if err != nil {
+		pimErrors = append(pimErrors, fmt.Errorf("cannot get pim bsrp info: %s", err))
+	} else {
+		if err := processPIMBSRPInfo(ch, jsonPIMBSRPInfo); err != nil {
+			pimErrors = append(pimErrors, err)
+		}
+	}
+
+	totalPIMErrors += float64(len(pimErrors))
+}
+
+// CollectErrors returns what errors have been gathered.
+func (*PIMCollector) CollectErrors() []error {
+	return pimErrors
+}
+
+// CollectTotalErrors returns total errors.
+func (*PIMCollector) CollectTotalErrors() float64 {
+	return totalPIMErrors
+}
+
+func processPIMNeighbor(ch chan<- prometheus.Metric, jsonPIMNeighbor []byte, ipVersion string) error {
+	// The neighbors are keyed by VRF, interface and neighbor address.
+	var pimNeighbors map[string]map[string]map[string]pimNeighbor
+	if err := json.Unmarshal(jsonPIMNeighbor, &pimNeighbors); err != nil {
+		return fmt.Errorf("cannot unmarshal pim neighbor json: %s", err)
+	}
+
+	for vrfName, vrfData := range pimNeighbors {
+		for ifaceName, neighbors := range vrfData {
+			for neighborAddr, neighbor := range neighbors {
+				// The labels are "vrf", "ip_version", "iface", "neighbor"
+				labels := []string{strings.ToLower(vrfName), ipVersion, ifaceName, neighborAddr}
+				if uptime, err := parseDuration(neighbor.UpTime); err == nil {
+					newGauge(ch, pimDesc["neighborUptime"], uptime, labels...)
+				}
+				if holdTime, err := parseDuration(neighbor.HoldTime); err == nil {
+					newGauge(ch, pimDesc["neighborHoldTime"], holdTime, labels...)
+				}
+				newGauge(ch, pimDesc["neighborDRPriority"], neighbor.DrPriority, labels...)
+			}
+		}
+	}
+	return nil
+}
+
+func processPIMInterface(ch chan<- prometheus.Metric, jsonPIMInterface []byte) error {
+	// The interfaces are keyed by VRF and interface name.
+	var pimInterfaces map[string]map[string]pimIface
+	if err := json.Unmarshal(jsonPIMInterface, &pimInterfaces); err != nil {
+		return fmt.Errorf("cannot unmarshal pim interface json: %s", err)
+	}
+
+	for vrfName, vrfData := range pimInterfaces {
+		for ifaceName, iface := range vrfData {
+			// The labels are "vrf", "iface"
+			labels := []string{strings.ToLower(vrfName), ifaceName}
+			ifaceUp := 0.0
+			if iface.State == "up" {
+				ifaceUp = 1
+			}
+			newGauge(ch, pimDesc["ifaceUp"], ifaceUp, labels...)
+			newGauge(ch, pimDesc["ifaceDR"], 1, append(labels, iface.DrAddress)...)
+			newCounter(ch, pimDesc["ifaceDRChanges"], iface.DrChanges, labels...)
+			newCounter(ch, pimDesc["ifaceHellosSent"], iface.HelloSend, labels...)
+			newCounter(ch, pimDesc["ifaceHellosReceived"], iface.HelloReceived, labels...)
+			newCounter(ch, pimDesc["ifaceHelloSendFailures"], iface.HelloSendFailed, labels...)
+			newCounter(ch, pimDesc["ifaceHelloReceiveErrors"], iface.HelloReceivedFailed, labels...)
+		}
+	}
+	return nil
+}
+
+func processIGMPGroups(ch chan<- prometheus.Metric, jsonIGMPGroups []byte, ipVersion string) error {
+	// The interfaces of each VRF are keyed by name alongside the totalGroups and watermarkLimit of the VRF.
+	var igmpGroups map[string]map[string]json.RawMessage
+	if err := json.Unmarshal(jsonIGMPGroups, &igmpGroups); err != nil {
+		return fmt.Errorf("cannot unmarshal igmp groups json: %s", err)
+	}
+
+	for vrfName, vrfData := range igmpGroups {
+		vrfName = strings.ToLower(vrfName)
+		for key, value := range vrfData {
+			switch key {
+			case "totalGroups":
+				var totalGroups float64
+				if err := json.Unmarshal(value, &totalGroups); err != nil {
+					return fmt.Errorf("cannot unmarshal igmp total groups json: %s", err)
+				}
+				// The labels are "vrf", "ip_version"
+				newGauge(ch, pimDesc["igmpTotalGroups"], totalGroups, vrfName, ipVersion)
+			default:
+				// Only the interfaces are objects, other keys such as watermarkLimit are numbers.
+				if !strings.HasPrefix(string(value), "{") {
+					continue
+				}
+				var iface struct {
+					Groups []json.RawMessage
+				}
+				if err := json.Unmarshal(value, &iface); err != nil {
+					return fmt.Errorf("cannot unmarshal igmp groups json of interface %s: %s", key, err)
+				}
+				// The labels are "vrf", "ip_version", "iface"
+				newGauge(ch, pimDesc["igmpGroups"], float64(len(iface.Groups)), vrfName, ipVersion, key)
+			}
+		}
+	}
+	return nil
+}
+
+func processMrouteCount(ch chan<- prometheus.Metric, jsonMrouteCount []byte, ipVersion string, mrouteFlows bool) error {
+	// The multicast routes are keyed by VRF, group and source, which is * for (*,G) routes.
+	var mrouteCount map[string]map[string]map[string]pimMrouteCount
+	if err := json.Unmarshal(jsonMrouteCount, &mrouteCount); err != nil {
+		return fmt.Errorf("cannot unmarshal mroute count json: %s", err)
+	}
+
+	for vrfName, vrfData := range mrouteCount {
+		vrfName = strings.ToLower(vrfName)
+		totals := map[string]*pimMrouteCount{
+			"(S,G)": {},
+			"(*,G)": {},
+		}
+		entries := map[string]float64{}
+		for group, sources := range vrfData {
+			for source, mroute := range sources {
+				entryType := "(S,G)"
+				if source == "*" {
+					entryType = "(*,G)"
+				}
+				entries[entryType]++
+				totals[entryType].Packets += mroute.Packets
+				totals[entryType].Bytes += mroute.Bytes
+				totals[entryType].WrongIf += mroute.WrongIf
+				if mrouteFlows {
+					// The labels are "vrf", "ip_version", "source", "group"
+					newCounter(ch, pimDesc["mrouteFlowPackets"], mroute.Packets, vrfName, ipVersion, source, group)
+					newCounter(ch, pimDesc["mrouteFlowBytes"], mroute.Bytes, vrfName, ipVersion, source, group)
+				}
+			}
+		}
+		for entryType, total := range totals {
+			// The labels are "vrf", "ip_version", "type"
+			labels := []string{vrfName, ipVersion, entryType}
+			newGauge(ch, pimDesc["mroutes"], entries[entryType], labels...)
+			// The totals are sums over the multicast routes at the time of the scrape, so they are not counters.
+			newGauge(ch, pimDesc["mroutePackets"], total.Packets, labels...)
+			newGauge(ch, pimDesc["mrouteBytes"], total.Bytes, labels...)
+			newGauge(ch, pimDesc["mrouteWrongIf"], total.WrongIf, labels...)
+		}
+	}
+	return nil
+}
+
+func processMSDPPeer(ch chan<- prometheus.Metric, jsonMSDPPeer []byte) error {
+	// The peers are keyed by VRF and peer address.
+	var msdpPeers map[string]map[string]pimMSDPPeer
+	if err := json.Unmarshal(jsonMSDPPeer, &msdpPeers); err != nil {
+		return fmt.Errorf("cannot unmarshal msdp peer json: %s", err)
+	}
+
+	for vrfName, vrfData := range msdpPeers {
+		for peerAddr, peer := range vrfData {
+			// The labels are "vrf", "peer", "local"
+			labels := []string{strings.ToLower(vrfName), peerAddr, peer.Local}
+			peerState := 0.0
+			if peer.State == "established" {
+				peerState = 1
+			}
+			newGauge(ch, pimDesc["msdpPeerState"], peerState, labels...)
+			newGauge(ch, pimDesc["msdpPeerStateInfo"], 1, append(labels, peer.State)...)
+			// The uptime is only included when the peer is established.
+			uptime := 0.0
+			if peerState == 1 {
+				if duration, err := parseDuration(peer.UpTime); err == nil {
+					uptime = duration
+				}
+			}
+			newGauge(ch, pimDesc["msdpPeerUptime"], uptime, labels...)
+			newGauge(ch, pimDesc["msdpPeerSAs"], peer.SaCount, labels...)
+		}
+	}
+	return nil
+}
+
+func processMSDPSA(ch chan<- prometheus.Metric, jsonMSDPSA []byte) error {
+	// The SA entries are keyed by VRF, group and source.
+	var msdpSAs map[string]map[string]map[string]json.RawMessage
+	if err := json.Unmarshal(jsonMSDPSA, &msdpSAs); err != nil {
+		return fmt.Errorf("cannot unmarshal msdp sa json: %s", err)
+	}
+
+	for vrfName, vrfData := range msdpSAs {
+		sas := 0.0
+		for _, sources := range vrfData {
+			sas += float64(len(sources))
+		}
+		// The labels are "vrf"
+		newGauge(ch, pimDesc["msdpSAs"], sas, strings.ToLower(vrfName))
+	}
+	return nil
+}
+
+func processPIMRPInfo(ch chan<- prometheus.Metric, jsonPIMRPInfo []byte) error {
+	// The group ranges are keyed by VRF and RP address.
+	var pimRPInfo map[string]map[string][]pimRPMapping
+	if err := json.Unmarshal(jsonPIMRPInfo, &pimRPInfo); err != nil {
+		return fmt.Errorf("cannot unmarshal pim rp info json: %s", err)
+	}
+
+	seen := map[string]bool{}
+	for vrfName, vrfData := range pimRPInfo {
+		for rp, mappings := range vrfData {
+			for _, mapping := range mappings {
+				// The labels are "vrf", "group", "prefix_list"
+				labels := []string{strings.ToLower(vrfName), mapping.Group, mapping.PrefixList}
+				newGauge(ch, pimDesc["rpInfo"], 1, append(labels, rp, mapping.Source)...)
+				// The labels are "vrf", "group", "prefix_list", "source"
+				labels = append(labels, mapping.Source)
+				key := strings.Join(labels, "|")
+				seen[key] = true
+				newCounter(ch, pimDesc["rpChanges"], pimRPChange(key, rp), labels...)
+			}
+		}
+	}
+	pimRPPrune(seen)
+	return nil
+}
+
+func processPIMUpstream(ch chan<- prometheus.Metric, jsonPIMUpstream []byte) error {
+	// The upstream entries are keyed by VRF, group and source, which is * for (*,G) entries.
+	var pimUpstream map[string]map[string]map[string]struct {
+		JoinState string
+		RegState  string
+	}
+	if err := json.Unmarshal(jsonPIMUpstream, &pimUpstream); err != nil {
+		return fmt.Errorf("cannot unmarshal pim upstream json: %s", err)
+	}
+
+	for vrfName, vrfData := range pimUpstream {
+		vrfName = strings.ToLower(vrfName)
+		joinStates, regStates := map[string]float64{}, map[string]float64{}
+		for _, sources := range vrfData {
+			for _, upstream := range sources {
+				joinStates[upstream.JoinState]++
+				regStates[upstream.RegState]++
+			}
+		}
+		for state, count := range joinStates {
+			// The labels are "vrf", "state"
+			newGauge(ch, pimDesc["upstreamJoinStates"], count, vrfName, state)
+		}
+		for state, count := range regStates {
+			// The labels are "vrf", "state"
+			newGauge(ch, pimDesc["upstreamRegisterStates"], count, vrfName, state)
+		}
+	}
+	return nil
+}
+
+func processPIMBSR(ch chan<- prometheus.Metric, jsonPIMBSR []byte) error {
+	var pimBSR struct {
+		Bsr      string
+		Priority float64
+		State    string
+		UpTime   string
+	}
+	if err := json.Unmarshal(jsonPIMBSR, &pimBSR); err != nil {
+		return fmt.Errorf("cannot unmarshal pim bsr json: %s", err)
+	}
+
+	// The labels are "bsr", "state"
+	newGauge(ch, pimDesc["bsrInfo"], 1, pimBSR.Bsr, pimBSR.State)
+	// The priority and uptime are meaningless until a BSR is elected.
+	if pimBSR.State == "NO_INFO" {
+		return nil
+	}
+	// The labels are "bsr"
+	newGauge(ch, pimDesc["bsrPriority"], pimBSR.Priority, pimBSR.Bsr)
+	if uptime, err := parseDuration(pimBSR.UpTime); err == nil {
+		newGauge(ch, pimDesc["bsrUptime"], uptime, pimBSR.Bsr)
+	}
+	return nil
+}
+
+func processPIMBSRPInfo(ch chan<- prometheus.Metric, jsonPIMBSRPInfo []byte) error {
+	// The group ranges are keyed by prefix alongside the "BSR Address", and their candidate RPs by address alongside
+	// the "Pending RP count".
+	var pimBSRPInfo map[string]json.RawMessage
+	if err := json.Unmarshal(jsonPIMBSRPInfo, &pimBSRPInfo); err != nil {
+		return fmt.Errorf("cannot unmarshal pim bsrp info json: %s", err)
+	}
+
+	for group, value := range pimBSRPInfo {
+		if !strings.HasPrefix(string(value), "{") {
+			continue
+		}
+		var groupData map[string]json.RawMessage
+		if err := json.Unmarshal(value, &groupData); err != nil {
+			return fmt.Errorf("cannot unmarshal pim bsrp info json of group %s: %s", group, err)
+		}
+		candidateRPs, pendingRPs := 0.0, 0.0
+		for key, value := range groupData {
+			if key == "Pending RP count" {
+				if err := json.Unmarshal(value, &pendingRPs); err != nil {
+					return fmt.Errorf("cannot unmarshal pim bsrp info pending rp count of group %s: %s", group, err)
+				}
+				continue
+			}
+			if strings.HasPrefix(string(value), "{") {
+				candidateRPs++
+			}
+		}
+		// The labels are "group"
+		newGauge(ch, pimDesc["bsrCandidateRPs"], candidateRPs, group)
+		newGauge(ch, pimDesc["bsrPendingRPs"], pendingRPs, group)
+	}
+	return nil
+}
+
+// pimRPChange records the RP of a group range and returns the number of times it has changed.
+func pimRPChange(key string, rp string) float64 {
+	pimRPMu.Lock()
+	defer pimRPMu.Unlock()
+
+	if lastRP, exist := pimLastRP[key]; exist && lastRP != rp {
+		pimRPChanges[key]++
+	}
+	pimLastRP[key] = rp
+	return pimRPChanges[key]
+}
+
+// pimRPPrune removes the group ranges that are no longer mapped to an RP.
+func pimRPPrune(seen map[string]bool) {
+	pimRPMu.Lock()
+	defer pimRPMu.Unlock()
+
+	for key := range pimLastRP {
+		if !seen[key] {
+			delete(pimLastRP, key)
+			delete(pimRPChanges, key)
+		}
+	}
+}
+
+type pimNeighbor struct {
+	UpTime     string
+	HoldTime   string
+	DrPriority float64
+}
+
+type pimMrouteCount struct {
+	Packets float64
+	Bytes   float64
+	WrongIf float64
+}
+
+type pimMSDPPeer struct {
+	Local   string
+	State   string
+	UpTime  string
+	SaCount float64
+}
+
+type pimRPMapping struct {
+	Group      string
+	PrefixList string
+	Source     string
+}
+
+type pimIface struct {
+	State               string
+	DrAddress           string
+	DrChanges           float64
+	HelloSend           float64
+	HelloSendFailed     float64
+	HelloReceived       float64
+	HelloReceivedFailed float64
+}
@@ -0,0 +1,458 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestProcessPIMNeighbor(t *testing.T) {
+	pimNeighbor := []byte(`{
+  "default":{
+    "swp1":{
+      "10.0.1.2":{
+        "interface":"swp1",
+        "neighbor":"10.0.1.2",
+        "upTime":"00:10:02",
+        "holdTime":"00:01:28",
+        "holdTimeMax":105,
+        "drPriority":1
+      },
+      "10.0.1.3":{
+        "interface":"swp1",
+        "neighbor":"10.0.1.3",
+        "upTime":"2d03h15m",
+        "holdTime":"00:01:30",
+        "holdTimeMax":105,
+        "drPriority":10
+      }
+    }
+  },
+  "red":{
+    "swp2":{
+      "10.0.2.2":{
+        "interface":"swp2",
+        "neighbor":"10.0.2.2",
+        "upTime":"01:00:00",
+        "holdTime":"--:--:--",
+        "holdTimeMax":65535,
+        "drPriority":1
+      }
+    }
+  }
+}`)
+
+	ch := make(chan prometheus.Metric, 1024)
+	if err := processPIMNeighbor(ch, pimNeighbor, "4"); err != nil {
+		t.Errorf("error calling processPIMNeighbor: %s", err)
+	}
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, map[string]float64{
+		"frr_pim_neighbor_uptime_seconds{iface=swp1,ip_version=4,neighbor=10.0.1.2,vrf=default}":              602,
+		"frr_pim_neighbor_uptime_seconds{iface=swp1,ip_version=4,neighbor=10.0.1.3,vrf=default}":              184500,
+		"frr_pim_neighbor_uptime_seconds{iface=swp2,ip_version=4,neighbor=10.0.2.2,vrf=red}":                  3600,
+		"frr_pim_neighbor_hold_time_remaining_seconds{iface=swp1,ip_version=4,neighbor=10.0.1.2,vrf=default}": 88,
+		"frr_pim_neighbor_hold_time_remaining_seconds{iface=swp1,ip_version=4,neighbor=10.0.1.3,vrf=default}": 90,
+		"frr_pim_neighbor_dr_priority{iface=swp1,ip_version=4,neighbor=10.0.1.2,vrf=default}":                 1,
+		"frr_pim_neighbor_dr_priority{iface=swp1,ip_version=4,neighbor=10.0.1.3,vrf=default}":                 10,
+		"frr_pim_neighbor_dr_priority{iface=swp2,ip_version=4,neighbor=10.0.2.2,vrf=red}":                     1,
+	})
+}
+
+func TestProcessPIM6Neighbor(t *testing.T) {
+	pim6Neighbor := []byte(`{
+  "default":{
+    "swp1":{
+      "fe80::2":{"interface":"swp1","neighbor":"fe80::2","upTime":"00:00:45","holdTime":"00:01:30","holdTimeMax":105,"drPriority":1}
+    }
+  }
+}`)
+
+	ch := make(chan prometheus.Metric, 1024)
+	if err := processPIMNeighbor(ch, pim6Neighbor, "6"); err != nil {
+		t.Errorf("error calling processPIMNeighbor: %s", err)
+	}
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, map[string]float64{
+		"frr_pim_neighbor_uptime_seconds{iface=swp1,ip_version=6,neighbor=fe80::2,vrf=default}":              45,
+		"frr_pim_neighbor_hold_time_remaining_seconds{iface=swp1,ip_version=6,neighbor=fe80::2,vrf=default}": 90,
+		"frr_pim_neighbor_dr_priority{iface=swp1,ip_version=6,neighbor=fe80::2,vrf=default}":                 1,
+	})
+}
+
+func TestProcessPIMInterface(t *testing.T) {
+	pimInterface := []byte(`{
+  "default":{
+    "swp1":{
+      "name":"swp1",
+      "state":"up",
+      "address":"10.0.1.1",
+      "index":5,
+      "flagMulticast":true,
+      "flagBroadcast":true,
+      "lanDelayEnabled":true,
+      "drAddress":"10.0.1.2",
+      "drPriority":1,
+      "drUptime":"00:10:00",
+      "drElections":2,
+      "drChanges":1,
+      "helloPeriod":30,
+      "helloTimer":"00:00:12",
+      "helloStatStart":"00:10:05",
+      "helloReceived":21,
+      "helloReceivedFailed":0,
+      "helloSend":20,
+      "helloSendFailed":0,
+      "helloGenerationId":"1a2b3c4d"
+    },
+    "swp3":{
+      "name":"swp3",
+      "state":"down",
+      "address":"0.0.0.0",
+      "index":7,
+      "drAddress":"0.0.0.0",
+      "drPriority":1,
+      "drElections":0,
+      "drChanges":0,
+      "helloPeriod":30,
+      "helloReceived":0,
+      "helloReceivedFailed":3,
+      "helloSend":0,
+      "helloSendFailed":4
+    }
+  }
+}`)
+
+	ch := make(chan prometheus.Metric, 1024)
+	if err := processPIMInterface(ch, pimInterface); err != nil {
+		t.Errorf("error calling processPIMInterface: %s", err)
+	}
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, map[string]float64{
+		"frr_pim_interface_up{iface=swp1,vrf=default}":                           1,
+		"frr_pim_interface_up{iface=swp3,vrf=default}":                           0,
+		"frr_pim_interface_dr_info{dr=10.0.1.2,iface=swp1,vrf=default}":          1,
+		"frr_pim_interface_dr_info{dr=0.0.0.0,iface=swp3,vrf=default}":           1,
+		"frr_pim_interface_dr_changes_total{iface=swp1,vrf=default}":             1,
+		"frr_pim_interface_dr_changes_total{iface=swp3,vrf=default}":             0,
+		"frr_pim_interface_hellos_sent_total{iface=swp1,vrf=default}":            20,
+		"frr_pim_interface_hellos_sent_total{iface=swp3,vrf=default}":            0,
+		"frr_pim_interface_hellos_received_total{iface=swp1,vrf=default}":        21,
+		"frr_pim_interface_hellos_received_total{iface=swp3,vrf=default}":        0,
+		"frr_pim_interface_hello_send_failures_total{iface=swp1,vrf=default}":    0,
+		"frr_pim_interface_hello_send_failures_total{iface=swp3,vrf=default}":    4,
+		"frr_pim_interface_hello_receive_failures_total{iface=swp1,vrf=default}": 0,
+		"frr_pim_interface_hello_receive_failures_total{iface=swp3,vrf=default}": 3,
+	})
+}
+
+func TestProcessIGMPGroups(t *testing.T) {
+	igmpGroups := []byte(`{
+  "default":{
+    "totalGroups":3,
+    "watermarkLimit":0,
+    "swp1":{
+      "name":"swp1",
+      "state":"up",
+      "address":"10.0.1.1",
+      "index":5,
+      "flagMulticast":true,
+      "flagBroadcast":true,
+      "lanDelayEnabled":true,
+      "groups":[
+        {"source":"10.0.1.1","group":"239.1.1.1","timer":"00:03:59","sourcesCount":1,"version":3,"uptime":"00:10:00"},
+        {"source":"10.0.1.1","group":"239.1.1.2","timer":"00:03:12","sourcesCount":0,"version":2,"uptime":"00:01:05"}
+      ]
+    },
+    "swp2":{
+      "name":"swp2",
+      "state":"up",
+      "address":"10.0.2.1",
+      "index":6,
+      "groups":[
+        {"source":"10.0.2.1","group":"239.1.1.1","timer":"00:04:10","sourcesCount":1,"version":3,"uptime":"00:00:10"}
+      ]
+    }
+  },
+  "red":{
+    "totalGroups":0,
+    "watermarkLimit":0
+  }
+}`)
+
+	ch := make(chan prometheus.Metric, 1024)
+	if err := processIGMPGroups(ch, igmpGroups, "4"); err != nil {
+		t.Errorf("error calling processIGMPGroups: %s", err)
+	}
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, map[string]float64{
+		"frr_igmp_groups_count_total{iface=swp1,ip_version=4,vrf=default}": 2,
+		"frr_igmp_groups_count_total{iface=swp2,ip_version=4,vrf=default}": 1,
+		"frr_igmp_total_groups_count_total{ip_version=4,vrf=default}":      3,
+		"frr_igmp_total_groups_count_total{ip_version=4,vrf=red}":          0,
+	})
+}
+
+func TestProcessMrouteCount(t *testing.T) {
+	mrouteCount := []byte(`{
+  "default":{
+    "239.1.1.1":{
+      "*":{"lastUsed":0,"packets":0,"bytes":0,"wrongIf":0},
+      "10.0.1.5":{"lastUsed":1,"packets":1500,"bytes":1500000,"wrongIf":2},
+      "10.0.1.6":{"lastUsed":3,"packets":500,"bytes":250000,"wrongIf":0}
+    },
+    "239.1.1.2":{
+      "*":{"lastUsed":12,"packets":10,"bytes":1000,"wrongIf":1}
+    }
+  },
+  "red":{}
+}`)
+
+	for _, mrouteFlows := range []bool{false, true} {
+		ch := make(chan prometheus.Metric, 1024)
+		if err := processMrouteCount(ch, mrouteCount, "4", mrouteFlows); err != nil {
+			t.Errorf("error calling processMrouteCount: %s", err)
+		}
+		close(ch)
+
+		expectedMetrics := map[string]float64{
+			"frr_mroute_entries_count_total{ip_version=4,type=(*,G),vrf=default}": 2,
+			"frr_mroute_entries_count_total{ip_version=4,type=(S,G),vrf=default}": 2,
+			"frr_mroute_entries_count_total{ip_version=4,type=(*,G),vrf=red}":     0,
+			"frr_mroute_entries_count_total{ip_version=4,type=(S,G),vrf=red}":     0,
+			"frr_mroute_packets{ip_version=4,type=(*,G),vrf=default}":             10,
+			"frr_mroute_packets{ip_version=4,type=(S,G),vrf=default}":             2000,
+			"frr_mroute_packets{ip_version=4,type=(*,G),vrf=red}":                 0,
+			"frr_mroute_packets{ip_version=4,type=(S,G),vrf=red}":                 0,
+			"frr_mroute_bytes{ip_version=4,type=(*,G),vrf=default}":               1000,
+			"frr_mroute_bytes{ip_version=4,type=(S,G),vrf=default}":               1750000,
+			"frr_mroute_bytes{ip_version=4,type=(*,G),vrf=red}":                   0,
+			"frr_mroute_bytes{ip_version=4,type=(S,G),vrf=red}":                   0,
+			"frr_mroute_wrong_iif_packets{ip_version=4,type=(*,G),vrf=default}":   1,
+			"frr_mroute_wrong_iif_packets{ip_version=4,type=(S,G),vrf=default}":   2,
+			"frr_mroute_wrong_iif_packets{ip_version=4,type=(*,G),vrf=red}":       0,
+			"frr_mroute_wrong_iif_packets{ip_version=4,type=(S,G),vrf=red}":       0,
+		}
+		if mrouteFlows {
+			expectedMetrics["frr_mroute_flow_packets_total{group=239.1.1.1,ip_version=4,source=*,vrf=default}"] = 0
+			expectedMetrics["frr_mroute_flow_packets_total{group=239.1.1.1,ip_version=4,source=10.0.1.5,vrf=default}"] = 1500
+			expectedMetrics["frr_mroute_flow_packets_total{group=239.1.1.1,ip_version=4,source=10.0.1.6,vrf=default}"] = 500
+			expectedMetrics["frr_mroute_flow_packets_total{group=239.1.1.2,ip_version=4,source=*,vrf=default}"] = 10
+			expectedMetrics["frr_mroute_flow_bytes_total{group=239.1.1.1,ip_version=4,source=*,vrf=default}"] = 0
+			expectedMetrics["frr_mroute_flow_bytes_total{group=239.1.1.1,ip_version=4,source=10.0.1.5,vrf=default}"] = 1500000
+			expectedMetrics["frr_mroute_flow_bytes_total{group=239.1.1.1,ip_version=4,source=10.0.1.6,vrf=default}"] = 250000
+			expectedMetrics["frr_mroute_flow_bytes_total{group=239.1.1.2,ip_version=4,source=*,vrf=default}"] = 1000
+		}
+
+		gotMetrics := prepareMetrics(ch, t)
+		compareMetrics(t, gotMetrics, expectedMetrics)
+	}
+}
+
+func TestProcessMSDPPeer(t *testing.T) {
+	msdpPeer := []byte(`{
+  "default":{
+    "10.0.0.2":{"peer":"10.0.0.2","local":"10.0.0.1","state":"established","upTime":"01:02:03","saCount":5},
+    "10.0.0.3":{"peer":"10.0.0.3","local":"10.0.0.1","state":"connecting","upTime":"00:05:00","saCount":0}
+  }
+}`)
+
+	ch := make(chan prometheus.Metric, 1024)
+	if err := processMSDPPeer(ch, msdpPeer); err != nil {
+		t.Errorf("error calling processMSDPPeer: %s", err)
+	}
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, map[string]float64{
+		"frr_msdp_peer_state{local=10.0.0.1,peer=10.0.0.2,vrf=default}":                        1,
+		"frr_msdp_peer_state{local=10.0.0.1,peer=10.0.0.3,vrf=default}":                        0,
+		"frr_msdp_peer_state_info{local=10.0.0.1,peer=10.0.0.2,state=established,vrf=default}": 1,
+		"frr_msdp_peer_state_info{local=10.0.0.1,peer=10.0.0.3,state=connecting,vrf=default}":  1,
+		"frr_msdp_peer_uptime_seconds{local=10.0.0.1,peer=10.0.0.2,vrf=default}":               3723,
+		"frr_msdp_peer_uptime_seconds{local=10.0.0.1,peer=10.0.0.3,vrf=default}":               0,
+		"frr_msdp_peer_sa_count_total{local=10.0.0.1,peer=10.0.0.2,vrf=default}":               5,
+		"frr_msdp_peer_sa_count_total{local=10.0.0.1,peer=10.0.0.3,vrf=default}":               0,
+	})
+}
+
+func TestProcessMSDPSA(t *testing.T) {
+	msdpSA := []byte(`{
+  "default":{
+    "239.1.1.1":{
+      "10.0.1.5":{"source":"10.0.1.5","group":"239.1.1.1","rp":"-","local":"yes","sptSetup":"yes","upTime":"00:10:00"},
+      "10.0.2.5":{"source":"10.0.2.5","group":"239.1.1.1","rp":"10.0.0.2","local":"no","sptSetup":"no","upTime":"00:01:00"}
+    },
+    "239.1.1.2":{
+      "10.0.2.6":{"source":"10.0.2.6","group":"239.1.1.2","rp":"10.0.0.2","local":"no","sptSetup":"no","upTime":"00:00:30"}
+    }
+  },
+  "red":{}
+}`)
+
+	ch := make(chan prometheus.Metric, 1024)
+	if err := processMSDPSA(ch, msdpSA); err != nil {
+		t.Errorf("error calling processMSDPSA: %s", err)
+	}
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, map[string]float64{
+		"frr_msdp_sa_count_total{vrf=default}": 3,
+		"frr_msdp_sa_count_total{vrf=red}":     0,
+	})
+}
+
+func TestProcessPIMRPInfo(t *testing.T) {
+	defer func() { pimLastRP, pimRPChanges = map[string]string{}, map[string]float64{} }()
+
+	// 239.0.0.0/8 has both a static RP and an RP learnt from the BSR.
+	pimRPInfo := []byte(`{
+  "default":{
+    "10.0.0.1":[
+      {"rpAddress":"10.0.0.1","outboundInterface":"lo","iAmRP":true,"group":"224.0.0.0/4","source":"Static"},
+      {"rpAddress":"10.0.0.1","outboundInterface":"lo","iAmRP":true,"prefixList":"PIM-SSM-EXCLUDE","source":"Static"},
+      {"rpAddress":"10.0.0.1","outboundInterface":"lo","iAmRP":true,"group":"239.0.0.0/8","source":"Static"}
+    ],
+    "10.0.0.2":[
+      {"rpAddress":"10.0.0.2","outboundInterface":"swp1","iAmRP":false,"group":"239.0.0.0/8","source":"BSR"}
+    ]
+  }
+}`)
+	pimRPInfoChanged := []byte(`{
+  "default":{
+    "10.0.0.1":[
+      {"rpAddress":"10.0.0.1","outboundInterface":"lo","iAmRP":true,"group":"224.0.0.0/4","source":"Static"},
+      {"rpAddress":"10.0.0.1","outboundInterface":"lo","iAmRP":true,"prefixList":"PIM-SSM-EXCLUDE","source":"Static"},
+      {"rpAddress":"10.0.0.1","outboundInterface":"lo","iAmRP":true,"group":"239.0.0.0/8","source":"Static"}
+    ],
+    "10.0.0.3":[
+      {"rpAddress":"10.0.0.3","outboundInterface":"swp2","iAmRP":false,"group":"239.0.0.0/8","source":"BSR"}
+    ]
+  }
+}`)
+
+	for i, rpInfo := range [][]byte{pimRPInfo, pimRPInfoChanged, pimRPInfoChanged} {
+		ch := make(chan prometheus.Metric, 1024)
+		if err := processPIMRPInfo(ch, rpInfo); err != nil {
+			t.Errorf("error calling processPIMRPInfo: %s", err)
+		}
+		close(ch)
+
+		rp := []string{"10.0.0.2", "10.0.0.3", "10.0.0.3"}[i]
+		gotMetrics := prepareMetrics(ch, t)
+		compareMetrics(t, gotMetrics, map[string]float64{
+			"frr_pim_rp_info{group=224.0.0.0/4,prefix_list=,rp=10.0.0.1,source=Static,vrf=default}":     1,
+			"frr_pim_rp_info{group=,prefix_list=PIM-SSM-EXCLUDE,rp=10.0.0.1,source=Static,vrf=default}": 1,
+			"frr_pim_rp_info{group=239.0.0.0/8,prefix_list=,rp=10.0.0.1,source=Static,vrf=default}":     1,
+			"frr_pim_rp_info{group=239.0.0.0/8,prefix_list=,rp=" + rp + ",source=BSR,vrf=default}":      1,
+			"frr_pim_rp_changes_total{group=224.0.0.0/4,prefix_list=,source=Static,vrf=default}":        0,
+			"frr_pim_rp_changes_total{group=,prefix_list=PIM-SSM-EXCLUDE,source=Static,vrf=default}":    0,
+			"frr_pim_rp_changes_total{group=239.0.0.0/8,prefix_list=,source=Static,vrf=default}":        0,
+			"frr_pim_rp_changes_total{group=239.0.0.0/8,prefix_list=,source=BSR,vrf=default}":           []float64{0, 1, 1}[i],
+		})
+	}
+
+	// The group ranges that are no longer mapped to an RP are removed.
+	ch := make(chan prometheus.Metric, 1024)
+	if err := processPIMRPInfo(ch, []byte(`{"default":{}}`)); err != nil {
+		t.Errorf("error calling processPIMRPInfo: %s", err)
+	}
+	close(ch)
+	if len(pimLastRP) != 0 || len(pimRPChanges) != 0 {
+		t.Errorf("expected the rp of all group ranges to be removed, got %v and %v", pimLastRP, pimRPChanges)
+	}
+}
+
+func TestProcessPIMUpstream(t *testing.T) {
+	pimUpstream := []byte(`{
+  "default":{
+    "239.1.1.1":{
+      "*":{"source":"*","group":"239.1.1.1","inboundInterface":"swp1","rpfAddress":"10.0.0.2","state":"J","joinState":"Joined","regState":"RegNoInfo","upTime":"00:10:00","sptBit":0},
+      "10.0.1.5":{"source":"10.0.1.5","group":"239.1.1.1","inboundInterface":"swp2","rpfAddress":"10.0.1.5","state":"J","joinState":"Joined","regState":"RegJoined","upTime":"00:09:00","sptBit":1}
+    },
+    "239.1.1.2":{
+      "10.0.1.6":{"source":"10.0.1.6","group":"239.1.1.2","inboundInterface":"swp2","rpfAddress":"10.0.1.6","state":"NJ","joinState":"NotJoined","regState":"RegPrune","upTime":"00:01:00","sptBit":0}
+    }
+  }
+}`)
+
+	ch := make(chan prometheus.Metric, 1024)
+	if err := processPIMUpstream(ch, pimUpstream); err != nil {
+		t.Errorf("error calling processPIMUpstream: %s", err)
+	}
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, map[string]float64{
+		"frr_pim_upstream_join_state_count_total{state=Joined,vrf=default}":        2,
+		"frr_pim_upstream_join_state_count_total{state=NotJoined,vrf=default}":     1,
+		"frr_pim_upstream_register_state_count_total{state=RegNoInfo,vrf=default}": 1,
+		"frr_pim_upstream_register_state_count_total{state=RegJoined,vrf=default}": 1,
+		"frr_pim_upstream_register_state_count_total{state=RegPrune,vrf=default}":  1,
+	})
+}
+
+func TestProcessPIMBSR(t *testing.T) {
+	pimBSR := []byte(`{
+  "bsr":"10.0.0.1",
+  "priority":64,
+  "fragmentTag":4321,
+  "state":"ACCEPT_PREFERRED",
+  "upTime":"01:00:05",
+  "lastBsmSeen":"00:00:20"
+}`)
+	pimNoBSR := []byte(`{"bsr":"0.0.0.0","priority":0,"fragmentTag":0,"state":"NO_INFO","upTime":"--:--:--","lastBsmSeen":"--:--:--"}`)
+
+	ch := make(chan prometheus.Metric, 1024)
+	for _, bsr := range [][]byte{pimBSR, pimNoBSR} {
+		if err := processPIMBSR(ch, bsr); err != nil {
+			t.Errorf("error calling processPIMBSR: %s", err)
+		}
+	}
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, map[string]float64{
+		"frr_pim_bsr_info{bsr=10.0.0.1,state=ACCEPT_PREFERRED}": 1,
+		"frr_pim_bsr_info{bsr=0.0.0.0,state=NO_INFO}":           1,
+		"frr_pim_bsr_priority{bsr=10.0.0.1}":                    64,
+		"frr_pim_bsr_uptime_seconds{bsr=10.0.0.1}":              3605,
+	})
+}
+
+func TestProcessPIMBSRPInfo(t *testing.T) {
+	pimBSRPInfo := []byte(`{
+  "BSR Address":"10.0.0.1",
+  "224.0.0.0/4":{
+    "10.0.0.2":{"Rp Address":"10.0.0.2","Rp HoldTime":150,"Rp Priority":0,"Hash Val":1234},
+    "10.0.0.3":{"Rp Address":"10.0.0.3","Rp HoldTime":150,"Rp Priority":10,"Hash Val":5678},
+    "Pending RP count":0
+  },
+  "239.0.0.0/8":{
+    "Pending RP count":1
+  }
+}`)
+
+	ch := make(chan prometheus.Metric, 1024)
+	if err := processPIMBSRPInfo(ch, pimBSRPInfo); err != nil {
+		t.Errorf("error calling processPIMBSRPInfo: %s", err)
+	}
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, map[string]float64{
+		"frr_pim_bsr_candidate_rps_count_total{group=224.0.0.0/4}": 2,
+		"frr_pim_bsr_candidate_rps_count_total{group=239.0.0.0/8}": 0,
+		"frr_pim_bsr_pending_rps_count_total{group=224.0.0.0/4}":   0,
+		"frr_pim_bsr_pending_rps_count_total{group=239.0.0.0/8}":   1,
+	})
+}
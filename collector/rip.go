@@ -0,0 +1,244 @@
+package collector
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	ripSubsystem   = "rip"
+	ripngSubsystem = "ripng"
+
+	ripDesc   = getRIPDesc(ripSubsystem, "RIP")
+	ripngDesc = getRIPDesc(ripngSubsystem, "RIPng")
+
+	ripErrors        = []error{}
+	totalRIPErrors   = 0.0
+	ripngErrors      = []error{}
+	totalRIPngErrors = 0.0
+
+	// ripRouteTypes maps the codes of 'show ip rip' and 'show ipv6 ripng' to the route type label.
+	ripRouteTypes = map[string]string{
+		"R": "rip",
+		"C": "connected",
+		"S": "static",
+		"O": "ospf",
+		"B": "bgp",
+		"K": "kernel",
+	}
+	ripUpdateRegexp  = regexp.MustCompile(`Sending updates every (\d+) seconds`)
+	ripTimeoutRegexp = regexp.MustCompile(`Timeout after (\d+) seconds, garbage collect after (\d+) seconds`)
+	ripRouteRegexp   = regexp.MustCompile(`^([A-Z])\([a-zA-Z/]+\)\s+(\S+)`)
+)
+
+func getRIPDesc(subsystem string, protocol string) map[string]*prometheus.Desc {
+	return map[string]*prometheus.Desc{
+		"updateInterval": colPromDesc(subsystem, "update_interval_seconds", fmt.Sprintf("Configured interval between %s updates.", protocol), nil),
+		"timeout":        colPromDesc(subsystem, "timeout_seconds", fmt.Sprintf("Configured time after which %s routes that are not updated become invalid.", protocol), nil),
+		"garbageCollect": colPromDesc(subsystem, "garbage_collect_seconds", fmt.Sprintf("Configured time after which invalid %s routes are removed.", protocol), nil),
+		"neighbors":      colPromDesc(subsystem, "neighbors_count_total", fmt.Sprintf("Number of %s neighbors (routing information sources) that updates have been received from.", protocol), nil),
+
+		"routes": colPromDesc(subsystem, "routes_count_total", fmt.Sprintf("Number of routes in the %s routing table per route type, such as rip, connected or static.", protocol), []string{"type"}),
+	}
+}
+
+// RIPCollector collects RIP metrics, implemented as per prometheus.Collector interface.
+type RIPCollector struct{}
+
+// NewRIPCollector returns a RIPCollector struct.
+func NewRIPCollector() *RIPCollector {
+	return &RIPCollector{}
+}
+
+// Name of the collector. Used to populate flag name.
+func (*RIPCollector) Name() string {
+	return ripSubsystem
+}
+
+// Help describes the metrics this collector scrapes. Used to populate flag help.
+func (*RIPCollector) Help() string {
+	return "Collect RIP Metrics"
+}
+
+// EnabledByDefault describes whether this collector is enabled by default. Used to populate flag default.
+func (*RIPCollector) EnabledByDefault() bool {
+	return false
+}
+
+// Describe implemented as per the prometheus.Collector interface.
+func (*RIPCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range ripDesc {
+		ch <- desc
+	}
+}
+
+// Collect implemented as per the prometheus.Collector interface.
+func (c *RIPCollector) Collect(ch chan<- prometheus.Metric) {
+	ripErrors = []error{}
+
+	// The status and routing table of ripd are only available as text.
+	status, err := execVtyshCommand("-c", "show ip rip status")
+	if err != nil {
+		ripErrors = append(ripErrors, fmt.Errorf("cannot get rip status: %s", err))
+	} else {
+		processRIPStatus(ch, status, ripDesc)
+	}
+
+	routes, err := execVtyshCommand("-c", "show ip rip")
+	if err != nil {
+		ripErrors = append(ripErrors, fmt.Errorf("cannot get rip routes: %s", err))
+	} else {
+		processRIPRoutes(ch, routes, ripDesc)
+	}
+
+	totalRIPErrors += float64(len(ripErrors))
+}
+
+// CollectErrors returns what errors have been gathered.
+func (*RIPCollector) CollectErrors() []error {
+	return ripErrors
+}
+
+// CollectTotalErrors returns total errors.
+func (*RIPCollector) CollectTotalErrors() float64 {
+	return totalRIPErrors
+}
+
+// RIPngCollector collects RIPng metrics, implemented as per prometheus.Collector interface.
+type RIPngCollector struct{}
+
+// NewRIPngCollector returns a RIPngCollector struct.
+func NewRIPngCollector() *RIPngCollector {
+	return &RIPngCollector{}
+}
+
+// Name of the collector. Used to populate flag name.
+func (*RIPngCollector) Name() string {
+	return ripngSubsystem
+}
+
+// Help describes the metrics this collector scrapes. Used to populate flag help.
+func (*RIPngCollector) Help() string {
+	return "Collect RIPng Metrics"
+}
+
+// EnabledByDefault describes whether this collector is enabled by default. Used to populate flag default.
+func (*RIPngCollector) EnabledByDefault() bool {
+	return false
+}
+
+// Describe implemented as per the prometheus.Collector interface.
+func (*RIPngCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range ripngDesc {
+		ch <- desc
+	}
+}
+
+// Collect implemented as per the prometheus.Collector interface.
+func (c *RIPngCollector) Collect(ch chan<- prometheus.Metric) {
+	ripngErrors = []error{}
+
+	status, err := execVtyshCommand("-c", "show ipv6 ripng status")
+	if err != nil {
+		ripngErrors = append(ripngErrors, fmt.Errorf("cannot get ripng status: %s", err))
+	} else {
+		processRIPStatus(ch, status, ripngDesc)
+	}
+
+	routes, err := execVtyshCommand("-c", "show ipv6 ripng")
+	if err != nil {
+		ripngErrors = append(ripngErrors, fmt.Errorf("cannot get ripng routes: %s", err))
+	} else {
+		processRIPRoutes(ch, routes, ripngDesc)
+	}
+
+	totalRIPngErrors += float64(len(ripngErrors))
+}
+
+// CollectErrors returns what errors have been gathered.
+func (*RIPngCollector) CollectErrors() []error {
+	return ripngErrors
+}
+
+// CollectTotalErrors returns total errors.
+func (*RIPngCollector) CollectTotalErrors() float64 {
+	return totalRIPngErrors
+}
+
+// processRIPStatus processes the output of 'show ip rip status' and 'show ipv6 ripng status', such as:
+//
+//	Routing Protocol is "rip"
+//	  Sending updates every 30 seconds with +/-50%, next due in 14 seconds
+//	  Timeout after 180 seconds, garbage collect after 120 seconds
+//	  ...
+//	  Routing Information Sources:
+//	    Gateway          BadPackets BadRoutes  Distance Last Update
+//	    10.0.0.2                 0         0       120   00:00:12
+//	  Distance: (default is 120)
+func processRIPStatus(ch chan<- prometheus.Metric, output []byte, desc map[string]*prometheus.Desc) {
+	neighbors := 0.0
+	sources := false
+	for _, line := range strings.Split(string(output), "\n") {
+		if match := ripUpdateRegexp.FindStringSubmatch(line); match != nil {
+			if interval, err := strconv.ParseFloat(match[1], 64); err == nil {
+				newGauge(ch, desc["updateInterval"], interval)
+			}
+			continue
+		}
+		if match := ripTimeoutRegexp.FindStringSubmatch(line); match != nil {
+			if timeout, err := strconv.ParseFloat(match[1], 64); err == nil {
+				newGauge(ch, desc["timeout"], timeout)
+			}
+			if garbageCollect, err := strconv.ParseFloat(match[2], 64); err == nil {
+				newGauge(ch, desc["garbageCollect"], garbageCollect)
+			}
+			continue
+		}
+		if strings.Contains(line, "Routing Information Sources:") {
+			sources = true
+			continue
+		}
+		// The routing information sources are listed one per line until the distance.
+		if sources {
+			if fields := strings.Fields(line); len(fields) > 0 && net.ParseIP(fields[0]) != nil {
+				neighbors++
+			} else if len(fields) > 0 && fields[0] == "Distance:" {
+				sources = false
+			}
+		}
+	}
+	newGauge(ch, desc["neighbors"], neighbors)
+}
+
+// processRIPRoutes processes the routes of 'show ip rip' and 'show ipv6 ripng', such as:
+//
+//	     Network            Next Hop         Metric From            Tag Time
+//	C(i) 10.0.0.0/24        0.0.0.0               1 self              0
+//	R(n) 192.168.1.0/24     10.0.0.2              2 10.0.0.2          0 02:58
+func processRIPRoutes(ch chan<- prometheus.Metric, output []byte, desc map[string]*prometheus.Desc) {
+	// A route with multiple nexthops is listed once per nexthop.
+	prefixes := map[string]map[string]bool{}
+	for _, line := range strings.Split(string(output), "\n") {
+		match := ripRouteRegexp.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		routeType, exist := ripRouteTypes[match[1]]
+		if !exist {
+			routeType = match[1]
+		}
+		if _, exist := prefixes[routeType]; !exist {
+			prefixes[routeType] = map[string]bool{}
+		}
+		prefixes[routeType][match[2]] = true
+	}
+	for routeType, routes := range prefixes {
+		// The labels are "type"
+		newGauge(ch, desc["routes"], float64(len(routes)), routeType)
+	}
+}
@@ -0,0 +1,103 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestProcessRIPStatus(t *testing.T) {
+	ripStatus := []byte(`Routing Protocol is "rip"
+  Sending updates every 30 seconds with +/-50%, next due in 14 seconds
+  Timeout after 180 seconds, garbage collect after 120 seconds
+  Outgoing update filter list for all interface is not set
+  Incoming update filter list for all interface is not set
+  Default redistribution metric is 1
+  Redistributing: connected
+  Default version control: send version 2, receive any version
+    Interface        Send  Recv   Key-chain
+    be0              2     2
+    eth1             2     2
+  Routing for Networks:
+    10.0.0.0/8
+  Routing Information Sources:
+    Gateway          BadPackets BadRoutes  Distance Last Update
+    10.0.0.2                 0         0       120   00:00:12
+    10.0.1.2                 1         0       120   00:00:25
+  Distance: (default is 120)
+`)
+	ripngStatus := []byte(`Routing Protocol is "RIPng"
+  Sending updates every 10 seconds with +/-50%, next due in 6 seconds
+  Timeout after 60 seconds, garbage collect after 40 seconds
+  Outgoing update filter list for all interface is not set
+  Incoming update filter list for all interface is not set
+  Default redistribution metric is 1
+  Redistributing:
+  Default version control: send version 1, receive version 1
+    Interface        Send  Recv
+    eth0             1     1
+  Routing for Networks:
+    eth0
+  Routing Information Sources:
+    Gateway          BadPackets BadRoutes  Distance Last Update
+    fe80::1                  0         0       120   00:00:04
+`)
+
+	ch := make(chan prometheus.Metric, 1024)
+	processRIPStatus(ch, ripStatus, ripDesc)
+	processRIPStatus(ch, ripngStatus, ripngDesc)
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, map[string]float64{
+		"frr_rip_update_interval_seconds{}":   30,
+		"frr_rip_timeout_seconds{}":           180,
+		"frr_rip_garbage_collect_seconds{}":   120,
+		"frr_rip_neighbors_count_total{}":     2,
+		"frr_ripng_update_interval_seconds{}": 10,
+		"frr_ripng_timeout_seconds{}":         60,
+		"frr_ripng_garbage_collect_seconds{}": 40,
+		"frr_ripng_neighbors_count_total{}":   1,
+	})
+}
+
+func TestProcessRIPRoutes(t *testing.T) {
+	ripRoutes := []byte(`Codes: R - RIP, C - connected, S - Static, O - OSPF, B - BGP
+Sub-codes:
+      (n) - normal, (s) - static, (d) - default, (r) - redistribute,
+      (i) - interface
+
+     Network            Next Hop         Metric From            Tag Time
+C(i) 10.0.0.0/24        0.0.0.0               1 self              0
+C(i) 10.0.1.0/24        0.0.0.0               1 self              0
+R(n) 192.168.1.0/24     10.0.0.2              2 10.0.0.2          0 02:58
+R(n) 192.168.1.0/24     10.0.1.2              2 10.0.1.2          0 02:41
+R(n) 192.168.2.0/24     10.0.0.2              3 10.0.0.2          0 02:58
+S(r) 172.16.0.0/16      10.0.0.254            1 self              0
+`)
+	ripngRoutes := []byte(`Codes: R - RIPng, C - connected, S - Static, O - OSPF, B - BGP
+Sub-codes:
+      (n) - normal, (s) - static, (d) - default, (r) - redistribute,
+      (i) - interface, (a/S) - aggregated/Suppressed
+
+   Network      Next Hop                      Via     Metric Tag Time
+C(i) 2001:db8::/64
+                 ::                          self       1    0
+R(n) 2001:db8:1::/64
+                 fe80::1                     eth0       2    0  02:55
+`)
+
+	ch := make(chan prometheus.Metric, 1024)
+	processRIPRoutes(ch, ripRoutes, ripDesc)
+	processRIPRoutes(ch, ripngRoutes, ripngDesc)
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, map[string]float64{
+		"frr_rip_routes_count_total{type=connected}":   2,
+		"frr_rip_routes_count_total{type=rip}":         2,
+		"frr_rip_routes_count_total{type=static}":      1,
+		"frr_ripng_routes_count_total{type=connected}": 1,
+		"frr_ripng_routes_count_total{type=rip}":       1,
+	})
+}
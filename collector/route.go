@@ -0,0 +1,205 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	routeSubsystem = "route"
+
+	routeLabels = []string{"vrf", "afi", "type"}
+	routeDesc   = map[string]*prometheus.Desc{
+		"rib": colPromDesc(routeSubsystem, "rib_count_total", "Number of routes in the RIB per route type, such as connected, static, ebgp or ospf.", routeLabels),
+		"fib": colPromDesc(routeSubsystem, "fib_count_total", "Number of routes installed in the FIB per route type, such as connected, static, ebgp or ospf.", routeLabels),
+
+		"fibOffloaded": colPromDesc(routeSubsystem, "fib_offloaded_count_total", "Number of routes installed in the FIB that are offloaded to the hardware per route type.", routeLabels),
+		"fibTrapped":   colPromDesc(routeSubsystem, "fib_trapped_count_total", "Number of routes installed in the FIB that trap packets to the CPU per route type.", routeLabels),
+
+		"dplaneUpdates":       colPromDesc(routeSubsystem, "dplane_updates_total", "Number of route updates processed by the zebra dataplane.", nil),
+		"dplaneUpdateErrors":  colPromDesc(routeSubsystem, "dplane_update_errors_total", "Number of route updates that the zebra dataplane failed to install in the FIB.", nil),
+		"dplaneOtherErrors":   colPromDesc(routeSubsystem, "dplane_other_errors_total", "Number of updates other than route updates that the zebra dataplane failed to install, such as LSP or pseudowire updates.", nil),
+		"dplaneQueueDepth":    colPromDesc(routeSubsystem, "dplane_queue_depth", "Number of route updates queued for installation in the FIB by the zebra dataplane.", nil),
+		"dplaneQueueLimit":    colPromDesc(routeSubsystem, "dplane_queue_limit", "Maximum number of route updates the zebra dataplane queues before updates are deferred.", nil),
+		"dplaneQueueMaxDepth": colPromDesc(routeSubsystem, "dplane_queue_max_depth", "Highest number of route updates queued by the zebra dataplane since zebra started.", nil),
+	}
+	routeErrors      = []error{}
+	totalRouteErrors = 0.0
+
+	// routeAFIs maps the address family label to the address family of the route commands.
+	routeAFIs = map[string]string{
+		"ipv4": "ip",
+		"ipv6": "ipv6",
+	}
+
+	// routeDplaneCounters and routeDplaneGauges map the counters of 'show zebra dplane' to the desc of the metric.
+	routeDplaneCounters = map[string]string{
+		"Route updates":       "dplaneUpdates",
+		"Route update errors": "dplaneUpdateErrors",
+		"Other errors":        "dplaneOtherErrors",
+	}
+	routeDplaneGauges = map[string]string{
+		"Route update queue depth": "dplaneQueueDepth",
+		"Route update queue limit": "dplaneQueueLimit",
+		"Route update queue max":   "dplaneQueueMaxDepth",
+	}
+	routeDplaneRegexp = regexp.MustCompile(`^\s*([^:]+?)\s*:\s*(\d+)\s*$`)
+)
+
+// RouteCollector collects RIB metrics, implemented as per prometheus.Collector interface.
+type RouteCollector struct{}
+
+// NewRouteCollector returns a RouteCollector struct.
+func NewRouteCollector() *RouteCollector {
+	return &RouteCollector{}
+}
+
+// Name of the collector. Used to populate flag name.
+func (*RouteCollector) Name() string {
+	return routeSubsystem
+}
+
+// Help describes the metrics this collector scrapes. Used to populate flag help.
+func (*RouteCollector) Help() string {
+	return "Collect RIB Route Summary Metrics"
+}
+
+// EnabledByDefault describes whether this collector is enabled by default. Used to populate flag default.
+func (*RouteCollector) EnabledByDefault() bool {
+	return false
+}
+
+// Describe implemented as per the prometheus.Collector interface.
+func (*RouteCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range routeDesc {
+		ch <- desc
+	}
+}
+
+// Collect implemented as per the prometheus.Collector interface.
+func (c *RouteCollector) Collect(ch chan<- prometheus.Metric) {
+	routeErrors = []error{}
+
+	// zebra prints a JSON object without the name of the VRF for each VRF of 'show ip route vrf all summary json', so the
+	// summary is collected per VRF.
+	vrfs := []string{"default"}
+	jsonVRF, err := execVtyshCommand("-c", "show vrf json")
+	if err != nil {
+		routeErrors = append(routeErrors, fmt.Errorf("cannot get vrfs: %s", err))
+	} else {
+		if vrfNames, err := processVRFNames(jsonVRF); err != nil {
+			routeErrors = append(routeErrors, err)
+		} else {
+			vrfs = append(vrfs, vrfNames...)
+		}
+	}
+
+	for _, vrf := range vrfs {
+		for afi, ip := range routeAFIs {
+			jsonRouteSummary, err := execVtyshCommand("-c", fmt.Sprintf("show %s route vrf %s summary json", ip, vrf))
+			if err != nil {
+				routeErrors = append(routeErrors, fmt.Errorf("cannot get %s route summary of vrf %s: %s", afi, vrf, err))
+			} else {
+				if err := processRouteSummary(ch, jsonRouteSummary, vrf, afi); err != nil {
+					routeErrors = append(routeErrors, err)
+				}
+			}
+		}
+	}
+
+	// The dataplane counters are only available as text.
+	dplane, err := execVtyshCommand("-c", "show zebra dplane")
+	if err != nil {
+		routeErrors = append(routeErrors, fmt.Errorf("cannot get zebra dplane: %s", err))
+	} else {
+		processRouteDplane(ch, dplane)
+	}
+
+	totalRouteErrors += float64(len(routeErrors))
+}
+
+// CollectErrors returns what errors have been gathered.
+func (*RouteCollector) CollectErrors() []error {
+	return routeErrors
+}
+
+// CollectTotalErrors returns total errors.
+func (*RouteCollector) CollectTotalErrors() float64 {
+	return totalRouteErrors
+}
+
+// processVRFNames returns the names of the VRFs other than the default VRF, which is not included in 'show vrf json'.
+func processVRFNames(jsonVRF []byte) ([]string, error) {
+	var vrfs map[string]json.RawMessage
+	if err := json.Unmarshal(jsonVRF, &vrfs); err != nil {
+		return nil, fmt.Errorf("cannot unmarshal vrf json: %s", err)
+	}
+
+	vrfNames := []string{}
+	for vrfName := range vrfs {
+		if vrfName == "default" {
+			continue
+		}
+		vrfNames = append(vrfNames, vrfName)
+	}
+	sort.Strings(vrfNames)
+	return vrfNames, nil
+}
+
+func processRouteSummary(ch chan<- prometheus.Metric, jsonRouteSummary []byte, vrfName string, afi string) error {
+	var routeSummary struct {
+		Routes []struct {
+			Type         string
+			Rib          float64
+			Fib          float64
+			FibOffLoaded float64 `json:"fibOffLoaded"`
+			FibTrapped   float64
+		}
+	}
+	if err := json.Unmarshal(jsonRouteSummary, &routeSummary); err != nil {
+		return fmt.Errorf("cannot unmarshal route summary json: %s", err)
+	}
+
+	for _, route := range routeSummary.Routes {
+		// The labels are "vrf", "afi", "type"
+		labels := []string{strings.ToLower(vrfName), afi, route.Type}
+		newGauge(ch, routeDesc["rib"], route.Rib, labels...)
+		newGauge(ch, routeDesc["fib"], route.Fib, labels...)
+		newGauge(ch, routeDesc["fibOffloaded"], route.FibOffLoaded, labels...)
+		newGauge(ch, routeDesc["fibTrapped"], route.FibTrapped, labels...)
+	}
+	return nil
+}
+
+// processRouteDplane processes the route counters of 'show zebra dplane', such as:
+//
+//	Zebra dataplane:
+//	Route updates:            24
+//	Route update errors:      0
+//	Other errors       :      0
+//	Route update queue limit: 200
+//	Route update queue depth: 0
+//	Route update queue max:   3
+func processRouteDplane(ch chan<- prometheus.Metric, output []byte) {
+	for _, line := range strings.Split(string(output), "\n") {
+		match := routeDplaneRegexp.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		value, err := strconv.ParseFloat(match[2], 64)
+		if err != nil {
+			continue
+		}
+		if descName, exist := routeDplaneCounters[match[1]]; exist {
+			newCounter(ch, routeDesc[descName], value)
+		} else if descName, exist := routeDplaneGauges[match[1]]; exist {
+			newGauge(ch, routeDesc[descName], value)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package collector
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestProcessVRFNames(t *testing.T) {
+	jsonVRF := []byte(`{
+  "red":{"vrfId":5,"vrfName":"red","tableId":1001,"state":"active"},
+  "Blue":{"vrfId":6,"vrfName":"Blue","tableId":1002,"state":"active"}
+}`)
+
+	vrfNames, err := processVRFNames(jsonVRF)
+	if err != nil {
+		t.Errorf("error calling processVRFNames: %s", err)
+	}
+	if want := []string{"Blue", "red"}; !reflect.DeepEqual(vrfNames, want) {
+		t.Errorf("got vrfs %v, want %v", vrfNames, want)
+	}
+}
+
+func TestProcessRouteSummary(t *testing.T) {
+	routeSummaryV4 := []byte(`{
+  "routes":[
+    {"fib":2,"rib":2,"fibOffLoaded":0,"fibTrapped":0,"type":"connected"},
+    {"fib":2,"rib":2,"fibOffLoaded":0,"fibTrapped":0,"type":"local"},
+    {"fib":1,"rib":1,"fibOffLoaded":0,"fibTrapped":0,"type":"static"},
+    {"fib":3,"rib":5,"fibOffLoaded":0,"fibTrapped":0,"type":"ospf"},
+    {"fib":100,"rib":120,"fibOffLoaded":90,"fibTrapped":2,"type":"ebgp"}
+  ],
+  "routesTotal":130,
+  "routesTotalFib":108
+}`)
+	routeSummaryV6 := []byte(`{
+  "routes":[
+    {"fib":1,"rib":1,"fibOffLoaded":0,"fibTrapped":0,"type":"connected"}
+  ],
+  "routesTotal":1,
+  "routesTotalFib":1
+}`)
+
+	ch := make(chan prometheus.Metric, 1024)
+	if err := processRouteSummary(ch, routeSummaryV4, "default", "ipv4"); err != nil {
+		t.Errorf("error calling processRouteSummary ipv4: %s", err)
+	}
+	if err := processRouteSummary(ch, routeSummaryV6, "Blue", "ipv6"); err != nil {
+		t.Errorf("error calling processRouteSummary ipv6: %s", err)
+	}
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, map[string]float64{
+		"frr_route_rib_count_total{afi=ipv4,type=connected,vrf=default}": 2,
+		"frr_route_rib_count_total{afi=ipv4,type=local,vrf=default}":     2,
+		"frr_route_rib_count_total{afi=ipv4,type=static,vrf=default}":    1,
+		"frr_route_rib_count_total{afi=ipv4,type=ospf,vrf=default}":      5,
+		"frr_route_rib_count_total{afi=ipv4,type=ebgp,vrf=default}":      120,
+		"frr_route_rib_count_total{afi=ipv6,type=connected,vrf=blue}":    1,
+		"frr_route_fib_count_total{afi=ipv4,type=connected,vrf=default}": 2,
+		"frr_route_fib_count_total{afi=ipv4,type=local,vrf=default}":     2,
+		"frr_route_fib_count_total{afi=ipv4,type=static,vrf=default}":    1,
+		"frr_route_fib_count_total{afi=ipv4,type=ospf,vrf=default}":      3,
+		"frr_route_fib_count_total{afi=ipv4,type=ebgp,vrf=default}":      100,
+		"frr_route_fib_count_total{afi=ipv6,type=connected,vrf=blue}":    1,
+
+		"frr_route_fib_offloaded_count_total{afi=ipv4,type=connected,vrf=default}": 0,
+		"frr_route_fib_offloaded_count_total{afi=ipv4,type=local,vrf=default}":     0,
+		"frr_route_fib_offloaded_count_total{afi=ipv4,type=static,vrf=default}":    0,
+		"frr_route_fib_offloaded_count_total{afi=ipv4,type=ospf,vrf=default}":      0,
+		"frr_route_fib_offloaded_count_total{afi=ipv4,type=ebgp,vrf=default}":      90,
+		"frr_route_fib_offloaded_count_total{afi=ipv6,type=connected,vrf=blue}":    0,
+		"frr_route_fib_trapped_count_total{afi=ipv4,type=connected,vrf=default}":   0,
+		"frr_route_fib_trapped_count_total{afi=ipv4,type=local,vrf=default}":       0,
+		"frr_route_fib_trapped_count_total{afi=ipv4,type=static,vrf=default}":      0,
+		"frr_route_fib_trapped_count_total{afi=ipv4,type=ospf,vrf=default}":        0,
+		"frr_route_fib_trapped_count_total{afi=ipv4,type=ebgp,vrf=default}":        2,
+		"frr_route_fib_trapped_count_total{afi=ipv6,type=connected,vrf=blue}":      0,
+	})
+}
+
+func TestProcessRouteDplane(t *testing.T) {
+	dplane := []byte(`Zebra dataplane:
+Route updates:            24
+Route update errors:      2
+Other errors       :      1
+Route update queue limit: 200
+Route update queue depth: 5
+Route update queue max:   30
+Dplane update yields:      3
+`)
+
+	ch := make(chan prometheus.Metric, 1024)
+	processRouteDplane(ch, dplane)
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, map[string]float64{
+		"frr_route_dplane_updates_total{}":       24,
+		"frr_route_dplane_update_errors_total{}": 2,
+		"frr_route_dplane_other_errors_total{}":  1,
+		"frr_route_dplane_queue_limit{}":         200,
+		"frr_route_dplane_queue_depth{}":         5,
+		"frr_route_dplane_queue_max_depth{}":     30,
+	})
+}
@@ -0,0 +1,111 @@
+package collector
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	routerIDSubsystem = "routerid"
+
+	routerIDDesc = map[string]*prometheus.Desc{
+		"info": promDesc("router_id_info", "Router ID of zebra per VRF, which the routing protocols use unless a router ID is configured for the protocol. Value is always 1.", []string{"vrf", "router_id"}),
+	}
+	routerIDErrors      = []error{}
+	totalRouterIDErrors = 0.0
+
+	// Matches both "router-id 10.0.0.1 vrf default" and "Router ID: 10.0.0.1" of older FRR versions.
+	routerIDRegexp = regexp.MustCompile(`(?i)^\s*router[- ]id:?\s+(\S+)`)
+)
+
+// RouterIDCollector collects router ID metrics, implemented as per prometheus.Collector interface.
+type RouterIDCollector struct{}
+
+// NewRouterIDCollector returns a RouterIDCollector struct.
+func NewRouterIDCollector() *RouterIDCollector {
+	return &RouterIDCollector{}
+}
+
+// Name of the collector. Used to populate flag name.
+func (*RouterIDCollector) Name() string {
+	return routerIDSubsystem
+}
+
+// Help describes the metrics this collector scrapes. Used to populate flag help.
+func (*RouterIDCollector) Help() string {
+	return "Collect Router ID Metrics"
+}
+
+// EnabledByDefault describes whether this collector is enabled by default. Used to populate flag default.
+func (*RouterIDCollector) EnabledByDefault() bool {
+	return false
+}
+
+// Describe implemented as per the prometheus.Collector interface.
+func (*RouterIDCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range routerIDDesc {
+		ch <- desc
+	}
+}
+
+// Collect implemented as per the prometheus.Collector interface.
+func (c *RouterIDCollector) Collect(ch chan<- prometheus.Metric) {
+	routerIDErrors = []error{}
+
+	vrfs := []string{"default"}
+	jsonVRF, err := execVtyshCommand("-c", "show vrf json")
+	if err != nil {
+		routerIDErrors = append(routerIDErrors, fmt.Errorf("cannot get vrfs: %s", err))
+	} else {
+		if vrfNames, err := processVRFNames(jsonVRF); err != nil {
+			routerIDErrors = append(routerIDErrors, err)
+		} else {
+			vrfs = append(vrfs, vrfNames...)
+		}
+	}
+
+	for _, vrf := range vrfs {
+		routerID, err := execVtyshCommand("-c", fmt.Sprintf("show ip router-id vrf %s", vrf))
+		if err != nil {
+			routerIDErrors = append(routerIDErrors, fmt.Errorf("cannot get router-id of vrf %s: %s", vrf, err))
+		} else {
+			processRouterID(ch, routerID, vrf)
+		}
+	}
+
+	totalRouterIDErrors += float64(len(routerIDErrors))
+}
+
+// CollectErrors returns what errors have been gathered.
+func (*RouterIDCollector) CollectErrors() []error {
+	return routerIDErrors
+}
+
+// CollectTotalErrors returns total errors.
+func (*RouterIDCollector) CollectTotalErrors() float64 {
+	return totalRouterIDErrors
+}
+
+// processRouterID parses the output of 'show ip router-id vrf NAME', such as:
+//
+//	zebra:
+//	     router-id 10.0.0.1 vrf default
+func processRouterID(ch chan<- prometheus.Metric, output []byte, vrfName string) {
+	for _, line := range strings.Split(string(output), "\n") {
+		match := routerIDRegexp.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		// zebra shows 0.0.0.0 until an address is configured that the router ID can be taken from.
+		if ip := net.ParseIP(match[1]); ip == nil || ip.IsUnspecified() {
+			return
+		}
+		// The labels are "vrf", "router_id"
+		newGauge(ch, routerIDDesc["info"], 1, strings.ToLower(vrfName), match[1])
+		return
+	}
+}
@@ -0,0 +1,23 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestProcessRouterID(t *testing.T) {
+	ch := make(chan prometheus.Metric, 1024)
+	processRouterID(ch, []byte("zebra:\n     router-id 10.0.0.1 vrf default\n"), "default")
+	processRouterID(ch, []byte("zebra:\n     router-id 192.168.1.1 vrf Red\n"), "Red")
+	processRouterID(ch, []byte("Router ID: 172.16.0.1\n"), "blue")
+	processRouterID(ch, []byte("zebra:\n     router-id 0.0.0.0 vrf green\n"), "green")
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, map[string]float64{
+		"frr_router_id_info{router_id=10.0.0.1,vrf=default}": 1,
+		"frr_router_id_info{router_id=192.168.1.1,vrf=red}":  1,
+		"frr_router_id_info{router_id=172.16.0.1,vrf=blue}":  1,
+	})
+}
@@ -0,0 +1,169 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	rpkiSubsystem = "rpki"
+
+	rpkiCacheLabels = []string{"host", "port", "mode", "preference"}
+	rpkiDesc        = map[string]*prometheus.Desc{
+		"cacheUp":        colPromDesc(rpkiSubsystem, "cache_connection_up", "Whether the RPKI cache server is connected (1 = connected, 0 = not connected).", rpkiCacheLabels),
+		"cacheConnected": colPromDesc(rpkiSubsystem, "cache_connected_group", "Preference group of the connected RPKI cache servers.", nil),
+		"prefixes":       colPromDesc(rpkiSubsystem, "prefixes_count_total", "Number of validated ROA prefixes received from the RPKI cache servers.", []string{"afi"}),
+		"routes":         colPromDesc(rpkiSubsystem, "bgp_routes_count_total", "Number of BGP unicast prefixes per RPKI validation state.", []string{"afi", "state"}),
+	}
+	rpkiErrors      = []error{}
+	totalRPKIErrors = 0.0
+
+	rpkiStates = []string{"valid", "invalid", "notfound"}
+)
+
+// RPKICollector collects RPKI metrics, implemented as per prometheus.Collector interface.
+type RPKICollector struct{}
+
+// NewRPKICollector returns a RPKICollector struct.
+func NewRPKICollector() *RPKICollector {
+	return &RPKICollector{}
+}
+
+// Name of the collector. Used to populate flag name.
+func (*RPKICollector) Name() string {
+	return rpkiSubsystem
+}
+
+// Help describes the metrics this collector scrapes. Used to populate flag help.
+func (*RPKICollector) Help() string {
+	return "Collect RPKI Metrics"
+}
+
+// EnabledByDefault describes whether this collector is enabled by default. Used to populate flag default.
+func (*RPKICollector) EnabledByDefault() bool {
+	return false
+}
+
+// Describe implemented as per the prometheus.Collector interface.
+func (*RPKICollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range rpkiDesc {
+		ch <- desc
+	}
+}
+
+// Collect implemented as per the prometheus.Collector interface.
+func (c *RPKICollector) Collect(ch chan<- prometheus.Metric) {
+	rpkiErrors = []error{}
+
+	jsonRPKICache, err := execVtyshCommand("-c", "show rpki cache-connection json")
+	if err != nil {
+		rpkiErrors = append(rpkiErrors, fmt.Errorf("cannot get rpki cache connections: %s", err))
+	} else {
+		if err := processRPKICacheConnection(ch, jsonRPKICache); err != nil {
+			rpkiErrors = append(rpkiErrors, err)
+		}
+	}
+
+	jsonRPKIPrefixes, err := execVtyshCommand("-c", "show rpki prefix-table json")
+	if err != nil {
+		rpkiErrors = append(rpkiErrors, fmt.Errorf("cannot get rpki prefix table: %s", err))
+	} else {
+		if err := processRPKIPrefixTable(ch, jsonRPKIPrefixes); err != nil {
+			rpkiErrors = append(rpkiErrors, err)
+		}
+	}
+
+	for _, afi := range []string{"ipv4", "ipv6"} {
+		for _, state := range rpkiStates {
+			jsonBGPRoutes, err := execVtyshCommand("-c", fmt.Sprintf("show bgp %s unicast rpki %s json", afi, state))
+			if err != nil {
+				rpkiErrors = append(rpkiErrors, fmt.Errorf("cannot get bgp %s unicast rpki %s routes: %s", afi, state, err))
+				continue
+			}
+			if err := processRPKIRoutes(ch, jsonBGPRoutes, afi, state); err != nil {
+				rpkiErrors = append(rpkiErrors, err)
+			}
+		}
+	}
+
+	totalRPKIErrors += float64(len(rpkiErrors))
+}
+
+// CollectErrors returns what errors have been gathered.
+func (*RPKICollector) CollectErrors() []error {
+	return rpkiErrors
+}
+
+// CollectTotalErrors returns total errors.
+func (*RPKICollector) CollectTotalErrors() float64 {
+	return totalRPKIErrors
+}
+
+func processRPKICacheConnection(ch chan<- prometheus.Metric, jsonRPKICache []byte) error {
+	var cache rpkiCacheConnection
+	if err := json.Unmarshal(jsonRPKICache, &cache); err != nil {
+		return fmt.Errorf("cannot unmarshal rpki cache-connection json: %s", err)
+	}
+
+	newGauge(ch, rpkiDesc["cacheConnected"], cache.ConnectedGroup)
+	for _, conn := range cache.Connections {
+		connUp := 0.0
+		if strings.ToLower(conn.State) == "connected" {
+			connUp = 1
+		}
+		// The labels are "host", "port", "mode", "preference"
+		newGauge(ch, rpkiDesc["cacheUp"], connUp, conn.Host, conn.Port, conn.Mode, strconv.Itoa(conn.Preference))
+	}
+	return nil
+}
+
+func processRPKIPrefixTable(ch chan<- prometheus.Metric, jsonRPKIPrefixes []byte) error {
+	var prefixTable rpkiPrefixTable
+	if err := json.Unmarshal(jsonRPKIPrefixes, &prefixTable); err != nil {
+		return fmt.Errorf("cannot unmarshal rpki prefix-table json: %s", err)
+	}
+
+	prefixes := map[string]float64{"ipv4": 0, "ipv6": 0}
+	for _, prefix := range prefixTable.Prefixes {
+		if strings.Contains(prefix.Prefix, ":") {
+			prefixes["ipv6"]++
+		} else {
+			prefixes["ipv4"]++
+		}
+	}
+	for afi, count := range prefixes {
+		newGauge(ch, rpkiDesc["prefixes"], count, afi)
+	}
+	return nil
+}
+
+func processRPKIRoutes(ch chan<- prometheus.Metric, jsonBGPRoutes []byte, AFI string, state string) error {
+	var routes bgpRoutes
+	if err := json.Unmarshal(jsonBGPRoutes, &routes); err != nil {
+		return fmt.Errorf("cannot unmarshal bgp %s unicast rpki %s json: %s", AFI, state, err)
+	}
+
+	newGauge(ch, rpkiDesc["routes"], float64(len(routes.Routes)), AFI, state)
+	return nil
+}
+
+type rpkiCacheConnection struct {
+	ConnectedGroup float64
+	Connections    []struct {
+		Mode       string
+		Host       string
+		Port       string
+		Preference int
+		State      string
+	}
+}
+
+type rpkiPrefixTable struct {
+	Prefixes []struct {
+		Prefix string
+	}
+}
@@ -0,0 +1,73 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	rpkiCacheConnectionJSON = []byte(`{
+  "connectedGroup":1,
+  "connections":[
+    {
+      "mode":"tcp",
+      "host":"192.168.10.1",
+      "port":"3323",
+      "preference":1,
+      "state":"connected"
+    },
+    {
+      "mode":"tcp",
+      "host":"192.168.10.2",
+      "port":"3323",
+      "preference":2,
+      "state":"disconnected"
+    }
+  ]
+}`)
+
+	rpkiPrefixTableJSON = []byte(`{
+  "prefixes":[
+    {"prefix":"1.0.0.0","prefixLenMin":24,"prefixLenMax":24,"asn":13335},
+    {"prefix":"1.1.1.0","prefixLenMin":24,"prefixLenMax":24,"asn":13335},
+    {"prefix":"2606:4700::","prefixLenMin":32,"prefixLenMax":48,"asn":13335}
+  ]
+}`)
+
+	rpkiInvalidRoutesJSON = []byte(`{
+  "vrfId":0,
+  "vrfName":"default",
+  "routerId":"192.168.0.1",
+  "localAS":64512,
+  "routes":{
+    "1.0.0.0/25":[{"valid":true}]
+  }
+}`)
+
+	expectedRPKIMetrics = map[string]float64{
+		"frr_rpki_cache_connected_group{}":                                                1.0,
+		"frr_rpki_cache_connection_up{host=192.168.10.1,mode=tcp,port=3323,preference=1}": 1.0,
+		"frr_rpki_cache_connection_up{host=192.168.10.2,mode=tcp,port=3323,preference=2}": 0.0,
+		"frr_rpki_prefixes_count_total{afi=ipv4}":                                         2.0,
+		"frr_rpki_prefixes_count_total{afi=ipv6}":                                         1.0,
+		"frr_rpki_bgp_routes_count_total{afi=ipv4,state=invalid}":                         1.0,
+	}
+)
+
+func TestProcessRPKI(t *testing.T) {
+	ch := make(chan prometheus.Metric, 1024)
+	if err := processRPKICacheConnection(ch, rpkiCacheConnectionJSON); err != nil {
+		t.Errorf("error calling processRPKICacheConnection: %s", err)
+	}
+	if err := processRPKIPrefixTable(ch, rpkiPrefixTableJSON); err != nil {
+		t.Errorf("error calling processRPKIPrefixTable: %s", err)
+	}
+	if err := processRPKIRoutes(ch, rpkiInvalidRoutesJSON, "ipv4", "invalid"); err != nil {
+		t.Errorf("error calling processRPKIRoutes: %s", err)
+	}
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, expectedRPKIMetrics)
+}
@@ -0,0 +1,149 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	srSubsystem = "sr"
+
+	srChunkLabels = []string{"protocol", "protocol_instance", "dynamic"}
+	srDesc        = map[string]*prometheus.Desc{
+		"chunks":    colPromDesc(srSubsystem, "label_manager_chunks_count_total", "Number of label chunks allocated by the zebra label manager to the client.", srChunkLabels),
+		"labels":    colPromDesc(srSubsystem, "label_manager_labels_count_total", "Number of labels allocated by the zebra label manager to the client.", srChunkLabels),
+		"blockInfo": colPromDesc(srSubsystem, "label_block_info", "Label block reserved by the client, such as the SRGB or SRLB of an IGP. Value is always 1.", []string{"protocol", "protocol_instance", "start_label", "end_label"}),
+
+		"sidLabels": colPromDesc(srSubsystem, "sid_labels_count_total", "Number of incoming labels in the MPLS label table installed by Segment Routing, such as prefix and adjacency SIDs.", []string{"protocol"}),
+	}
+	srErrors      = []error{}
+	totalSRErrors = 0.0
+)
+
+// SRCollector collects Segment Routing metrics, implemented as per prometheus.Collector interface.
+type SRCollector struct{}
+
+// NewSRCollector returns a SRCollector struct.
+func NewSRCollector() *SRCollector {
+	return &SRCollector{}
+}
+
+// Name of the collector. Used to populate flag name.
+func (*SRCollector) Name() string {
+	return srSubsystem
+}
+
+// Help describes the metrics this collector scrapes. Used to populate flag help.
+func (*SRCollector) Help() string {
+	return "Collect Segment Routing (SR-MPLS) Metrics"
+}
+
+// EnabledByDefault describes whether this collector is enabled by default. Used to populate flag default.
+func (*SRCollector) EnabledByDefault() bool {
+	return false
+}
+
+// Describe implemented as per the prometheus.Collector interface.
+func (*SRCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range srDesc {
+		ch <- desc
+	}
+}
+
+// Collect implemented as per the prometheus.Collector interface.
+func (c *SRCollector) Collect(ch chan<- prometheus.Metric) {
+	srErrors = []error{}
+
+	jsonLabelTable, err := execVtyshCommand("-c", "show debugging label-table json")
+	if err != nil {
+		srErrors = append(srErrors, fmt.Errorf("cannot get label manager chunks: %s", err))
+	} else {
+		if err := processSRLabelChunks(ch, jsonLabelTable); err != nil {
+			srErrors = append(srErrors, err)
+		}
+	}
+
+	jsonMPLSTable, err := execVtyshCommand("-c", "show mpls table json")
+	if err != nil {
+		srErrors = append(srErrors, fmt.Errorf("cannot get mpls table: %s", err))
+	} else {
+		if err := processSRLabels(ch, jsonMPLSTable); err != nil {
+			srErrors = append(srErrors, err)
+		}
+	}
+
+	totalSRErrors += float64(len(srErrors))
+}
+
+// CollectErrors returns what errors have been gathered.
+func (*SRCollector) CollectErrors() []error {
+	return srErrors
+}
+
+// CollectTotalErrors returns total errors.
+func (*SRCollector) CollectTotalErrors() float64 {
+	return totalSRErrors
+}
+
+func processSRLabelChunks(ch chan<- prometheus.Metric, jsonLabelTable []byte) error {
+	var labelTable struct {
+		Chunks []struct {
+			Protocol string
+			Instance float64
+			Start    float64
+			End      float64
+			Dynamic  bool
+		}
+	}
+	if err := json.Unmarshal(jsonLabelTable, &labelTable); err != nil {
+		return fmt.Errorf("cannot unmarshal label manager chunks json: %s", err)
+	}
+
+	chunks, labels := map[[3]string]float64{}, map[[3]string]float64{}
+	for _, chunk := range labelTable.Chunks {
+		instance := strconv.FormatFloat(chunk.Instance, 'f', -1, 64)
+		// The labels are "protocol", "protocol_instance", "dynamic"
+		key := [3]string{chunk.Protocol, instance, strconv.FormatBool(chunk.Dynamic)}
+		chunks[key]++
+		labels[key] += chunk.End - chunk.Start + 1
+		// Chunks that are not dynamic are blocks reserved by the client, which are the SRGB and SRLB for the IGPs.
+		if !chunk.Dynamic {
+			// The labels are "protocol", "protocol_instance", "start_label", "end_label"
+			newGauge(ch, srDesc["blockInfo"], 1, chunk.Protocol, instance, strconv.FormatFloat(chunk.Start, 'f', -1, 64), strconv.FormatFloat(chunk.End, 'f', -1, 64))
+		}
+	}
+	for key, count := range chunks {
+		newGauge(ch, srDesc["chunks"], count, key[:]...)
+		newGauge(ch, srDesc["labels"], labels[key], key[:]...)
+	}
+	return nil
+}
+
+func processSRLabels(ch chan<- prometheus.Metric, jsonMPLSTable []byte) error {
+	var mplsTable map[string]mplsLabel
+	if err := json.Unmarshal(jsonMPLSTable, &mplsTable); err != nil {
+		return fmt.Errorf("cannot unmarshal mpls table json: %s", err)
+	}
+
+	sidLabels := map[string]float64{}
+	for _, label := range mplsTable {
+		if len(label.Nexthops) == 0 {
+			continue
+		}
+		// Labels installed by Segment Routing have a type such as "SR (IS-IS)" or "SR (OSPF)".
+		labelType := label.Nexthops[0].Type
+		if !strings.HasPrefix(labelType, "SR (") {
+			continue
+		}
+		sidLabels[strings.TrimSuffix(strings.TrimPrefix(labelType, "SR ("), ")")]++
+	}
+	for protocol, count := range sidLabels {
+		// The labels are "protocol"
+		newGauge(ch, srDesc["sidLabels"], count, protocol)
+	}
+	return nil
+}
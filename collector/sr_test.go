@@ -0,0 +1,59 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestProcessSRLabelChunks(t *testing.T) {
+	labelTable := []byte(`{
+  "chunks":[
+    {"protocol":"isis","instance":0,"sessionId":0,"start":16000,"end":23999,"dynamic":false},
+    {"protocol":"isis","instance":0,"sessionId":0,"start":15000,"end":15999,"dynamic":false},
+    {"protocol":"ldp","instance":0,"sessionId":0,"start":24000,"end":24063,"dynamic":true},
+    {"protocol":"ldp","instance":0,"sessionId":0,"start":24064,"end":24127,"dynamic":true},
+    {"protocol":"bgp","instance":0,"sessionId":1,"start":24128,"end":24191,"dynamic":true}
+  ]
+}`)
+
+	ch := make(chan prometheus.Metric, 1024)
+	if err := processSRLabelChunks(ch, labelTable); err != nil {
+		t.Errorf("error calling processSRLabelChunks: %s", err)
+	}
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, map[string]float64{
+		"frr_sr_label_manager_chunks_count_total{dynamic=false,protocol=isis,protocol_instance=0}":     2,
+		"frr_sr_label_manager_chunks_count_total{dynamic=true,protocol=ldp,protocol_instance=0}":       2,
+		"frr_sr_label_manager_chunks_count_total{dynamic=true,protocol=bgp,protocol_instance=0}":       1,
+		"frr_sr_label_manager_labels_count_total{dynamic=false,protocol=isis,protocol_instance=0}":     9000,
+		"frr_sr_label_manager_labels_count_total{dynamic=true,protocol=ldp,protocol_instance=0}":       128,
+		"frr_sr_label_manager_labels_count_total{dynamic=true,protocol=bgp,protocol_instance=0}":       64,
+		"frr_sr_label_block_info{end_label=23999,protocol=isis,protocol_instance=0,start_label=16000}": 1,
+		"frr_sr_label_block_info{end_label=15999,protocol=isis,protocol_instance=0,start_label=15000}": 1,
+	})
+}
+
+func TestProcessSRLabels(t *testing.T) {
+	mplsTable := []byte(`{
+  "16001":{"inLabel":16001,"installed":true,"nexthops":[{"type":"SR (IS-IS)","outLabel":3,"installed":true,"nexthop":"10.0.1.2"}]},
+  "16002":{"inLabel":16002,"installed":true,"nexthops":[{"type":"SR (IS-IS)","outLabel":16002,"installed":true,"nexthop":"10.0.1.2"}]},
+  "15000":{"inLabel":15000,"installed":true,"nexthops":[{"type":"SR (IS-IS)","outLabel":3,"installed":true,"nexthop":"10.0.1.2"}]},
+  "17001":{"inLabel":17001,"installed":true,"nexthops":[{"type":"SR (OSPF)","outLabel":3,"installed":true,"nexthop":"10.0.2.2"}]},
+  "24000":{"inLabel":24000,"installed":true,"nexthops":[{"type":"LDP","outLabel":3,"installed":true,"nexthop":"10.0.1.2"}]}
+}`)
+
+	ch := make(chan prometheus.Metric, 1024)
+	if err := processSRLabels(ch, mplsTable); err != nil {
+		t.Errorf("error calling processSRLabels: %s", err)
+	}
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, map[string]float64{
+		"frr_sr_sid_labels_count_total{protocol=IS-IS}": 3,
+		"frr_sr_sid_labels_count_total{protocol=OSPF}":  1,
+	})
+}
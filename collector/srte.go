@@ -0,0 +1,127 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	srteSubsystem          = "srte"
+	srtePolicyMetricPrefix = "srte_policy"
+
+	srtePolicyLabels = []string{"color", "endpoint", "name"}
+	srteDesc         = map[string]*prometheus.Desc{
+		"up":                  colPromDesc(srtePolicyMetricPrefix, "up", "Operational state of the SR-TE policy (1 = Active, 0 = Inactive).", srtePolicyLabels),
+		"candidatePaths":      colPromDesc(srtePolicyMetricPrefix, "candidate_paths_count_total", "Number of candidate paths of the SR-TE policy.", srtePolicyLabels),
+		"activeCandidatePath": colPromDesc(srtePolicyMetricPrefix, "active_candidate_path_info", "Active candidate path of the SR-TE policy, the best valid candidate path. Value is always 1.", append(srtePolicyLabels, "candidate_path", "preference", "protocol_origin", "type")),
+		"segmentListLength":   colPromDesc(srtePolicyMetricPrefix, "segment_list_length", "Number of segments in the segment list of the active candidate path of the SR-TE policy.", srtePolicyLabels),
+	}
+	srteErrors      = []error{}
+	totalSRTEErrors = 0.0
+)
+
+// SRTECollector collects SR-TE metrics, implemented as per prometheus.Collector interface.
+type SRTECollector struct{}
+
+// NewSRTECollector returns a SRTECollector struct.
+func NewSRTECollector() *SRTECollector {
+	return &SRTECollector{}
+}
+
+// Name of the collector. Used to populate flag name.
+func (*SRTECollector) Name() string {
+	return srteSubsystem
+}
+
+// Help describes the metrics this collector scrapes. Used to populate flag help.
+func (*SRTECollector) Help() string {
+	return "Collect SR-TE Policy Metrics"
+}
+
+// EnabledByDefault describes whether this collector is enabled by default. Used to populate flag default.
+func (*SRTECollector) EnabledByDefault() bool {
+	return false
+}
+
+// Describe implemented as per the prometheus.Collector interface.
+func (*SRTECollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range srteDesc {
+		ch <- desc
+	}
+}
+
+// Collect implemented as per the prometheus.Collector interface.
+func (c *SRTECollector) Collect(ch chan<- prometheus.Metric) {
+	srteErrors = []error{}
+
+	jsonSRTEPolicy, err := execVtyshCommand("-c", "show sr-te policy detail json")
+	if err != nil {
+		srteErrors = append(srteErrors, fmt.Errorf("cannot get sr-te policies: %s", err))
+	} else {
+		if err := processSRTEPolicy(ch, jsonSRTEPolicy); err != nil {
+			srteErrors = append(srteErrors, err)
+		}
+	}
+
+	totalSRTEErrors += float64(len(srteErrors))
+}
+
+// CollectErrors returns what errors have been gathered.
+func (*SRTECollector) CollectErrors() []error {
+	return srteErrors
+}
+
+// CollectTotalErrors returns total errors.
+func (*SRTECollector) CollectTotalErrors() float64 {
+	return totalSRTEErrors
+}
+
+func processSRTEPolicy(ch chan<- prometheus.Metric, jsonSRTEPolicy []byte) error {
+	var srtePolicies []struct {
+		Color          float64
+		Endpoint       string
+		Name           string
+		Status         string
+		CandidatePaths []srteCandidatePath
+	}
+	if err := json.Unmarshal(jsonSRTEPolicy, &srtePolicies); err != nil {
+		return fmt.Errorf("cannot unmarshal sr-te policy json: %s", err)
+	}
+
+	for _, policy := range srtePolicies {
+		// The labels are "color", "endpoint", "name"
+		labels := []string{strconv.FormatFloat(policy.Color, 'f', -1, 64), policy.Endpoint, policy.Name}
+		up := 0.0
+		if policy.Status == "Active" {
+			up = 1
+		}
+		newGauge(ch, srteDesc["up"], up, labels...)
+		newGauge(ch, srteDesc["candidatePaths"], float64(len(policy.CandidatePaths)), labels...)
+		// Policies without a valid candidate path do not have an active candidate path, so its segment list length is 0.
+		segmentListLength := 0.0
+		for _, path := range policy.CandidatePaths {
+			if !path.IsBestCandidate {
+				continue
+			}
+			segmentListLength = float64(len(path.Segments))
+			newGauge(ch, srteDesc["activeCandidatePath"], 1, append(labels, path.Name, strconv.FormatFloat(path.Preference, 'f', -1, 64), path.ProtocolOrigin, path.Type)...)
+		}
+		newGauge(ch, srteDesc["segmentListLength"], segmentListLength, labels...)
+	}
+	return nil
+}
+
+type srteCandidatePath struct {
+	Name            string
+	Preference      float64
+	ProtocolOrigin  string
+	Type            string
+	IsBestCandidate bool
+	Segments        []struct {
+		Index    float64
+		SidValue float64
+	}
+}
@@ -0,0 +1,49 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestProcessSRTEPolicy(t *testing.T) {
+	srtePolicy := []byte(`[
+  {
+    "color":100,
+    "endpoint":"2.2.2.2",
+    "name":"TO_R2",
+    "bindingSID":1111,
+    "status":"Active",
+    "candidatePaths":[
+      {"name":"PRIMARY","preference":200,"protocolOrigin":"Local","type":"explicit","isBestCandidate":true,"segmentListName":"SL_PRIMARY","segments":[{"index":10,"sidValue":16003},{"index":20,"sidValue":16002}]},
+      {"name":"BACKUP","preference":100,"protocolOrigin":"Local","type":"explicit","isBestCandidate":false,"segmentListName":"SL_BACKUP","segments":[{"index":10,"sidValue":16002}]}
+    ]
+  },
+  {
+    "color":200,
+    "endpoint":"3.3.3.3",
+    "name":"TO_R3",
+    "status":"Inactive",
+    "candidatePaths":[
+      {"name":"DYNAMIC","preference":100,"protocolOrigin":"PCEP","type":"dynamic","isBestCandidate":false,"segments":[]}
+    ]
+  }
+]`)
+
+	ch := make(chan prometheus.Metric, 1024)
+	if err := processSRTEPolicy(ch, srtePolicy); err != nil {
+		t.Errorf("error calling processSRTEPolicy: %s", err)
+	}
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, map[string]float64{
+		"frr_srte_policy_up{color=100,endpoint=2.2.2.2,name=TO_R2}":                                                                                                   1,
+		"frr_srte_policy_up{color=200,endpoint=3.3.3.3,name=TO_R3}":                                                                                                   0,
+		"frr_srte_policy_candidate_paths_count_total{color=100,endpoint=2.2.2.2,name=TO_R2}":                                                                          2,
+		"frr_srte_policy_candidate_paths_count_total{color=200,endpoint=3.3.3.3,name=TO_R3}":                                                                          1,
+		"frr_srte_policy_active_candidate_path_info{candidate_path=PRIMARY,color=100,endpoint=2.2.2.2,name=TO_R2,preference=200,protocol_origin=Local,type=explicit}": 1,
+		"frr_srte_policy_segment_list_length{color=100,endpoint=2.2.2.2,name=TO_R2}":                                                                                  2,
+		"frr_srte_policy_segment_list_length{color=200,endpoint=3.3.3.3,name=TO_R3}":                                                                                  0,
+	})
+}
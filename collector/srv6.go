@@ -0,0 +1,153 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	srv6Subsystem = "srv6"
+
+	srv6LocatorLabels = []string{"locator"}
+	srv6Desc          = map[string]*prometheus.Desc{
+		"locatorUp":        colPromDesc(srv6Subsystem, "locator_up", "State of the SRv6 locator (1 = Up, 0 = Down).", srv6LocatorLabels),
+		"locatorInfo":      colPromDesc(srv6Subsystem, "locator_info", "Prefix and bits lengths of the SRv6 locator. Value is always 1.", append(srv6LocatorLabels, "prefix", "block_bits", "node_bits", "function_bits", "argument_bits")),
+		"locatorFunctions": colPromDesc(srv6Subsystem, "locator_functions_count_total", "Number of SID functions the SRv6 locator can allocate, as per the function bits length of the locator.", srv6LocatorLabels),
+		"locatorChunks":    colPromDesc(srv6Subsystem, "locator_chunks_count_total", "Number of chunks of the SRv6 locator allocated to the protocol.", append(srv6LocatorLabels, "protocol")),
+
+		"sids": colPromDesc(srv6Subsystem, "sids_count_total", "Number of SIDs allocated from the SRv6 locator per behavior, such as End, End.X or End.DT46.", append(srv6LocatorLabels, "behavior")),
+	}
+	srv6Errors      = []error{}
+	totalSRv6Errors = 0.0
+)
+
+// SRv6Collector collects SRv6 metrics, implemented as per prometheus.Collector interface.
+type SRv6Collector struct{}
+
+// NewSRv6Collector returns a SRv6Collector struct.
+func NewSRv6Collector() *SRv6Collector {
+	return &SRv6Collector{}
+}
+
+// Name of the collector. Used to populate flag name.
+func (*SRv6Collector) Name() string {
+	return srv6Subsystem
+}
+
+// Help describes the metrics this collector scrapes. Used to populate flag help.
+func (*SRv6Collector) Help() string {
+	return "Collect SRv6 Metrics"
+}
+
+// EnabledByDefault describes whether this collector is enabled by default. Used to populate flag default.
+func (*SRv6Collector) EnabledByDefault() bool {
+	return false
+}
+
+// Describe implemented as per the prometheus.Collector interface.
+func (*SRv6Collector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range srv6Desc {
+		ch <- desc
+	}
+}
+
+// Collect implemented as per the prometheus.Collector interface.
+func (c *SRv6Collector) Collect(ch chan<- prometheus.Metric) {
+	srv6Errors = []error{}
+
+	jsonSRv6Locator, err := execVtyshCommand("-c", "show segment-routing srv6 locator json")
+	if err != nil {
+		srv6Errors = append(srv6Errors, fmt.Errorf("cannot get srv6 locators: %s", err))
+	} else {
+		if err := processSRv6Locator(ch, jsonSRv6Locator); err != nil {
+			srv6Errors = append(srv6Errors, err)
+		}
+	}
+
+	jsonSRv6SID, err := execVtyshCommand("-c", "show segment-routing srv6 sid json")
+	if err != nil {
+		srv6Errors = append(srv6Errors, fmt.Errorf("cannot get srv6 sids: %s", err))
+	} else {
+		if err := processSRv6SID(ch, jsonSRv6SID); err != nil {
+			srv6Errors = append(srv6Errors, err)
+		}
+	}
+
+	totalSRv6Errors += float64(len(srv6Errors))
+}
+
+// CollectErrors returns what errors have been gathered.
+func (*SRv6Collector) CollectErrors() []error {
+	return srv6Errors
+}
+
+// CollectTotalErrors returns total errors.
+func (*SRv6Collector) CollectTotalErrors() float64 {
+	return totalSRv6Errors
+}
+
+func processSRv6Locator(ch chan<- prometheus.Metric, jsonSRv6Locator []byte) error {
+	var srv6Locators struct {
+		Locators []struct {
+			Name               string
+			Prefix             string
+			BlockBitsLength    int
+			NodeBitsLength     int
+			FunctionBitsLength int
+			ArgumentBitsLength int
+			StatusUp           bool
+			Chunks             []struct {
+				Proto string
+			}
+		}
+	}
+	if err := json.Unmarshal(jsonSRv6Locator, &srv6Locators); err != nil {
+		return fmt.Errorf("cannot unmarshal srv6 locator json: %s", err)
+	}
+
+	for _, locator := range srv6Locators.Locators {
+		// The labels are "locator"
+		up := 0.0
+		if locator.StatusUp {
+			up = 1
+		}
+		newGauge(ch, srv6Desc["locatorUp"], up, locator.Name)
+		newGauge(ch, srv6Desc["locatorInfo"], 1, locator.Name, locator.Prefix, strconv.Itoa(locator.BlockBitsLength), strconv.Itoa(locator.NodeBitsLength), strconv.Itoa(locator.FunctionBitsLength), strconv.Itoa(locator.ArgumentBitsLength))
+		newGauge(ch, srv6Desc["locatorFunctions"], math.Pow(2, float64(locator.FunctionBitsLength)), locator.Name)
+
+		chunks := map[string]float64{}
+		for _, chunk := range locator.Chunks {
+			chunks[chunk.Proto]++
+		}
+		for protocol, count := range chunks {
+			// The labels are "locator", "protocol"
+			newGauge(ch, srv6Desc["locatorChunks"], count, locator.Name, protocol)
+		}
+	}
+	return nil
+}
+
+func processSRv6SID(ch chan<- prometheus.Metric, jsonSRv6SID []byte) error {
+	// The SIDs are keyed by the SID address.
+	var srv6SIDs map[string]struct {
+		Behavior string
+		Locator  string
+	}
+	if err := json.Unmarshal(jsonSRv6SID, &srv6SIDs); err != nil {
+		return fmt.Errorf("cannot unmarshal srv6 sid json: %s", err)
+	}
+
+	sids := map[[2]string]float64{}
+	for _, sid := range srv6SIDs {
+		sids[[2]string{sid.Locator, sid.Behavior}]++
+	}
+	for key, count := range sids {
+		// The labels are "locator", "behavior"
+		newGauge(ch, srv6Desc["sids"], count, key[:]...)
+	}
+	return nil
+}
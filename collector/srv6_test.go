@@ -0,0 +1,77 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestProcessSRv6Locator(t *testing.T) {
+	srv6Locator := []byte(`{
+  "locators":[
+    {
+      "name":"MAIN",
+      "prefix":"fc00:0:1::/48",
+      "blockBitsLength":32,
+      "nodeBitsLength":16,
+      "functionBitsLength":16,
+      "argumentBitsLength":0,
+      "statusUp":true,
+      "chunks":[
+        {"prefix":"fc00:0:1::/48","proto":"bgp"},
+        {"prefix":"fc00:0:1::/48","proto":"isis"}
+      ]
+    },
+    {
+      "name":"BACKUP",
+      "prefix":"fc00:1:1::/48",
+      "blockBitsLength":32,
+      "nodeBitsLength":16,
+      "functionBitsLength":8,
+      "argumentBitsLength":0,
+      "statusUp":false,
+      "chunks":[]
+    }
+  ]
+}`)
+
+	ch := make(chan prometheus.Metric, 1024)
+	if err := processSRv6Locator(ch, srv6Locator); err != nil {
+		t.Errorf("error calling processSRv6Locator: %s", err)
+	}
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, map[string]float64{
+		"frr_srv6_locator_up{locator=MAIN}":   1,
+		"frr_srv6_locator_up{locator=BACKUP}": 0,
+		"frr_srv6_locator_info{argument_bits=0,block_bits=32,function_bits=16,locator=MAIN,node_bits=16,prefix=fc00:0:1::/48}":  1,
+		"frr_srv6_locator_info{argument_bits=0,block_bits=32,function_bits=8,locator=BACKUP,node_bits=16,prefix=fc00:1:1::/48}": 1,
+		"frr_srv6_locator_functions_count_total{locator=MAIN}":                                                                  65536,
+		"frr_srv6_locator_functions_count_total{locator=BACKUP}":                                                                256,
+		"frr_srv6_locator_chunks_count_total{locator=MAIN,protocol=bgp}":                                                        1,
+		"frr_srv6_locator_chunks_count_total{locator=MAIN,protocol=isis}":                                                       1,
+	})
+}
+
+func TestProcessSRv6SID(t *testing.T) {
+	srv6SID := []byte(`{
+  "fc00:0:1::":{"sid":"fc00:0:1::","behavior":"End","context":{},"locator":"MAIN","allocationMode":"dynamic","clients":[{"proto":"isis","instance":0}]},
+  "fc00:0:1:e000::":{"sid":"fc00:0:1:e000::","behavior":"End.X","context":{"interfaceName":"eth0","nexthopIpv6Address":"fe80::1"},"locator":"MAIN","allocationMode":"dynamic","clients":[{"proto":"isis","instance":0}]},
+  "fc00:0:1:e001::":{"sid":"fc00:0:1:e001::","behavior":"End.X","context":{"interfaceName":"eth1","nexthopIpv6Address":"fe80::2"},"locator":"MAIN","allocationMode":"dynamic","clients":[{"proto":"isis","instance":0}]},
+  "fc00:0:1:e002::":{"sid":"fc00:0:1:e002::","behavior":"End.DT46","context":{"table":10},"locator":"MAIN","allocationMode":"dynamic","clients":[{"proto":"bgp","instance":0}]}
+}`)
+
+	ch := make(chan prometheus.Metric, 1024)
+	if err := processSRv6SID(ch, srv6SID); err != nil {
+		t.Errorf("error calling processSRv6SID: %s", err)
+	}
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, map[string]float64{
+		"frr_srv6_sids_count_total{behavior=End,locator=MAIN}":      1,
+		"frr_srv6_sids_count_total{behavior=End.X,locator=MAIN}":    2,
+		"frr_srv6_sids_count_total{behavior=End.DT46,locator=MAIN}": 1,
+	})
+}
@@ -0,0 +1,209 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	staticSubsystem = "static"
+
+	staticLabels = []string{"vrf", "afi"}
+	staticDesc   = map[string]*prometheus.Desc{
+		"routes":          colPromDesc(staticSubsystem, "routes_count_total", "Number of static route prefixes configured in staticd.", staticLabels),
+		"installedRoutes": colPromDesc(staticSubsystem, "installed_routes_count_total", "Number of configured static route prefixes that are installed in the FIB by zebra.", staticLabels),
+		"inactiveRoutes":  colPromDesc(staticSubsystem, "inactive_routes_count_total", "Number of configured static route prefixes that are not installed in the FIB, such as due to unreachable nexthops.", staticLabels),
+	}
+	staticErrors      = []error{}
+	totalStaticErrors = 0.0
+
+	// staticAFIs maps the address family of the static route commands to the address family label.
+	staticAFIs = map[string]string{
+		"ip":   "ipv4",
+		"ipv6": "ipv6",
+	}
+	staticVRFRegexp   = regexp.MustCompile(`^vrf (\S+)`)
+	staticRouteRegexp = regexp.MustCompile(`^\s*(ip|ipv6) route (\S+)`)
+	// Older FRR versions configure the static routes of VRFs with the VRF at the end of the route.
+	staticRouteVRFRegexp = regexp.MustCompile(`\svrf (\S+)`)
+)
+
+// StaticCollector collects staticd metrics, implemented as per prometheus.Collector interface.
+type StaticCollector struct{}
+
+// NewStaticCollector returns a StaticCollector struct.
+func NewStaticCollector() *StaticCollector {
+	return &StaticCollector{}
+}
+
+// Name of the collector. Used to populate flag name.
+func (*StaticCollector) Name() string {
+	return staticSubsystem
+}
+
+// Help describes the metrics this collector scrapes. Used to populate flag help.
+func (*StaticCollector) Help() string {
+	return "Collect Static Route Metrics"
+}
+
+// EnabledByDefault describes whether this collector is enabled by default. Used to populate flag default.
+func (*StaticCollector) EnabledByDefault() bool {
+	return false
+}
+
+// Describe implemented as per the prometheus.Collector interface.
+func (*StaticCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range staticDesc {
+		ch <- desc
+	}
+}
+
+// Collect implemented as per the prometheus.Collector interface.
+func (c *StaticCollector) Collect(ch chan<- prometheus.Metric) {
+	staticErrors = []error{}
+
+	// staticd only sends the static routes with a reachable nexthop to zebra, so the configured static routes are
+	// taken from the running configuration of staticd.
+	config, err := execVtyshCommand("-c", "show running-config staticd")
+	if err != nil {
+		staticErrors = append(staticErrors, fmt.Errorf("cannot get staticd running-config: %s", err))
+		totalStaticErrors += float64(len(staticErrors))
+		return
+	}
+	configured := processStaticConfig(config)
+
+	// Static routes can be configured in VRFs that do not exist, so the installed routes are only collected from the
+	// VRFs known to zebra.
+	vrfs := map[string]bool{"default": true}
+	jsonVRF, err := execVtyshCommand("-c", "show vrf json")
+	if err != nil {
+		staticErrors = append(staticErrors, fmt.Errorf("cannot get vrfs: %s", err))
+	} else {
+		if vrfNames, err := processVRFNames(jsonVRF); err != nil {
+			staticErrors = append(staticErrors, err)
+		} else {
+			for _, vrfName := range vrfNames {
+				vrfs[vrfName] = true
+			}
+		}
+	}
+
+	installed := map[staticRouteKey]float64{}
+	for key := range configured {
+		if !vrfs[key.vrf] {
+			continue
+		}
+		jsonRoutes, err := execVtyshCommand("-c", fmt.Sprintf("show %s route vrf %s static json", routeAFIs[key.afi], key.vrf))
+		if err != nil {
+			staticErrors = append(staticErrors, fmt.Errorf("cannot get %s static routes of vrf %s: %s", key.afi, key.vrf, err))
+			continue
+		}
+		if installed[key], err = processStaticInstalled(jsonRoutes); err != nil {
+			staticErrors = append(staticErrors, err)
+		}
+	}
+	processStaticRoutes(ch, configured, installed)
+
+	totalStaticErrors += float64(len(staticErrors))
+}
+
+// CollectErrors returns what errors have been gathered.
+func (*StaticCollector) CollectErrors() []error {
+	return staticErrors
+}
+
+// CollectTotalErrors returns total errors.
+func (*StaticCollector) CollectTotalErrors() float64 {
+	return totalStaticErrors
+}
+
+// processStaticConfig returns the number of configured static route prefixes per VRF and address family of
+// 'show running-config staticd', such as:
+//
+//	ip route 10.0.0.0/24 192.168.1.1
+//	ip route 10.0.0.0/24 192.168.2.1 10
+//	ipv6 route 2001:db8::/64 blackhole
+//	!
+//	vrf red
+//	 ip route 10.1.0.0/24 10.0.0.1 nexthop-vrf default
+//	exit-vrf
+func processStaticConfig(output []byte) map[staticRouteKey]float64 {
+	// Each nexthop of a prefix is configured as a separate route.
+	prefixes := map[staticRouteKey]map[string]bool{}
+	vrfName := "default"
+	for _, line := range strings.Split(string(output), "\n") {
+		if match := staticVRFRegexp.FindStringSubmatch(line); match != nil {
+			vrfName = match[1]
+			continue
+		}
+		if line := strings.TrimSpace(line); line == "!" || line == "exit" || line == "exit-vrf" {
+			vrfName = "default"
+			continue
+		}
+		match := staticRouteRegexp.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		key := staticRouteKey{vrf: vrfName, afi: staticAFIs[match[1]]}
+		if vrfMatch := staticRouteVRFRegexp.FindStringSubmatch(line); vrfMatch != nil {
+			key.vrf = vrfMatch[1]
+		}
+		if _, exist := prefixes[key]; !exist {
+			prefixes[key] = map[string]bool{}
+		}
+		prefixes[key][match[2]] = true
+	}
+
+	configured := map[staticRouteKey]float64{}
+	for key, routes := range prefixes {
+		configured[key] = float64(len(routes))
+	}
+	return configured
+}
+
+// processStaticInstalled returns the number of static route prefixes of 'show ip route vrf NAME static json' that are
+// installed in the FIB.
+func processStaticInstalled(jsonRoutes []byte) (float64, error) {
+	// The routes are keyed by the prefix.
+	var routes map[string][]struct {
+		Protocol  string
+		Installed bool
+	}
+	if err := json.Unmarshal(jsonRoutes, &routes); err != nil {
+		return 0, fmt.Errorf("cannot unmarshal static route json: %s", err)
+	}
+
+	installed := 0.0
+	for _, entries := range routes {
+		for _, entry := range entries {
+			if entry.Protocol == "static" && entry.Installed {
+				installed++
+				break
+			}
+		}
+	}
+	return installed, nil
+}
+
+func processStaticRoutes(ch chan<- prometheus.Metric, configured map[staticRouteKey]float64, installed map[staticRouteKey]float64) {
+	for key := range configured {
+		inactive := configured[key] - installed[key]
+		if inactive < 0 {
+			inactive = 0
+		}
+		// The labels are "vrf", "afi"
+		labels := []string{strings.ToLower(key.vrf), key.afi}
+		newGauge(ch, staticDesc["routes"], configured[key], labels...)
+		newGauge(ch, staticDesc["installedRoutes"], installed[key], labels...)
+		newGauge(ch, staticDesc["inactiveRoutes"], inactive, labels...)
+	}
+}
+
+type staticRouteKey struct {
+	vrf string
+	afi string
+}
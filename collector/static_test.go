@@ -0,0 +1,70 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestProcessStaticRoutes(t *testing.T) {
+	staticConfig := []byte(`Building configuration...
+
+Current configuration:
+!
+frr version 8.4.2
+frr defaults traditional
+!
+ip route 10.0.0.0/24 192.168.1.1
+ip route 10.0.0.0/24 192.168.2.1 10
+ip route 10.1.0.0/24 192.168.3.1
+ip route 10.2.0.0/24 192.168.4.1 vrf blue
+ipv6 route 2001:db8::/64 blackhole
+!
+vrf Red
+ ip route 10.3.0.0/24 10.0.0.1 nexthop-vrf default
+ ip route 10.4.0.0/24 10.0.0.2
+exit-vrf
+!
+end
+`)
+	staticRoutes := []byte(`{
+  "10.0.0.0/24":[
+    {"prefix":"10.0.0.0/24","protocol":"static","vrfName":"default","selected":true,"distance":1,"installed":true,"nexthops":[{"ip":"192.168.1.1","active":true,"fib":true}]},
+    {"prefix":"10.0.0.0/24","protocol":"static","vrfName":"default","distance":10,"nexthops":[{"ip":"192.168.2.1","active":true}]}
+  ],
+  "10.1.0.0/24":[
+    {"prefix":"10.1.0.0/24","protocol":"static","vrfName":"default","distance":1,"nexthops":[{"ip":"192.168.3.1"}]}
+  ]
+}`)
+
+	configured := processStaticConfig(staticConfig)
+	installedV4, err := processStaticInstalled(staticRoutes)
+	if err != nil {
+		t.Errorf("error calling processStaticInstalled: %s", err)
+	}
+	installed := map[staticRouteKey]float64{
+		{vrf: "default", afi: "ipv4"}: installedV4,
+		{vrf: "default", afi: "ipv6"}: 1,
+		{vrf: "Red", afi: "ipv4"}:     2,
+	}
+
+	ch := make(chan prometheus.Metric, 1024)
+	processStaticRoutes(ch, configured, installed)
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, map[string]float64{
+		"frr_static_routes_count_total{afi=ipv4,vrf=default}":           2,
+		"frr_static_routes_count_total{afi=ipv6,vrf=default}":           1,
+		"frr_static_routes_count_total{afi=ipv4,vrf=blue}":              1,
+		"frr_static_routes_count_total{afi=ipv4,vrf=red}":               2,
+		"frr_static_installed_routes_count_total{afi=ipv4,vrf=default}": 1,
+		"frr_static_installed_routes_count_total{afi=ipv6,vrf=default}": 1,
+		"frr_static_installed_routes_count_total{afi=ipv4,vrf=blue}":    0,
+		"frr_static_installed_routes_count_total{afi=ipv4,vrf=red}":     2,
+		"frr_static_inactive_routes_count_total{afi=ipv4,vrf=default}":  1,
+		"frr_static_inactive_routes_count_total{afi=ipv6,vrf=default}":  0,
+		"frr_static_inactive_routes_count_total{afi=ipv4,vrf=blue}":     1,
+		"frr_static_inactive_routes_count_total{afi=ipv4,vrf=red}":      0,
+	})
+}
@@ -0,0 +1,167 @@
+package collector
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+var (
+	threadSubsystem = "thread"
+
+	threadTop = kingpin.Flag("collector.thread.top", "Number of the busiest threads by CPU time of each daemon of which the metrics are collected with the thread collector, in addition to the daemon totals (default: 10).").Default("10").Int()
+
+	threadFunctionLabels = []string{"daemon", "pthread", "thread"}
+	threadDesc           = map[string]*prometheus.Desc{
+		"cpu":         colPromDesc(threadSubsystem, "cpu_seconds_total", "CPU time (user+system) spent running the threads of the daemon.", []string{"daemon"}),
+		"invocations": colPromDesc(threadSubsystem, "invocations_total", "Number of times the threads of the daemon have been run.", []string{"daemon"}),
+
+		"functionCPU":         colPromDesc(threadSubsystem, "function_cpu_seconds_total", "CPU time (user+system) spent running the thread, for the busiest threads of the daemon.", threadFunctionLabels),
+		"functionInvocations": colPromDesc(threadSubsystem, "function_invocations_total", "Number of times the thread has been run, for the busiest threads of the daemon.", threadFunctionLabels),
+		"functionMaxCPU":      colPromDesc(threadSubsystem, "function_max_cpu_seconds", "Longest CPU time (user+system) of a single run of the thread, for the busiest threads of the daemon.", threadFunctionLabels),
+	}
+	threadErrors      = []error{}
+	totalThreadErrors = 0.0
+
+	threadDaemonRegexp  = regexp.MustCompile(`^(?:Thread|Event) statistics for (\S+):`)
+	threadPthreadRegexp = regexp.MustCompile(`^Showing statistics for pthread (.+)$`)
+	// The columns are Active, Runtime(ms), Invoked, CPU Avg uSec, CPU Max uSecs, Wall Avg uSec and Wall Max uSecs,
+	// followed by the warning counters of newer FRR versions, the type and the name of the thread.
+	threadRegexp = regexp.MustCompile(`^\s*\d+\s+([\d.]+)\s+(\d+)\s+\d+\s+(\d+)\s+\d+\s+\d+(?:\s+\d+)*\s+[RWTEX ]+?\s+(\S+)\s*$`)
+)
+
+// ThreadCollector collects thread CPU metrics, implemented as per prometheus.Collector interface.
+type ThreadCollector struct{}
+
+// NewThreadCollector returns a ThreadCollector struct.
+func NewThreadCollector() *ThreadCollector {
+	return &ThreadCollector{}
+}
+
+// Name of the collector. Used to populate flag name.
+func (*ThreadCollector) Name() string {
+	return threadSubsystem
+}
+
+// Help describes the metrics this collector scrapes. Used to populate flag help.
+func (*ThreadCollector) Help() string {
+	return "Collect Thread CPU Metrics"
+}
+
+// EnabledByDefault describes whether this collector is enabled by default. Used to populate flag default.
+func (*ThreadCollector) EnabledByDefault() bool {
+	return false
+}
+
+// Describe implemented as per the prometheus.Collector interface.
+func (*ThreadCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range threadDesc {
+		ch <- desc
+	}
+}
+
+// Collect implemented as per the prometheus.Collector interface.
+func (c *ThreadCollector) Collect(ch chan<- prometheus.Metric) {
+	threadErrors = []error{}
+
+	// The thread statistics are only available as text, vtysh prints the statistics of each daemon.
+	threadCPU, err := execVtyshCommand("-c", "show thread cpu")
+	if err != nil {
+		threadErrors = append(threadErrors, fmt.Errorf("cannot get thread cpu: %s", err))
+	} else {
+		processThreadCPU(ch, threadCPU, *threadTop)
+	}
+
+	totalThreadErrors += float64(len(threadErrors))
+}
+
+// CollectErrors returns what errors have been gathered.
+func (*ThreadCollector) CollectErrors() []error {
+	return threadErrors
+}
+
+// CollectTotalErrors returns total errors.
+func (*ThreadCollector) CollectTotalErrors() float64 {
+	return totalThreadErrors
+}
+
+// processThreadCPU processes the output of 'show thread cpu', such as:
+//
+//	Thread statistics for zebra:
+//
+//	Showing statistics for pthread main
+//	-----------------------------------
+//	                      CPU (user+system): Real (wall-clock):
+//	Active   Runtime(ms)   Invoked Avg uSec Max uSecs Avg uSec Max uSecs  CPU_Warn Wall_Warn Starv_Warn   Type  Thread
+//	    0         18.000        62      290      4000      402      7161         0         0          0    T    zebra_rib_sweep
+//
+//	Total thread statistics
+//	-------------------------
+//	...
+//	    1         24.000        87      275      4000      386      7161         0         0          0 RWT    TOTAL
+//
+// The totals of each daemon are collected, and the threads of each daemon with the most CPU time up to top.
+func processThreadCPU(ch chan<- prometheus.Metric, output []byte, top int) {
+	threads := map[string][]threadStats{}
+	daemons := []string{}
+	daemon, pthread := "", ""
+	for _, line := range strings.Split(string(output), "\n") {
+		if match := threadDaemonRegexp.FindStringSubmatch(line); match != nil {
+			daemon, pthread = match[1], ""
+			daemons = append(daemons, daemon)
+			continue
+		}
+		if match := threadPthreadRegexp.FindStringSubmatch(line); match != nil {
+			pthread = strings.TrimSpace(match[1])
+			continue
+		}
+		if daemon == "" {
+			continue
+		}
+		match := threadRegexp.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		runtime, _ := strconv.ParseFloat(match[1], 64)
+		invoked, _ := strconv.ParseFloat(match[2], 64)
+		maxCPU, _ := strconv.ParseFloat(match[3], 64)
+		// The runtime is in milliseconds and the max CPU time in microseconds.
+		stats := threadStats{pthread: pthread, name: match[4], cpu: runtime / 1000, invocations: invoked, maxCPU: maxCPU / 1000000}
+		if stats.name == "TOTAL" {
+			// The labels are "daemon"
+			newCounter(ch, threadDesc["cpu"], stats.cpu, daemon)
+			newCounter(ch, threadDesc["invocations"], stats.invocations, daemon)
+			continue
+		}
+		threads[daemon] = append(threads[daemon], stats)
+	}
+	for _, daemon := range daemons {
+		busiest := threads[daemon]
+		sort.SliceStable(busiest, func(i, j int) bool {
+			return busiest[i].cpu > busiest[j].cpu
+		})
+		if len(busiest) > top {
+			busiest = busiest[:top]
+		}
+		for _, stats := range busiest {
+			// The labels are "daemon", "pthread", "thread"
+			labels := []string{daemon, stats.pthread, stats.name}
+			newCounter(ch, threadDesc["functionCPU"], stats.cpu, labels...)
+			newCounter(ch, threadDesc["functionInvocations"], stats.invocations, labels...)
+			newGauge(ch, threadDesc["functionMaxCPU"], stats.maxCPU, labels...)
+		}
+	}
+}
+
+type threadStats struct {
+	pthread     string
+	name        string
+	cpu         float64
+	invocations float64
+	maxCPU      float64
+}
@@ -0,0 +1,68 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestProcessThreadCPU(t *testing.T) {
+	threadCPU := []byte(`
+Thread statistics for zebra:
+
+Showing statistics for pthread main
+-----------------------------------
+                      CPU (user+system): Real (wall-clock):
+Active   Runtime(ms)   Invoked Avg uSec Max uSecs Avg uSec Max uSecs  CPU_Warn Wall_Warn Starv_Warn   Type  Thread
+    0         18.000        62      290      4000      402      7161         0         0          0    T    zebra_rib_sweep
+    1          2.000        12      166      1000      172      1184         0         0          0   R     vtysh_accept
+    0          1.000        10      100       500      110       600         0         0          0     E   work_queue_run
+
+Showing statistics for pthread Zebra dplane thread
+--------------------------------------------------
+                      CPU (user+system): Real (wall-clock):
+Active   Runtime(ms)   Invoked Avg uSec Max uSecs Avg uSec Max uSecs  CPU_Warn Wall_Warn Starv_Warn   Type  Thread
+    0          5.500        20      275      2000      300      2500         0         0          0     E   dplane_thread_loop
+
+Total thread statistics
+-------------------------
+                      CPU (user+system): Real (wall-clock):
+Active   Runtime(ms)   Invoked Avg uSec Max uSecs Avg uSec Max uSecs  CPU_Warn Wall_Warn Starv_Warn   Type  Thread
+    1         26.500       104      254      4000      300      7161         0         0          0 RWTE    TOTAL
+
+Thread statistics for bgpd:
+
+Showing statistics for pthread main
+-----------------------------------
+                      CPU (user+system): Real (wall-clock):
+Active   Runtime(ms)   Invoked Avg uSec Max uSecs Avg uSec Max uSecs  CPU_Warn Wall_Warn   Type  Thread
+    0        120.000       300      400     12000      420     13000         1         1    T    bgp_start_timer
+
+Total thread statistics
+-------------------------
+                      CPU (user+system): Real (wall-clock):
+Active   Runtime(ms)   Invoked Avg uSec Max uSecs Avg uSec Max uSecs  CPU_Warn Wall_Warn   Type  Thread
+    0        120.000       300      400     12000      420     13000         1         1    T    TOTAL
+`)
+
+	ch := make(chan prometheus.Metric, 1024)
+	processThreadCPU(ch, threadCPU, 2)
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, map[string]float64{
+		"frr_thread_cpu_seconds_total{daemon=zebra}":                                                                0.0265,
+		"frr_thread_cpu_seconds_total{daemon=bgpd}":                                                                 0.12,
+		"frr_thread_invocations_total{daemon=zebra}":                                                                104,
+		"frr_thread_invocations_total{daemon=bgpd}":                                                                 300,
+		"frr_thread_function_cpu_seconds_total{daemon=zebra,pthread=main,thread=zebra_rib_sweep}":                   0.018,
+		"frr_thread_function_cpu_seconds_total{daemon=zebra,pthread=Zebra dplane thread,thread=dplane_thread_loop}": 0.0055,
+		"frr_thread_function_cpu_seconds_total{daemon=bgpd,pthread=main,thread=bgp_start_timer}":                    0.12,
+		"frr_thread_function_invocations_total{daemon=zebra,pthread=main,thread=zebra_rib_sweep}":                   62,
+		"frr_thread_function_invocations_total{daemon=zebra,pthread=Zebra dplane thread,thread=dplane_thread_loop}": 20,
+		"frr_thread_function_invocations_total{daemon=bgpd,pthread=main,thread=bgp_start_timer}":                    300,
+		"frr_thread_function_max_cpu_seconds{daemon=zebra,pthread=main,thread=zebra_rib_sweep}":                     0.004,
+		"frr_thread_function_max_cpu_seconds{daemon=zebra,pthread=Zebra dplane thread,thread=dplane_thread_loop}":   0.002,
+		"frr_thread_function_max_cpu_seconds{daemon=bgpd,pthread=main,thread=bgp_start_timer}":                      0.012,
+	})
+}
@@ -0,0 +1,110 @@
+package collector
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	versionSubsystem = "version"
+
+	versionDesc = map[string]*prometheus.Desc{
+		"info": promDesc("version_info", "Version of FRR, the host name and operating system FRR runs on, and the options FRR was built with. Value is always 1.", []string{"version", "hostname", "os", "build_options"}),
+	}
+	versionErrors      = []error{}
+	totalVersionErrors = 0.0
+
+	versionRegexp = regexp.MustCompile(`^FRRouting (\S+) \((.*)\) on (.+?)\.?\s*$`)
+)
+
+// VersionCollector collects version metrics, implemented as per prometheus.Collector interface.
+type VersionCollector struct{}
+
+// NewVersionCollector returns a VersionCollector struct.
+func NewVersionCollector() *VersionCollector {
+	return &VersionCollector{}
+}
+
+// Name of the collector. Used to populate flag name.
+func (*VersionCollector) Name() string {
+	return versionSubsystem
+}
+
+// Help describes the metrics this collector scrapes. Used to populate flag help.
+func (*VersionCollector) Help() string {
+	return "Collect FRR Version Metrics"
+}
+
+// EnabledByDefault describes whether this collector is enabled by default. Used to populate flag default.
+func (*VersionCollector) EnabledByDefault() bool {
+	return false
+}
+
+// Describe implemented as per the prometheus.Collector interface.
+func (*VersionCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range versionDesc {
+		ch <- desc
+	}
+}
+
+// Collect implemented as per the prometheus.Collector interface.
+func (c *VersionCollector) Collect(ch chan<- prometheus.Metric) {
+	versionErrors = []error{}
+
+	// The version is only available as text.
+	version, err := execVtyshCommand("-c", "show version")
+	if err != nil {
+		versionErrors = append(versionErrors, fmt.Errorf("cannot get version: %s", err))
+	} else {
+		if err := processVersion(ch, version); err != nil {
+			versionErrors = append(versionErrors, err)
+		}
+	}
+
+	totalVersionErrors += float64(len(versionErrors))
+}
+
+// CollectErrors returns what errors have been gathered.
+func (*VersionCollector) CollectErrors() []error {
+	return versionErrors
+}
+
+// CollectTotalErrors returns total errors.
+func (*VersionCollector) CollectTotalErrors() float64 {
+	return totalVersionErrors
+}
+
+// processVersion processes the output of 'show version', such as:
+//
+//	FRRouting 8.4.2 (r1) on Linux(5.15.0-56-generic).
+//	Copyright 1996-2005 Kunihiro Ishiguro, et al.
+//	configured with:
+//	    '--prefix=/usr' '--sysconfdir=/etc' '--enable-snmp'
+func processVersion(ch chan<- prometheus.Metric, output []byte) error {
+	var labels []string
+	configuredWith := false
+	buildOptions := []string{}
+	for _, line := range strings.Split(string(output), "\n") {
+		if match := versionRegexp.FindStringSubmatch(line); match != nil {
+			labels = match[1:]
+			continue
+		}
+		if strings.TrimSpace(line) == "configured with:" {
+			configuredWith = true
+			continue
+		}
+		// The build options are printed on the lines that follow 'configured with:'.
+		if configuredWith && strings.TrimSpace(line) != "" {
+			buildOptions = append(buildOptions, strings.TrimSpace(line))
+		}
+	}
+	if labels == nil {
+		return fmt.Errorf("cannot find version in show version output")
+	}
+	// The labels are "version", "hostname", "os", "build_options"
+	newGauge(ch, versionDesc["info"], 1, append(labels, strings.Join(buildOptions, " "))...)
+	return nil
+}
@@ -0,0 +1,30 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestProcessVersion(t *testing.T) {
+	version := []byte(`FRRouting 8.4.2 (r1) on Linux(5.15.0-56-generic).
+Copyright 1996-2005 Kunihiro Ishiguro, et al.
+configured with:
+    '--prefix=/usr' '--sysconfdir=/etc' '--enable-snmp'
+`)
+
+	ch := make(chan prometheus.Metric, 1024)
+	if err := processVersion(ch, version); err != nil {
+		t.Errorf("error calling processVersion: %s", err)
+	}
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, map[string]float64{
+		"frr_version_info{build_options='--prefix=/usr' '--sysconfdir=/etc' '--enable-snmp',hostname=r1,os=Linux(5.15.0-56-generic),version=8.4.2}": 1,
+	})
+
+	if err := processVersion(make(chan prometheus.Metric, 1024), []byte("% Unknown command: show version\n")); err == nil {
+		t.Errorf("expected error calling processVersion without a version")
+	}
+}
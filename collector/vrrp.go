@@ -0,0 +1,174 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	vrrpSubsystem = "vrrp"
+
+	vrrpLabels = []string{"iface", "vrid", "afi"}
+	vrrpDesc   = map[string]*prometheus.Desc{
+		"master":    colPromDesc(vrrpSubsystem, "master", "Whether the router is the master of the virtual router (1 = Master, 0 = Backup or Initialize).", vrrpLabels),
+		"stateInfo": colPromDesc(vrrpSubsystem, "state_info", "State of the virtual router, such as Master, Backup or Initialize. Value is always 1.", append(vrrpLabels, "state")),
+		"priority":  colPromDesc(vrrpSubsystem, "priority", "Effective priority of the router for the virtual router.", vrrpLabels),
+		"adverInt":  colPromDesc(vrrpSubsystem, "advertisement_interval_seconds", "Configured interval between VRRP advertisements of the virtual router.", vrrpLabels),
+		"addresses": colPromDesc(vrrpSubsystem, "addresses_count_total", "Number of addresses protected by the virtual router.", vrrpLabels),
+
+		"transitions":     colPromDesc(vrrpSubsystem, "state_transitions_total", "Number of state transitions of the virtual router, such as from Backup to Master, which detects flapping between scrapes.", vrrpLabels),
+		"lastStateChange": colPromDesc(vrrpSubsystem, "last_state_change_timestamp_seconds", "Time of the scrape at which the exporter first saw the last state transition of the virtual router, in seconds since the Unix epoch.", vrrpLabels),
+	}
+	vrrpErrors      = []error{}
+	totalVRRPErrors = 0.0
+
+	// FRR does not expose when the state of a virtual router last changed, so the state of each virtual router is
+	// tracked between scrapes.
+	vrrpLastStates = map[string]vrrpState{}
+	vrrpStateMu    sync.Mutex
+)
+
+// VRRPCollector collects VRRP metrics, implemented as per prometheus.Collector interface.
+type VRRPCollector struct{}
+
+// NewVRRPCollector returns a VRRPCollector struct.
+func NewVRRPCollector() *VRRPCollector {
+	return &VRRPCollector{}
+}
+
+// Name of the collector. Used to populate flag name.
+func (*VRRPCollector) Name() string {
+	return vrrpSubsystem
+}
+
+// Help describes the metrics this collector scrapes. Used to populate flag help.
+func (*VRRPCollector) Help() string {
+	return "Collect VRRP Metrics"
+}
+
+// EnabledByDefault describes whether this collector is enabled by default. Used to populate flag default.
+func (*VRRPCollector) EnabledByDefault() bool {
+	return false
+}
+
+// Describe implemented as per the prometheus.Collector interface.
+func (*VRRPCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range vrrpDesc {
+		ch <- desc
+	}
+}
+
+// Collect implemented as per the prometheus.Collector interface.
+func (c *VRRPCollector) Collect(ch chan<- prometheus.Metric) {
+	vrrpErrors = []error{}
+
+	jsonVRRP, err := execVtyshCommand("-c", "show vrrp json")
+	if err != nil {
+		vrrpErrors = append(vrrpErrors, fmt.Errorf("cannot get vrrp: %s", err))
+	} else {
+		if err := processVRRP(ch, jsonVRRP); err != nil {
+			vrrpErrors = append(vrrpErrors, err)
+		}
+	}
+
+	totalVRRPErrors += float64(len(vrrpErrors))
+}
+
+// CollectErrors returns what errors have been gathered.
+func (*VRRPCollector) CollectErrors() []error {
+	return vrrpErrors
+}
+
+// CollectTotalErrors returns total errors.
+func (*VRRPCollector) CollectTotalErrors() float64 {
+	return totalVRRPErrors
+}
+
+func processVRRP(ch chan<- prometheus.Metric, jsonVRRP []byte) error {
+	var vrrpRouters []struct {
+		Vrid                  int
+		Interface             string
+		AdvertisementInterval float64
+		V4                    *vrrpRouter
+		V6                    *vrrpRouter
+	}
+	if err := json.Unmarshal(jsonVRRP, &vrrpRouters); err != nil {
+		return fmt.Errorf("cannot unmarshal vrrp json: %s", err)
+	}
+
+	seen := map[string]bool{}
+	for _, vr := range vrrpRouters {
+		for afi, router := range map[string]*vrrpRouter{"ipv4": vr.V4, "ipv6": vr.V6} {
+			// Virtual routers only include the address families that are configured.
+			if router == nil {
+				continue
+			}
+			// The labels are "iface", "vrid", "afi"
+			labels := []string{vr.Interface, strconv.Itoa(vr.Vrid), afi}
+			seen[strings.Join(labels, "|")] = true
+			master := 0.0
+			if router.Status == "Master" {
+				master = 1
+			}
+			newGauge(ch, vrrpDesc["master"], master, labels...)
+			newGauge(ch, vrrpDesc["stateInfo"], 1, append(labels, router.Status)...)
+			newGauge(ch, vrrpDesc["priority"], router.EffectivePriority, labels...)
+			// The advertisement interval is in milliseconds.
+			newGauge(ch, vrrpDesc["adverInt"], vr.AdvertisementInterval*0.001, labels...)
+			newGauge(ch, vrrpDesc["addresses"], float64(len(router.Addresses)), labels...)
+			newCounter(ch, vrrpDesc["transitions"], router.Stats.TransitionCnt, labels...)
+			if lastStateChange := vrrpStateChange(strings.Join(labels, "|"), router.Status, router.Stats.TransitionCnt); lastStateChange != 0 {
+				newGauge(ch, vrrpDesc["lastStateChange"], lastStateChange, labels...)
+			}
+		}
+	}
+	vrrpPrune(seen)
+	return nil
+}
+
+// vrrpStateChange records the state of a virtual router and returns the timestamp of when its last state change was
+// seen, or 0 if no state change has been seen since the exporter started.
+func vrrpStateChange(key string, status string, transitions float64) float64 {
+	vrrpStateMu.Lock()
+	defer vrrpStateMu.Unlock()
+
+	state, exist := vrrpLastStates[key]
+	if exist && (state.status != status || state.transitions != transitions) {
+		state.changed = float64(timeNow().Unix())
+	}
+	state.status, state.transitions = status, transitions
+	vrrpLastStates[key] = state
+	return state.changed
+}
+
+// vrrpPrune removes the virtual routers that are no longer configured.
+func vrrpPrune(seen map[string]bool) {
+	vrrpStateMu.Lock()
+	defer vrrpStateMu.Unlock()
+
+	for key := range vrrpLastStates {
+		if !seen[key] {
+			delete(vrrpLastStates, key)
+		}
+	}
+}
+
+type vrrpState struct {
+	status      string
+	transitions float64
+	changed     float64
+}
+
+type vrrpRouter struct {
+	Status            string
+	EffectivePriority float64
+	Addresses         []string
+	Stats             struct {
+		TransitionCnt float64
+	}
+}
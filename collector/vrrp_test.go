@@ -0,0 +1,124 @@
+package collector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var vrrpJSON = []byte(`[
+  {
+    "vrid":5,
+    "version":3,
+    "autoconfigured":false,
+    "shutdown":false,
+    "preemptMode":true,
+    "acceptMode":true,
+    "interface":"swp1",
+    "advertisementInterval":1000,
+    "v4":{
+      "interface":"vrrp4-2-5",
+      "vmac":"00:00:5e:00:01:05",
+      "primaryAddress":"10.0.1.2",
+      "status":"Master",
+      "effectivePriority":200,
+      "masterAdverInterval":1000,
+      "skewTime":210,
+      "masterDownInterval":3210,
+      "stats":{"adverTxCnt":3600,"adverRxCnt":2,"garpTxCnt":1,"transitionCnt":3},
+      "addresses":["10.0.1.1","10.0.1.100"]
+    },
+    "v6":{
+      "interface":"vrrp6-2-5",
+      "vmac":"00:00:5e:00:02:05",
+      "primaryAddress":"",
+      "status":"Initialize",
+      "effectivePriority":200,
+      "masterAdverInterval":0,
+      "skewTime":0,
+      "masterDownInterval":0,
+      "stats":{"adverTxCnt":0,"adverRxCnt":0,"neighborAdverTxCnt":0,"transitionCnt":0},
+      "addresses":[]
+    }
+  },
+  {
+    "vrid":10,
+    "version":2,
+    "autoconfigured":false,
+    "shutdown":false,
+    "preemptMode":true,
+    "acceptMode":false,
+    "interface":"swp2",
+    "advertisementInterval":3000,
+    "v4":{
+      "interface":"vrrp4-3-10",
+      "vmac":"00:00:5e:00:01:0a",
+      "primaryAddress":"10.0.2.2",
+      "status":"Backup",
+      "effectivePriority":100,
+      "masterAdverInterval":3000,
+      "skewTime":1830,
+      "masterDownInterval":10830,
+      "stats":{"adverTxCnt":1,"adverRxCnt":1200,"garpTxCnt":0,"transitionCnt":2},
+      "addresses":["10.0.2.1"]
+    }
+  }
+]`)
+
+func TestProcessVRRP(t *testing.T) {
+	defer func() { vrrpLastStates = map[string]vrrpState{} }()
+
+	ch := make(chan prometheus.Metric, 1024)
+	if err := processVRRP(ch, vrrpJSON); err != nil {
+		t.Errorf("error calling processVRRP: %s", err)
+	}
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, map[string]float64{
+		"frr_vrrp_master{afi=ipv4,iface=swp1,vrid=5}":                          1,
+		"frr_vrrp_master{afi=ipv6,iface=swp1,vrid=5}":                          0,
+		"frr_vrrp_master{afi=ipv4,iface=swp2,vrid=10}":                         0,
+		"frr_vrrp_state_info{afi=ipv4,iface=swp1,state=Master,vrid=5}":         1,
+		"frr_vrrp_state_info{afi=ipv6,iface=swp1,state=Initialize,vrid=5}":     1,
+		"frr_vrrp_state_info{afi=ipv4,iface=swp2,state=Backup,vrid=10}":        1,
+		"frr_vrrp_priority{afi=ipv4,iface=swp1,vrid=5}":                        200,
+		"frr_vrrp_priority{afi=ipv6,iface=swp1,vrid=5}":                        200,
+		"frr_vrrp_priority{afi=ipv4,iface=swp2,vrid=10}":                       100,
+		"frr_vrrp_advertisement_interval_seconds{afi=ipv4,iface=swp1,vrid=5}":  1,
+		"frr_vrrp_advertisement_interval_seconds{afi=ipv6,iface=swp1,vrid=5}":  1,
+		"frr_vrrp_advertisement_interval_seconds{afi=ipv4,iface=swp2,vrid=10}": 3,
+		"frr_vrrp_addresses_count_total{afi=ipv4,iface=swp1,vrid=5}":           2,
+		"frr_vrrp_addresses_count_total{afi=ipv6,iface=swp1,vrid=5}":           0,
+		"frr_vrrp_addresses_count_total{afi=ipv4,iface=swp2,vrid=10}":          1,
+		"frr_vrrp_state_transitions_total{afi=ipv4,iface=swp1,vrid=5}":         3,
+		"frr_vrrp_state_transitions_total{afi=ipv6,iface=swp1,vrid=5}":         0,
+		"frr_vrrp_state_transitions_total{afi=ipv4,iface=swp2,vrid=10}":        2,
+	})
+}
+
+func TestVRRPStateChange(t *testing.T) {
+	defer func() { vrrpLastStates = map[string]vrrpState{} }()
+	defer func() { timeNow = time.Now }()
+
+	for i, state := range []struct {
+		status      string
+		transitions float64
+	}{{"Backup", 2}, {"Backup", 2}, {"Master", 3}, {"Master", 3}, {"Master", 5}} {
+		timeNow = func() time.Time { return time.Unix(1600000000+int64(i), 0) }
+		changed := vrrpStateChange("swp1|5|ipv4", state.status, state.transitions)
+		if expected := []float64{0, 0, 1600000002, 1600000002, 1600000004}[i]; changed != expected {
+			t.Errorf("state change %d to %q expected %v got %v", i, state.status, expected, changed)
+		}
+	}
+
+	vrrpStateChange("swp2|10|ipv4", "Master", 1)
+	vrrpPrune(map[string]bool{"swp2|10|ipv4": true})
+	if _, exist := vrrpLastStates["swp1|5|ipv4"]; exist {
+		t.Errorf("expected the state of the removed virtual router to be removed")
+	}
+	if _, exist := vrrpLastStates["swp2|10|ipv4"]; !exist {
+		t.Errorf("expected the state of the configured virtual router to be kept")
+	}
+}
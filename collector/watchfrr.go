@@ -0,0 +1,184 @@
+package collector
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	watchfrrSubsystem = "watchfrr"
+
+	watchfrrDaemonLabels = []string{"daemon"}
+	watchfrrDesc         = map[string]*prometheus.Desc{
+		"phaseInfo":  colPromDesc(watchfrrSubsystem, "phase_info", "Global phase of watchfrr, such as Idle or Zebra restarting. Value is always 1.", []string{"phase"}),
+		"up":         colPromDesc(watchfrrSubsystem, "daemon_up", "State of the daemon monitored by watchfrr (1 = Up, 0 = Init, Down or Unresponsive).", watchfrrDaemonLabels),
+		"stateInfo":  colPromDesc(watchfrrSubsystem, "daemon_state_info", "State of the daemon monitored by watchfrr, such as Up, Down or Unresponsive. Value is always 1.", append(watchfrrDaemonLabels, "state")),
+		"restarting": colPromDesc(watchfrrSubsystem, "daemon_restarting", "Whether watchfrr is restarting the daemon or waiting for the restart backoff interval to restart it (1 = Restarting, 0 = Not restarting).", watchfrrDaemonLabels),
+		"restarts":   colPromDesc(watchfrrSubsystem, "daemon_restarts_total", "Number of times the process ID of the daemon has changed, such as when watchfrr restarts it, seen by the exporter since it started.", watchfrrDaemonLabels),
+	}
+	watchfrrErrors      = []error{}
+	totalWatchfrrErrors = 0.0
+
+	watchfrrPhaseRegexp      = regexp.MustCompile(`^watchfrr global phase: (.+?)\s*$`)
+	watchfrrDaemonRegexp     = regexp.MustCompile(`^\s+(\S+)\s+(Init|Up|Down|Unresponsive)(?:/.*)?\s*$`)
+	watchfrrRestartingRegexp = regexp.MustCompile(`^\s+(?:restart running|restarting in)`)
+
+	// FRR does not count the restarts of the daemons, so the process ID of each daemon is tracked between scrapes. A
+	// restart by watchfrr usually completes within a scrape interval, so the state of the daemon cannot be used.
+	watchfrrLastPIDs = map[string]string{}
+	watchfrrRestarts = map[string]float64{}
+	watchfrrStateMu  sync.Mutex
+)
+
+// WatchfrrCollector collects watchfrr metrics, implemented as per prometheus.Collector interface.
+type WatchfrrCollector struct{}
+
+// NewWatchfrrCollector returns a WatchfrrCollector struct.
+func NewWatchfrrCollector() *WatchfrrCollector {
+	return &WatchfrrCollector{}
+}
+
+// Name of the collector. Used to populate flag name.
+func (*WatchfrrCollector) Name() string {
+	return watchfrrSubsystem
+}
+
+// Help describes the metrics this collector scrapes. Used to populate flag help.
+func (*WatchfrrCollector) Help() string {
+	return "Collect watchfrr Metrics"
+}
+
+// EnabledByDefault describes whether this collector is enabled by default. Used to populate flag default.
+func (*WatchfrrCollector) EnabledByDefault() bool {
+	return false
+}
+
+// Describe implemented as per the prometheus.Collector interface.
+func (*WatchfrrCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range watchfrrDesc {
+		ch <- desc
+	}
+}
+
+// Collect implemented as per the prometheus.Collector interface.
+func (c *WatchfrrCollector) Collect(ch chan<- prometheus.Metric) {
+	watchfrrErrors = []error{}
+
+	// The status of watchfrr is only available as text.
+	watchfrr, err := execVtyshCommand("-c", "show watchfrr")
+	if err != nil {
+		watchfrrErrors = append(watchfrrErrors, fmt.Errorf("cannot get watchfrr: %s", err))
+	} else {
+		watchfrrErrors = append(watchfrrErrors, processWatchfrr(ch, watchfrr, *daemonPIDDir)...)
+	}
+
+	totalWatchfrrErrors += float64(len(watchfrrErrors))
+}
+
+// CollectErrors returns what errors have been gathered.
+func (*WatchfrrCollector) CollectErrors() []error {
+	return watchfrrErrors
+}
+
+// CollectTotalErrors returns total errors.
+func (*WatchfrrCollector) CollectTotalErrors() float64 {
+	return totalWatchfrrErrors
+}
+
+// processWatchfrr processes the output of 'show watchfrr', such as:
+//
+//	watchfrr global phase: Idle
+//	 Restart Command: "/usr/lib/frr/watchfrr.sh restart %s"
+//	 ...
+//	  zebra                Up
+//	  bgpd                 Down
+//	      restarting in 42 seconds (60s backoff interval)
+//
+// The restarts of the daemons are counted from the changes of the process ID in the PID files of pidDir.
+func processWatchfrr(ch chan<- prometheus.Metric, output []byte, pidDir string) []error {
+	errors := []error{}
+	daemons := []string{}
+	states, restarting := map[string]string{}, map[string]float64{}
+	daemon := ""
+	for _, line := range strings.Split(string(output), "\n") {
+		if match := watchfrrPhaseRegexp.FindStringSubmatch(line); match != nil {
+			// The labels are "phase"
+			newGauge(ch, watchfrrDesc["phaseInfo"], 1, match[1])
+			continue
+		}
+		if match := watchfrrDaemonRegexp.FindStringSubmatch(line); match != nil {
+			daemon = match[1]
+			daemons = append(daemons, daemon)
+			states[daemon] = match[2]
+			continue
+		}
+		// The restart of a daemon is printed after the state of the daemon.
+		if daemon != "" && watchfrrRestartingRegexp.MatchString(line) {
+			restarting[daemon] = 1
+		}
+	}
+	for _, daemon := range daemons {
+		// The labels are "daemon"
+		up := 0.0
+		if states[daemon] == "Up" {
+			up = 1
+		}
+		newGauge(ch, watchfrrDesc["up"], up, daemon)
+		newGauge(ch, watchfrrDesc["stateInfo"], 1, daemon, states[daemon])
+		newGauge(ch, watchfrrDesc["restarting"], restarting[daemon], daemon)
+
+		// The PID file of a daemon that is not up may be missing or stale, so the last process ID is kept until the
+		// daemon is up again.
+		pid := ""
+		if states[daemon] == "Up" {
+			pidFile, err := ioutil.ReadFile(filepath.Join(pidDir, daemon+".pid"))
+			if err != nil {
+				errors = append(errors, fmt.Errorf("cannot read pid file of daemon %s: %s", daemon, err))
+			} else {
+				pid = strings.TrimSpace(string(pidFile))
+			}
+		}
+		newCounter(ch, watchfrrDesc["restarts"], watchfrrRestart(daemon, pid), daemon)
+	}
+	watchfrrPrune(daemons)
+	return errors
+}
+
+// watchfrrRestart records the process ID of a daemon and returns the number of times the process ID has changed. An
+// empty process ID, such as of a daemon that is not up, is not recorded.
+func watchfrrRestart(daemon string, pid string) float64 {
+	watchfrrStateMu.Lock()
+	defer watchfrrStateMu.Unlock()
+
+	if pid == "" {
+		return watchfrrRestarts[daemon]
+	}
+	if lastPID, exist := watchfrrLastPIDs[daemon]; exist && lastPID != pid {
+		watchfrrRestarts[daemon]++
+	}
+	watchfrrLastPIDs[daemon] = pid
+	return watchfrrRestarts[daemon]
+}
+
+// watchfrrPrune removes the daemons that are no longer monitored by watchfrr.
+func watchfrrPrune(daemons []string) {
+	watchfrrStateMu.Lock()
+	defer watchfrrStateMu.Unlock()
+
+	seen := map[string]bool{}
+	for _, daemon := range daemons {
+		seen[daemon] = true
+	}
+	for daemon := range watchfrrLastPIDs {
+		if !seen[daemon] {
+			delete(watchfrrLastPIDs, daemon)
+			delete(watchfrrRestarts, daemon)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package collector
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestProcessWatchfrr(t *testing.T) {
+	defer func() {
+		watchfrrLastPIDs, watchfrrRestarts = map[string]string{}, map[string]float64{}
+	}()
+
+	pidDir, err := ioutil.TempDir("", "watchfrr")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(pidDir)
+	writePID := func(daemon string, pid string) {
+		if err := ioutil.WriteFile(filepath.Join(pidDir, daemon+".pid"), []byte(pid+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writePID("zebra", "100")
+	writePID("staticd", "200")
+
+	watchfrr := []byte(`watchfrr global phase: Idle
+ Restart Command: "/usr/lib/frr/watchfrr.sh restart %s"
+ Start Command: "/usr/lib/frr/watchfrr.sh start %s"
+ Stop Command: "/usr/lib/frr/watchfrr.sh stop %s"
+ Min Restart Interval: 60
+ Max Restart Interval: 600
+ Restart Timeout: 20
+  zebra                Up
+  bgpd                 Down
+      restarting in 42 seconds (60s backoff interval)
+  ospfd                Unresponsive/Ignoring Timeout
+  staticd              Up
+`)
+
+	ch := make(chan prometheus.Metric, 1024)
+	if errors := processWatchfrr(ch, watchfrr, pidDir); len(errors) != 0 {
+		t.Errorf("error calling processWatchfrr: %v", errors)
+	}
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, map[string]float64{
+		"frr_watchfrr_phase_info{phase=Idle}":                             1,
+		"frr_watchfrr_daemon_up{daemon=zebra}":                            1,
+		"frr_watchfrr_daemon_up{daemon=bgpd}":                             0,
+		"frr_watchfrr_daemon_up{daemon=ospfd}":                            0,
+		"frr_watchfrr_daemon_up{daemon=staticd}":                          1,
+		"frr_watchfrr_daemon_state_info{daemon=zebra,state=Up}":           1,
+		"frr_watchfrr_daemon_state_info{daemon=bgpd,state=Down}":          1,
+		"frr_watchfrr_daemon_state_info{daemon=ospfd,state=Unresponsive}": 1,
+		"frr_watchfrr_daemon_state_info{daemon=staticd,state=Up}":         1,
+		"frr_watchfrr_daemon_restarting{daemon=zebra}":                    0,
+		"frr_watchfrr_daemon_restarting{daemon=bgpd}":                     1,
+		"frr_watchfrr_daemon_restarting{daemon=ospfd}":                    0,
+		"frr_watchfrr_daemon_restarting{daemon=staticd}":                  0,
+		"frr_watchfrr_daemon_restarts_total{daemon=zebra}":                0,
+		"frr_watchfrr_daemon_restarts_total{daemon=bgpd}":                 0,
+		"frr_watchfrr_daemon_restarts_total{daemon=ospfd}":                0,
+		"frr_watchfrr_daemon_restarts_total{daemon=staticd}":              0,
+	})
+}
+
+func TestWatchfrrRestart(t *testing.T) {
+	defer func() {
+		watchfrrLastPIDs, watchfrrRestarts = map[string]string{}, map[string]float64{}
+	}()
+
+	// A restart is counted even if the daemon was not seen down, and the PID of a daemon that is not up is empty.
+	for i, pid := range []string{"100", "100", "101", "", "", "102", "102"} {
+		restarts := watchfrrRestart("bgpd", pid)
+		if expected := []float64{0, 0, 1, 1, 1, 2, 2}[i]; restarts != expected {
+			t.Errorf("restart %d with pid %q expected %v got %v", i, pid, expected, restarts)
+		}
+	}
+
+	watchfrrPrune([]string{"zebra"})
+	if _, exist := watchfrrLastPIDs["bgpd"]; exist {
+		t.Errorf("expected bgpd to be pruned")
+	}
+	if restarts := watchfrrRestart("bgpd", "103"); restarts != 0 {
+		t.Errorf("expected restarts of pruned bgpd to be reset, got %v", restarts)
+	}
+}
@@ -0,0 +1,193 @@
+package collector
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	zebraSubsystem = "zebra"
+
+	zebraWorkQueueLabels = []string{"daemon", "queue"}
+	zebraProviderLabels  = []string{"provider"}
+	zebraDesc            = map[string]*prometheus.Desc{
+		"workQueueItems":   colPromDesc(zebraSubsystem, "work_queue_items", "Number of items queued in the work queue of the daemon.", zebraWorkQueueLabels),
+		"workQueuePlugged": colPromDesc(zebraSubsystem, "work_queue_plugged", "Whether the work queue of the daemon is plugged, which holds the processing of the queued items (1 = Plugged, 0 = Unplugged).", zebraWorkQueueLabels),
+		"workQueueRuns":    colPromDesc(zebraSubsystem, "work_queue_runs_total", "Number of times the work queue of the daemon has been run.", zebraWorkQueueLabels),
+		"workQueueYields":  colPromDesc(zebraSubsystem, "work_queue_yields_total", "Number of times the work queue of the daemon has yielded before the queued items were processed.", zebraWorkQueueLabels),
+
+		"providerIn":          colPromDesc(zebraSubsystem, "dplane_provider_in_total", "Number of dataplane contexts received by the dataplane provider.", zebraProviderLabels),
+		"providerInQueue":     colPromDesc(zebraSubsystem, "dplane_provider_in_queue_depth", "Number of dataplane contexts queued to be processed by the dataplane provider.", zebraProviderLabels),
+		"providerInQueueMax":  colPromDesc(zebraSubsystem, "dplane_provider_in_queue_max_depth", "Highest number of dataplane contexts queued to be processed by the dataplane provider since zebra started.", zebraProviderLabels),
+		"providerOut":         colPromDesc(zebraSubsystem, "dplane_provider_out_total", "Number of dataplane contexts processed by the dataplane provider.", zebraProviderLabels),
+		"providerOutQueue":    colPromDesc(zebraSubsystem, "dplane_provider_out_queue_depth", "Number of dataplane contexts processed by the dataplane provider that are queued to the next provider.", zebraProviderLabels),
+		"providerOutQueueMax": colPromDesc(zebraSubsystem, "dplane_provider_out_queue_max_depth", "Highest number of dataplane contexts processed by the dataplane provider that were queued to the next provider since zebra started.", zebraProviderLabels),
+		"dplaneUpdates":       colPromDesc(zebraSubsystem, "dplane_updates_total", "Number of updates processed by the zebra dataplane per update type other than routes, such as LSP, PW or EVPN MAC.", []string{"type"}),
+		"dplaneUpdateErrors":  colPromDesc(zebraSubsystem, "dplane_update_errors_total", "Number of updates that the zebra dataplane failed to install per update type other than routes, such as LSP, PW or EVPN MAC.", []string{"type"}),
+	}
+	zebraErrors      = []error{}
+	totalZebraErrors = 0.0
+
+	zebraWorkQueueDaemonRegexp = regexp.MustCompile(`^Work queue statistics for (\S+):`)
+	// The columns are the plugged flag, Items, Hold, Q. Runs, Yields, the cycle counts Best, Gran., Total and
+	// Avg., and the name of the queue.
+	zebraWorkQueueRegexp = regexp.MustCompile(`^([ P])\s+(\d+)\s+\d+\s+(\d+)\s+(\d+)\s+\d+\s+\d+\s+\d+\s+\d+\s+(.+?)\s*$`)
+	zebraProviderRegexp  = regexp.MustCompile(`^(.+?) \(\d+\): in: (\d+), q: (\d+), q_max: (\d+), out: (\d+), q: (\d+), q_max: (\d+)`)
+	zebraDplaneRegexp    = regexp.MustCompile(`^(.+?) (updates|update errors|errors)\s*:\s*(\d+)\s*$`)
+)
+
+// ZebraCollector collects zebra metrics, implemented as per prometheus.Collector interface.
+type ZebraCollector struct{}
+
+// NewZebraCollector returns a ZebraCollector struct.
+func NewZebraCollector() *ZebraCollector {
+	return &ZebraCollector{}
+}
+
+// Name of the collector. Used to populate flag name.
+func (*ZebraCollector) Name() string {
+	return zebraSubsystem
+}
+
+// Help describes the metrics this collector scrapes. Used to populate flag help.
+func (*ZebraCollector) Help() string {
+	return "Collect Zebra Dataplane and Work Queue Metrics"
+}
+
+// EnabledByDefault describes whether this collector is enabled by default. Used to populate flag default.
+func (*ZebraCollector) EnabledByDefault() bool {
+	return false
+}
+
+// Describe implemented as per the prometheus.Collector interface.
+func (*ZebraCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range zebraDesc {
+		ch <- desc
+	}
+}
+
+// Collect implemented as per the prometheus.Collector interface.
+func (c *ZebraCollector) Collect(ch chan<- prometheus.Metric) {
+	zebraErrors = []error{}
+
+	// The work queues and dataplane statistics are only available as text.
+	workQueues, err := execVtyshCommand("-c", "show work-queues")
+	if err != nil {
+		zebraErrors = append(zebraErrors, fmt.Errorf("cannot get work queues: %s", err))
+	} else {
+		processZebraWorkQueues(ch, workQueues)
+	}
+
+	providers, err := execVtyshCommand("-c", "show zebra dplane providers")
+	if err != nil {
+		zebraErrors = append(zebraErrors, fmt.Errorf("cannot get zebra dplane providers: %s", err))
+	} else {
+		processZebraDplaneProviders(ch, providers)
+	}
+
+	dplane, err := execVtyshCommand("-c", "show zebra dplane detailed")
+	if err != nil {
+		zebraErrors = append(zebraErrors, fmt.Errorf("cannot get zebra dplane: %s", err))
+	} else {
+		processZebraDplane(ch, dplane)
+	}
+
+	totalZebraErrors += float64(len(zebraErrors))
+}
+
+// CollectErrors returns what errors have been gathered.
+func (*ZebraCollector) CollectErrors() []error {
+	return zebraErrors
+}
+
+// CollectTotalErrors returns total errors.
+func (*ZebraCollector) CollectTotalErrors() float64 {
+	return totalZebraErrors
+}
+
+// processZebraWorkQueues processes the output of 'show work-queues', which vtysh prints for each daemon, such as:
+//
+//	Work queue statistics for zebra:
+//	      List  (ms)   Q. Runs   Yields        Cycle Counts
+//	P    Items  Hold    Total    Total    Best  Gran.    Total   Avg. Name
+//	         0    10      273        0       4      1     3011     11 route_node processing
+func processZebraWorkQueues(ch chan<- prometheus.Metric, output []byte) {
+	daemon := ""
+	for _, line := range strings.Split(string(output), "\n") {
+		if match := zebraWorkQueueDaemonRegexp.FindStringSubmatch(line); match != nil {
+			daemon = match[1]
+			continue
+		}
+		match := zebraWorkQueueRegexp.FindStringSubmatch(line)
+		if daemon == "" || match == nil {
+			continue
+		}
+		// The labels are "daemon", "queue"
+		labels := []string{daemon, match[5]}
+		plugged := 0.0
+		if match[1] == "P" {
+			plugged = 1
+		}
+		items, _ := strconv.ParseFloat(match[2], 64)
+		runs, _ := strconv.ParseFloat(match[3], 64)
+		yields, _ := strconv.ParseFloat(match[4], 64)
+		newGauge(ch, zebraDesc["workQueueItems"], items, labels...)
+		newGauge(ch, zebraDesc["workQueuePlugged"], plugged, labels...)
+		newCounter(ch, zebraDesc["workQueueRuns"], runs, labels...)
+		newCounter(ch, zebraDesc["workQueueYields"], yields, labels...)
+	}
+}
+
+// processZebraDplaneProviders processes the output of 'show zebra dplane providers', such as:
+//
+//	Zebra dataplane providers:
+//	Kernel (1): in: 24, q: 0, q_max: 3, out: 24, q: 0, q_max: 3
+func processZebraDplaneProviders(ch chan<- prometheus.Metric, output []byte) {
+	for _, line := range strings.Split(string(output), "\n") {
+		match := zebraProviderRegexp.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		values := make([]float64, 6)
+		for i := range values {
+			values[i], _ = strconv.ParseFloat(match[i+2], 64)
+		}
+		// The labels are "provider"
+		newCounter(ch, zebraDesc["providerIn"], values[0], match[1])
+		newGauge(ch, zebraDesc["providerInQueue"], values[1], match[1])
+		newGauge(ch, zebraDesc["providerInQueueMax"], values[2], match[1])
+		newCounter(ch, zebraDesc["providerOut"], values[3], match[1])
+		newGauge(ch, zebraDesc["providerOutQueue"], values[4], match[1])
+		newGauge(ch, zebraDesc["providerOutQueueMax"], values[5], match[1])
+	}
+}
+
+// processZebraDplane processes the update counters of 'show zebra dplane detailed' other than the route counters,
+// which are collected by the route collector, such as:
+//
+//	LSP updates:              2
+//	LSP update errors:        0
+//	Intf addr updates:        12
+//	Intf addr errors:         0
+func processZebraDplane(ch chan<- prometheus.Metric, output []byte) {
+	for _, line := range strings.Split(string(output), "\n") {
+		match := zebraDplaneRegexp.FindStringSubmatch(line)
+		if match == nil || match[1] == "Route" || match[1] == "Other" {
+			continue
+		}
+		value, err := strconv.ParseFloat(match[3], 64)
+		if err != nil {
+			continue
+		}
+		// The labels are "type"
+		if match[2] == "updates" {
+			newCounter(ch, zebraDesc["dplaneUpdates"], value, match[1])
+		} else {
+			newCounter(ch, zebraDesc["dplaneUpdateErrors"], value, match[1])
+		}
+	}
+}
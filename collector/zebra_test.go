@@ -0,0 +1,103 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestProcessZebraWorkQueues(t *testing.T) {
+	workQueues := []byte(`Work queue statistics for zebra:
+      List  (ms)   Q. Runs   Yields        Cycle Counts
+P    Items  Hold    Total    Total    Best  Gran.    Total   Avg. Name
+         5    10      273        2       4      1     3011     11 route_node processing
+P        0    10       12        0       1      1       12      1 zebra_evpn_mh
+Work queue statistics for bgpd:
+      List  (ms)   Q. Runs   Yields        Cycle Counts
+P    Items  Hold    Total    Total    Best  Gran.    Total   Avg. Name
+         0    10       64        0       8      1      520      8 process_queue
+`)
+
+	ch := make(chan prometheus.Metric, 1024)
+	processZebraWorkQueues(ch, workQueues)
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, map[string]float64{
+		"frr_zebra_work_queue_items{daemon=zebra,queue=route_node processing}":        5,
+		"frr_zebra_work_queue_items{daemon=zebra,queue=zebra_evpn_mh}":                0,
+		"frr_zebra_work_queue_items{daemon=bgpd,queue=process_queue}":                 0,
+		"frr_zebra_work_queue_plugged{daemon=zebra,queue=route_node processing}":      0,
+		"frr_zebra_work_queue_plugged{daemon=zebra,queue=zebra_evpn_mh}":              1,
+		"frr_zebra_work_queue_plugged{daemon=bgpd,queue=process_queue}":               0,
+		"frr_zebra_work_queue_runs_total{daemon=zebra,queue=route_node processing}":   273,
+		"frr_zebra_work_queue_runs_total{daemon=zebra,queue=zebra_evpn_mh}":           12,
+		"frr_zebra_work_queue_runs_total{daemon=bgpd,queue=process_queue}":            64,
+		"frr_zebra_work_queue_yields_total{daemon=zebra,queue=route_node processing}": 2,
+		"frr_zebra_work_queue_yields_total{daemon=zebra,queue=zebra_evpn_mh}":         0,
+		"frr_zebra_work_queue_yields_total{daemon=bgpd,queue=process_queue}":          0,
+	})
+}
+
+func TestProcessZebraDplaneProviders(t *testing.T) {
+	providers := []byte(`Zebra dataplane providers:
+Kernel (1): in: 24, q: 2, q_max: 8, out: 22, q: 0, q_max: 3
+dplane_fpm_nl (2): in: 22, q: 0, q_max: 5, out: 22, q: 1, q_max: 4
+`)
+
+	ch := make(chan prometheus.Metric, 1024)
+	processZebraDplaneProviders(ch, providers)
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, map[string]float64{
+		"frr_zebra_dplane_provider_in_total{provider=Kernel}":                   24,
+		"frr_zebra_dplane_provider_in_total{provider=dplane_fpm_nl}":            22,
+		"frr_zebra_dplane_provider_in_queue_depth{provider=Kernel}":             2,
+		"frr_zebra_dplane_provider_in_queue_depth{provider=dplane_fpm_nl}":      0,
+		"frr_zebra_dplane_provider_in_queue_max_depth{provider=Kernel}":         8,
+		"frr_zebra_dplane_provider_in_queue_max_depth{provider=dplane_fpm_nl}":  5,
+		"frr_zebra_dplane_provider_out_total{provider=Kernel}":                  22,
+		"frr_zebra_dplane_provider_out_total{provider=dplane_fpm_nl}":           22,
+		"frr_zebra_dplane_provider_out_queue_depth{provider=Kernel}":            0,
+		"frr_zebra_dplane_provider_out_queue_depth{provider=dplane_fpm_nl}":     1,
+		"frr_zebra_dplane_provider_out_queue_max_depth{provider=Kernel}":        3,
+		"frr_zebra_dplane_provider_out_queue_max_depth{provider=dplane_fpm_nl}": 4,
+	})
+}
+
+func TestProcessZebraDplane(t *testing.T) {
+	dplane := []byte(`Zebra dataplane:
+Route updates:            24
+Route update errors:      0
+Other errors       :      0
+Route update queue limit: 200
+Route update queue depth: 0
+Route update queue max:   3
+Dplane update yields:      3
+LSP updates:              2
+LSP update errors:        1
+PW updates:               0
+PW update errors:         0
+Intf addr updates:        12
+Intf addr errors:         0
+EVPN MAC updates:         4
+EVPN MAC errors:          0
+`)
+
+	ch := make(chan prometheus.Metric, 1024)
+	processZebraDplane(ch, dplane)
+	close(ch)
+
+	gotMetrics := prepareMetrics(ch, t)
+	compareMetrics(t, gotMetrics, map[string]float64{
+		"frr_zebra_dplane_updates_total{type=LSP}":             2,
+		"frr_zebra_dplane_updates_total{type=PW}":              0,
+		"frr_zebra_dplane_updates_total{type=Intf addr}":       12,
+		"frr_zebra_dplane_updates_total{type=EVPN MAC}":        4,
+		"frr_zebra_dplane_update_errors_total{type=LSP}":       1,
+		"frr_zebra_dplane_update_errors_total{type=PW}":        0,
+		"frr_zebra_dplane_update_errors_total{type=Intf addr}": 0,
+		"frr_zebra_dplane_update_errors_total{type=EVPN MAC}":  0,
+	})
+}
@@ -52,6 +52,251 @@ func initCollectors() {
 		Errors:        bgpl2vpn,
 		CLIHelper:     bgpl2vpn,
 	})
+	rpki := collector.NewRPKICollector()
+	collectors = append(collectors, &collector.Collector{
+		Name:          rpki.Name(),
+		PromCollector: rpki,
+		Errors:        rpki,
+		CLIHelper:     rpki,
+	})
+	bgpNexthop := collector.NewBGPNexthopCollector()
+	collectors = append(collectors, &collector.Collector{
+		Name:          bgpNexthop.Name(),
+		PromCollector: bgpNexthop,
+		Errors:        bgpNexthop,
+		CLIHelper:     bgpNexthop,
+	})
+	bmp := collector.NewBMPCollector()
+	collectors = append(collectors, &collector.Collector{
+		Name:          bmp.Name(),
+		PromCollector: bmp,
+		Errors:        bmp,
+		CLIHelper:     bmp,
+	})
+	bgpStatistics := collector.NewBGPStatisticsCollector()
+	collectors = append(collectors, &collector.Collector{
+		Name:          bgpStatistics.Name(),
+		PromCollector: bgpStatistics,
+		Errors:        bgpStatistics,
+		CLIHelper:     bgpStatistics,
+	})
+	ospfDatabase := collector.NewOSPFDatabaseCollector()
+	collectors = append(collectors, &collector.Collector{
+		Name:          ospfDatabase.Name(),
+		PromCollector: ospfDatabase,
+		Errors:        ospfDatabase,
+		CLIHelper:     ospfDatabase,
+	})
+	ospf6 := collector.NewOSPF6Collector()
+	collectors = append(collectors, &collector.Collector{
+		Name:          ospf6.Name(),
+		PromCollector: ospf6,
+		Errors:        ospf6,
+		CLIHelper:     ospf6,
+	})
+	ospfSR := collector.NewOSPFSRCollector()
+	collectors = append(collectors, &collector.Collector{
+		Name:          ospfSR.Name(),
+		PromCollector: ospfSR,
+		Errors:        ospfSR,
+		CLIHelper:     ospfSR,
+	})
+	isis := collector.NewISISCollector()
+	collectors = append(collectors, &collector.Collector{
+		Name:          isis.Name(),
+		PromCollector: isis,
+		Errors:        isis,
+		CLIHelper:     isis,
+	})
+	isisDatabase := collector.NewISISDatabaseCollector()
+	collectors = append(collectors, &collector.Collector{
+		Name:          isisDatabase.Name(),
+		PromCollector: isisDatabase,
+		Errors:        isisDatabase,
+		CLIHelper:     isisDatabase,
+	})
+	isisSR := collector.NewISISSRCollector()
+	collectors = append(collectors, &collector.Collector{
+		Name:          isisSR.Name(),
+		PromCollector: isisSR,
+		Errors:        isisSR,
+		CLIHelper:     isisSR,
+	})
+	bfd := collector.NewBFDCollector()
+	collectors = append(collectors, &collector.Collector{
+		Name:          bfd.Name(),
+		PromCollector: bfd,
+		Errors:        bfd,
+		CLIHelper:     bfd,
+	})
+	pim := collector.NewPIMCollector()
+	collectors = append(collectors, &collector.Collector{
+		Name:          pim.Name(),
+		PromCollector: pim,
+		Errors:        pim,
+		CLIHelper:     pim,
+	})
+	vrrp := collector.NewVRRPCollector()
+	collectors = append(collectors, &collector.Collector{
+		Name:          vrrp.Name(),
+		PromCollector: vrrp,
+		Errors:        vrrp,
+		CLIHelper:     vrrp,
+	})
+	ldp := collector.NewLDPCollector()
+	collectors = append(collectors, &collector.Collector{
+		Name:          ldp.Name(),
+		PromCollector: ldp,
+		Errors:        ldp,
+		CLIHelper:     ldp,
+	})
+	mpls := collector.NewMPLSCollector()
+	collectors = append(collectors, &collector.Collector{
+		Name:          mpls.Name(),
+		PromCollector: mpls,
+		Errors:        mpls,
+		CLIHelper:     mpls,
+	})
+	sr := collector.NewSRCollector()
+	collectors = append(collectors, &collector.Collector{
+		Name:          sr.Name(),
+		PromCollector: sr,
+		Errors:        sr,
+		CLIHelper:     sr,
+	})
+	srv6 := collector.NewSRv6Collector()
+	collectors = append(collectors, &collector.Collector{
+		Name:          srv6.Name(),
+		PromCollector: srv6,
+		Errors:        srv6,
+		CLIHelper:     srv6,
+	})
+	srte := collector.NewSRTECollector()
+	collectors = append(collectors, &collector.Collector{
+		Name:          srte.Name(),
+		PromCollector: srte,
+		Errors:        srte,
+		CLIHelper:     srte,
+	})
+	route := collector.NewRouteCollector()
+	collectors = append(collectors, &collector.Collector{
+		Name:          route.Name(),
+		PromCollector: route,
+		Errors:        route,
+		CLIHelper:     route,
+	})
+	iface := collector.NewInterfaceCollector()
+	collectors = append(collectors, &collector.Collector{
+		Name:          iface.Name(),
+		PromCollector: iface,
+		Errors:        iface,
+		CLIHelper:     iface,
+	})
+	nexthopGroup := collector.NewNexthopGroupCollector()
+	collectors = append(collectors, &collector.Collector{
+		Name:          nexthopGroup.Name(),
+		PromCollector: nexthopGroup,
+		Errors:        nexthopGroup,
+		CLIHelper:     nexthopGroup,
+	})
+	memory := collector.NewMemoryCollector()
+	collectors = append(collectors, &collector.Collector{
+		Name:          memory.Name(),
+		PromCollector: memory,
+		Errors:        memory,
+		CLIHelper:     memory,
+	})
+	thread := collector.NewThreadCollector()
+	collectors = append(collectors, &collector.Collector{
+		Name:          thread.Name(),
+		PromCollector: thread,
+		Errors:        thread,
+		CLIHelper:     thread,
+	})
+	zebra := collector.NewZebraCollector()
+	collectors = append(collectors, &collector.Collector{
+		Name:          zebra.Name(),
+		PromCollector: zebra,
+		Errors:        zebra,
+		CLIHelper:     zebra,
+	})
+	daemon := collector.NewDaemonCollector()
+	collectors = append(collectors, &collector.Collector{
+		Name:          daemon.Name(),
+		PromCollector: daemon,
+		Errors:        daemon,
+		CLIHelper:     daemon,
+	})
+	watchfrr := collector.NewWatchfrrCollector()
+	collectors = append(collectors, &collector.Collector{
+		Name:          watchfrr.Name(),
+		PromCollector: watchfrr,
+		Errors:        watchfrr,
+		CLIHelper:     watchfrr,
+	})
+	frrVersion := collector.NewVersionCollector()
+	collectors = append(collectors, &collector.Collector{
+		Name:          frrVersion.Name(),
+		PromCollector: frrVersion,
+		Errors:        frrVersion,
+		CLIHelper:     frrVersion,
+	})
+	evpn := collector.NewEVPNCollector()
+	collectors = append(collectors, &collector.Collector{
+		Name:          evpn.Name(),
+		PromCollector: evpn,
+		Errors:        evpn,
+		CLIHelper:     evpn,
+	})
+	routerID := collector.NewRouterIDCollector()
+	collectors = append(collectors, &collector.Collector{
+		Name:          routerID.Name(),
+		PromCollector: routerID,
+		Errors:        routerID,
+		CLIHelper:     routerID,
+	})
+	config := collector.NewConfigCollector()
+	collectors = append(collectors, &collector.Collector{
+		Name:          config.Name(),
+		PromCollector: config,
+		Errors:        config,
+		CLIHelper:     config,
+	})
+	static := collector.NewStaticCollector()
+	collectors = append(collectors, &collector.Collector{
+		Name:          static.Name(),
+		PromCollector: static,
+		Errors:        static,
+		CLIHelper:     static,
+	})
+	pbr := collector.NewPBRCollector()
+	collectors = append(collectors, &collector.Collector{
+		Name:          pbr.Name(),
+		PromCollector: pbr,
+		Errors:        pbr,
+		CLIHelper:     pbr,
+	})
+	nhrp := collector.NewNHRPCollector()
+	collectors = append(collectors, &collector.Collector{
+		Name:          nhrp.Name(),
+		PromCollector: nhrp,
+		Errors:        nhrp,
+		CLIHelper:     nhrp,
+	})
+	rip := collector.NewRIPCollector()
+	collectors = append(collectors, &collector.Collector{
+		Name:          rip.Name(),
+		PromCollector: rip,
+		Errors:        rip,
+		CLIHelper:     rip,
+	})
+	ripng := collector.NewRIPngCollector()
+	collectors = append(collectors, &collector.Collector{
+		Name:          ripng.Name(),
+		PromCollector: ripng,
+		Errors:        ripng,
+		CLIHelper:     ripng,
+	})
 }
 
 func handler(w http.ResponseWriter, r *http.Request) {